@@ -79,6 +79,27 @@ func Run(content embed.FS) {
 					},
 				},
 			},
+			{
+				Name:    "history",
+				Aliases: []string{"hi"},
+				Usage:   "Print the history of finished file operations, most recent first",
+				Action: func(_ context.Context, c *cli.Command) error {
+					return printProcessHistory(int(c.Int("limit")), c.Bool("json"))
+				},
+				Flags: []cli.Flag{
+					&cli.IntFlag{
+						Name:    "limit",
+						Aliases: []string{"n"},
+						Usage:   "Print only the given count of most recent entries, 0 for all of them",
+						Value:   defaultHistoryLimit,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Print the entries as a JSON array",
+						Value: false,
+					},
+				},
+			},
 		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
@@ -123,6 +144,18 @@ func Run(content embed.FS) {
 				Usage:   "On trying to open any file, superfile will write to its path to this file, and exit",
 				Value:   "", // Default to the blank string indicating non-usage of flag
 			},
+			&cli.StringFlag{
+				Name:    "command",
+				Aliases: []string{"cmd"},
+				Usage:   "Run ';' separated SPF prompt commands at startup, like \"split; sort size desc\"",
+				Value:   "",
+			},
+			&cli.StringFlag{
+				Name:    "script",
+				Aliases: []string{"s"},
+				Usage:   "Run the SPF prompt commands of a file at startup, before those of --command",
+				Value:   "",
+			},
 		},
 		Action: spfAppAction,
 	}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/fatih/color"
+
+	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+const defaultHistoryLimit = 20
+
+// printProcessHistory prints the last limit finished processes, most recent
+// first. All of them are printed if limit is 0
+func printProcessHistory(limit int, asJSON bool) error {
+	entries, err := processbar.ReadHistory(variable.ProcessHistoryFile)
+	if err != nil {
+		return err
+	}
+	slices.Reverse(entries)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if entries == nil {
+			entries = []processbar.HistoryEntry{}
+		}
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No finished process in history yet")
+		return nil
+	}
+	// Times are shown like in superfile, with the time format of the config
+	// file. The default one is used when it can't be read
+	if err = common.PopulateConfigFromFile(variable.ConfigFile); err != nil {
+		slog.Debug("Cannot read config file for history", "error", err)
+	}
+	times := make([]string, len(entries))
+	timeWidth := 0
+	for i, entry := range entries {
+		times[i] = common.FormatTime(entry.EndTime.Local())
+		timeWidth = max(timeWidth, ansi.StringWidth(times[i]))
+	}
+
+	timeColor := color.New(color.FgCyan)
+	errorColor := color.New(color.FgRed)
+	for i, entry := range entries {
+		stateColor := color.New(color.FgGreen)
+		if entry.State != processbar.Successful.String() {
+			stateColor = errorColor
+		}
+		fmt.Printf("%s  %-10s  %-14s  %s\n",
+			timeColor.Sprintf("%-*s", timeWidth, times[i]),
+			stateColor.Sprint(entry.State), entry.Operation, entry.Name)
+		fmt.Printf("    %s\n", entry.Details())
+		if entry.Error != "" {
+			fmt.Printf("    %s\n", errorColor.Sprint(entry.Error))
+		}
+	}
+	return nil
+}
@@ -46,11 +46,16 @@ var (
 	PinnedFile       = filepath.Join(SuperFileDataDir, "pinned.json")
 	ToggleDotFile    = filepath.Join(SuperFileDataDir, "toggleDotFile")
 	ToggleFooter     = filepath.Join(SuperFileDataDir, "toggleFooter")
+	MacrosFile       = filepath.Join(SuperFileDataDir, "macros.json")
 
 	// StateDir files
 	LogFile     = filepath.Join(SuperFileStateDir, "superfile.log")
 	LastDirFile = filepath.Join(SuperFileStateDir, "lastdir")
 
+	ProcessHistoryFile     = filepath.Join(SuperFileStateDir, "process_history.jsonl")
+	SPFPromptHistoryFile   = filepath.Join(SuperFileStateDir, "spf_prompt_history")
+	ShellPromptHistoryFile = filepath.Join(SuperFileStateDir, "shell_prompt_history")
+
 	// Trash Directories
 	DarwinTrashDirectory = filepath.Join(HomeDir, ".Trash")
 
@@ -70,6 +75,10 @@ var (
 	// opened, before exiting
 	ChooserFile = ""
 
+	// SPF prompt commands of --command, and script file of --script, run at startup
+	StartupCommand = ""
+	StartupScript  = ""
+
 	// Other state variables
 	FixHotkeys    = false
 	FixConfigFile = false
@@ -111,6 +120,15 @@ func UpdateVarFromCliArgs(c *cli.Command) {
 	// It could be non existent. We are writing to the file. If file doesn't exists, we would attempt to create it.
 	SetChooserFile(c.String("chooser-file"))
 
+	scriptArg := c.String("script")
+	if scriptArg != "" {
+		if _, err := os.Stat(scriptArg); err != nil {
+			utils.PrintfAndExitf("Error: While reading script file '%s' from argument : %v", scriptArg, err)
+		}
+		StartupScript = scriptArg
+	}
+	StartupCommand = c.String("command")
+
 	FixHotkeys = c.Bool("fix-hotkeys")
 	FixConfigFile = c.Bool("fix-config-file")
 	PrintLastDir = c.Bool("print-last-dir")
@@ -16,6 +16,8 @@ package icon
 //   - UI elements (Cursor, Browser, Select, etc.)
 //   - Status indicators (Error, Warn, Done, InOperation)
 //   - Navigation and sorting (Directory, Search, SortAsc, SortDesc)
+//   - Git repository information (GitBranch, GitAhead, GitBehind)
+//   - Tree view (Tree, TreeExpanded, TreeCollapsed, TreeGuide)
 func InitIcon(nerdfont bool, directoryIconColor string) {
 	// Make sure that these alternatives are ASCII characters only.
 	// Dont place any special unicode characters here.
@@ -58,6 +60,13 @@ func InitIcon(nerdfont bool, directoryIconColor string) {
 		Terminal = ""
 		Pinned = ""
 		Disk = ""
+		GitBranch = ""
+		GitAhead = "+"
+		GitBehind = "-"
+		Tree = "T"
+		TreeExpanded = "v"
+		TreeCollapsed = ">"
+		TreeGuide = "|"
 	}
 
 	if directoryIconColor == "" {
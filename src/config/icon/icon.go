@@ -45,6 +45,13 @@ var (
 	Terminal        = "\ue795"     // Printable Rune : ""
 	Pinned          = "\U000f0403" // Printable Rune : "󰐃"
 	Disk            = "\U000f11f0" // Printable Rune : "󱇰"
+	GitBranch       = "\ue725"     // Printable Rune : ""
+	GitAhead        = "\uf062"     // Printable Rune : ""
+	GitBehind       = "\uf063"     // Printable Rune : ""
+	Tree            = "\U000f0645" // Printable Rune : "󰙅"
+	TreeExpanded    = "\ueab4"     // Printable Rune : ""
+	TreeCollapsed   = "\ueab6"     // Printable Rune : ""
+	TreeGuide       = "\u2502"     // Printable Rune : "│"
 
 )
 
@@ -70,6 +70,8 @@ type ConfigType struct {
 	DirEditor string `toml:"dir_editor" comment:"\nThe editor directories will be opened with. (Leave blank to use the default editors)."`
 	// The table (map) for editor by file extension
 	OpenWith map[string]string `toml:"open_with" comment:"\nCustom open commands by file extension."`
+	// The table of user-defined commands by name
+	Commands map[string]CustomCommand `toml:"commands" comment:"\nUser-defined commands."`
 
 	AutoCheckUpdate        bool   `toml:"auto_check_update" comment:"\nAuto check for update"`
 	CdOnQuit               bool   `toml:"cd_on_quit" comment:"\nCd on quit (For more details, please check out https://superfile.dev/configure/superfile-config/#cd_on_quit)"`
@@ -77,17 +79,30 @@ type ConfigType struct {
 	ShowImagePreview       bool   `toml:"show_image_preview" comment:"\nWhether to show image preview."`
 	ShowPanelFooterInfo    bool   `toml:"show_panel_footer_info" comment:"\nWhether to show additional footer info for file panel."`
 	DefaultDirectory       string `toml:"default_directory" comment:"\nThe path of the first file panel when superfile is opened."`
+	StartupScript          string `toml:"startup_script" comment:"\nPath of a file of SPF prompt commands run at startup, unless --command or --script is given (Leave blank to disable)."`
 	FileSizeUseSI          bool   `toml:"file_size_use_si" comment:"\nDisplay file sizes using powers of 1000 (kB, MB, GB) instead of powers of 1024 (KiB, MiB, GiB)."`
+	TimeFormat             string `toml:"time_format" comment:"\nFormat of the timestamps, as a Go layout like \"2006-01-02 15:04\" or a strftime one like \"%Y-%m-%d %H:%M\" (Leave blank for the default)."`
+	RelativeTimeHours      int    `toml:"relative_time_hours" comment:"\nTimestamps younger than this many hours are shown relative, like \"3m ago\" or \"yesterday\" (0: disabled)."`
 	DefaultSortType        int    `toml:"default_sort_type" comment:"\nDefault sort type (0: Name, 1: Size, 2: Date Modified, 3: Type, 4: Natural)."`
 	SortOrderReversed      bool   `toml:"sort_order_reversed" comment:"\nDefault sort order (false: Ascending, true: Descending)."`
 	CaseSensitiveSort      bool   `toml:"case_sensitive_sort" comment:"\nCase sensitive sort by name (capital \"B\" comes before \"a\" if true)."`
 	ShellCloseOnSuccess    bool   `toml:"shell_close_on_success" comment:"\nWhether to close the shell on successful command execution."`
+	PromptHistorySize      int    `toml:"prompt_history_size" comment:"\nCount of commands kept in the history of each prompt mode (0: no history)."`
 	Debug                  bool   `toml:"debug" comment:"\nWhether to enable debug mode."`
 	// IgnoreMissingFields controls whether warnings about missing TOML fields are suppressed.
 	IgnoreMissingFields   bool `toml:"ignore_missing_fields" comment:"\nWhether to ignore warnings about missing fields in the config file."`
 	PageScrollSize        int  `toml:"page_scroll_size" comment:"\nNumber of lines to scroll for PgUp/PgDown keys (0: full page, default behavior)."`
 	FilePanelExtraColumns int  `toml:"file_panel_extra_columns" comment:"\nCount of extra columns in file panel in addition to file name. When option equal 0 then feature is disabled."`
 	FilePanelNamePercent  int  `toml:"file_panel_name_percent" comment:"\nPercentage of file panel width allocated to file names (25-100). Higher values give more space to names, less to extra columns."`
+	// Extra columns in display order. When empty, file_panel_extra_columns picks from the default ones
+	FilePanelColumns []FilePanelColumn `toml:"file_panel_columns" comment:"\nOrdered extra columns of the file panel, like [{ type = \"size\" }, { type = \"owner\", width = 10, align = \"left\" }]. Overrides file_panel_extra_columns when not empty."`
+	ShowGitBranch    bool              `toml:"show_git_branch" comment:"\nWhether to show the git branch, with the commits ahead and behind its upstream, in the top bar of file panels in repositories."`
+
+	ProcessRetentionCount   int  `toml:"process_retention_count" comment:"\nMaximum count of finished processes kept in the process bar (0: keep all of them)."`
+	ProcessRetentionMinutes int  `toml:"process_retention_minutes" comment:"\nMinutes after which finished processes are removed from the process bar (0: never)."`
+	ProcessHistory          bool `toml:"process_history" comment:"\nWhether to save finished processes to the history file."`
+	ContinueOnError         bool `toml:"continue_on_error" comment:"\nWhether copy and move operations go on with the other files after an error."`
+	SearchIncludeIgnored    bool `toml:"search_include_ignored" comment:"\nWhether the recursive search lists the files ignored by git."`
 
 	Nerdfont                bool     `toml:"nerdfont" comment:"\n================   Style =================\n\n If you don't have or don't want Nerdfont installed you can turn this off"`
 	ShowSelectIcons         bool     `toml:"show_select_icons" comment:"\nShow checkbox icons in select mode (requires nerdfont)"`
@@ -114,6 +129,70 @@ type ConfigType struct {
 	ZoxideSupport     bool `toml:"zoxide_support" comment:"Zoxide support for the fast navigation"`
 }
 
+// FilePanelColumn is an extra column of the file panel, shown after the name
+type FilePanelColumn struct {
+	Type string `toml:"type"`
+	// Width in cells, the default width of the type if 0
+	Width int `toml:"width"`
+	// "left", "center" or "right", the default alignment of the type if empty
+	Align string `toml:"align"`
+}
+
+// Types of the file panel columns
+const (
+	ColumnSize          = "size"
+	ColumnModifyTime    = "modify_time"
+	ColumnPermission    = "permission"
+	ColumnOwner         = "owner"
+	ColumnGroup         = "group"
+	ColumnCreateTime    = "create_time"
+	ColumnAccessTime    = "access_time"
+	ColumnExtension     = "extension"
+	ColumnInode         = "inode"
+	ColumnLinkCount     = "link_count"
+	ColumnSymlinkTarget = "symlink_target"
+	ColumnDirSize       = "dir_size"
+	ColumnMimeType      = "mime_type"
+	ColumnGitStatus     = "git_status"
+
+	ColumnAlignLeft   = "left"
+	ColumnAlignCenter = "center"
+	ColumnAlignRight  = "right"
+)
+
+// FilePanelColumnTypes returns the column types allowed in file_panel_columns
+func FilePanelColumnTypes() []string {
+	return []string{
+		ColumnSize, ColumnModifyTime, ColumnPermission, ColumnOwner, ColumnGroup, ColumnCreateTime,
+		ColumnAccessTime, ColumnExtension, ColumnInode, ColumnLinkCount, ColumnSymlinkTarget,
+		ColumnDirSize, ColumnMimeType, ColumnGitStatus,
+	}
+}
+
+// CustomCommand is a user-defined command of the [commands] table. Exactly one
+// of Run and SPF is set
+type CustomCommand struct {
+	// Shell command, with %f, %s, %d and %D placeholders
+	Run string `toml:"run"`
+	// SPF prompt command, with the same placeholders
+	SPF         string `toml:"spf"`
+	Description string `toml:"description"`
+	// Optional hotkey running the command in normal and select mode
+	Hotkey       string `toml:"hotkey"`
+	Confirm      bool   `toml:"confirm"`
+	Background   bool   `toml:"background"`
+	RefreshAfter bool   `toml:"refresh_after"`
+	OpenOutput   bool   `toml:"open_output"`
+}
+
+// GetDescription returns the description, or the command itself without one
+func (c CustomCommand) GetDescription() string {
+	if c.Description != "" {
+		return c.Description
+	}
+	return c.Run + c.SPF
+}
+
 // GetIgnoreMissingFields reports whether warnings about missing TOML fields should be ignored.
 func (c *ConfigType) GetIgnoreMissingFields() bool {
 	return c.IgnoreMissingFields
@@ -154,6 +233,8 @@ type HotkeysType struct {
 
 	ExtractFile  []string `toml:"extract_file" comment:"compress and extract"`
 	CompressFile []string `toml:"compress_file"`
+	AddToArchive []string `toml:"add_to_archive"`
+	TestArchive  []string `toml:"test_archive"`
 
 	OpenFileWithEditor             []string `toml:"open_file_with_editor" comment:"editor"`
 	OpenCurrentDirectoryWithEditor []string `toml:"open_current_directory_with_editor"`
@@ -161,6 +242,7 @@ type HotkeysType struct {
 	PinnedDirectory []string `toml:"pinned_directory" comment:"other"`
 	ToggleDotFile   []string `toml:"toggle_dot_file"`
 	ChangePanelMode []string `toml:"change_panel_mode"`
+	ToggleTreeView  []string `toml:"toggle_tree_view"`
 	OpenHelpMenu    []string `toml:"open_help_menu"`
 	OpenCommandLine []string `toml:"open_command_line"`
 	OpenSPFPrompt   []string `toml:"open_spf_prompt"`
@@ -169,13 +251,34 @@ type HotkeysType struct {
 	CopyPath []string `toml:"copy_path"`
 	CopyPWD  []string `toml:"copy_present_working_directory"`
 
-	ToggleFooter []string `toml:"toggle_footer"`
+	ToggleFooter  []string `toml:"toggle_footer"`
+	CancelProcess []string `toml:"cancel_process"`
+
+	ClearFinishedProcesses []string `toml:"clear_finished_processes"`
+	OpenProcessHistory     []string `toml:"open_process_history"`
+	RetryFailedItems       []string `toml:"retry_failed_items"`
+	CopyErrorList          []string `toml:"copy_error_list"`
+
+	RecordMacro []string `toml:"record_macro"`
+	ReplayMacro []string `toml:"replay_macro"`
+
+	GitStage   []string `toml:"git_stage" comment:"git"`
+	GitUnstage []string `toml:"git_unstage"`
+	GitDiscard []string `toml:"git_discard"`
+	GitDiff    []string `toml:"git_diff"`
+	GitCommit  []string `toml:"git_commit"`
 
 	ConfirmTyping []string `toml:"confirm_typing" comment:"=================================================================================================\nTyping hotkeys (can conflict with all hotkeys)"`
 	CancelTyping  []string `toml:"cancel_typing"`
 
-	ParentDirectory []string `toml:"parent_directory" comment:"=================================================================================================\nNormal mode hotkeys (can conflict with other modes, cannot conflict with global hotkeys)"`
-	SearchBar       []string `toml:"search_bar"`
+	ParentDirectory        []string `toml:"parent_directory" comment:"=================================================================================================\nNormal mode hotkeys (can conflict with other modes, cannot conflict with global hotkeys)"`
+	SearchBar              []string `toml:"search_bar"`
+	JumpToContainingFolder []string `toml:"jump_to_containing_folder"`
+
+	ToggleSearchMode []string `toml:"toggle_search_mode" comment:"search bar (can conflict with all hotkeys except typing hotkeys)"`
+	TogglePinFilter  []string `toml:"toggle_pin_filter"`
+	RecursiveSearch  []string `toml:"recursive_search"`
+	ContentSearch    []string `toml:"content_search"`
 
 	FilePanelSelectModeItemsSelectDown []string `toml:"file_panel_select_mode_items_select_down" comment:"=================================================================================================\nSelect mode hotkeys (can conflict with other modes, cannot conflict with global hotkeys)"`
 	FilePanelSelectModeItemsSelectUp   []string `toml:"file_panel_select_mode_items_select_up"`
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
+	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/x/ansi"
 	"github.com/pelletier/go-toml/v2"
@@ -86,6 +90,36 @@ func ValidateConfig(c *ConfigType) error {
 		)
 	}
 
+	if err := validateFilePanelColumns(c.FilePanelColumns); err != nil {
+		return err
+	}
+
+	if err := validateTimeFormat(c.TimeFormat); err != nil {
+		return errors.New(LoadConfigError("time_format", fmt.Sprintf("Invalid time format: %v.", err)))
+	}
+
+	if c.RelativeTimeHours < 0 {
+		return errors.New(LoadConfigError("relative_time_hours", "Relative time hours cannot be negative."))
+	}
+
+	if c.PromptHistorySize < 0 {
+		return errors.New(LoadConfigError("prompt_history_size", "Prompt history size cannot be negative."))
+	}
+
+	if c.ProcessRetentionCount < 0 {
+		return errors.New(LoadConfigError("process_retention_count", "Process retention count cannot be negative."))
+	}
+
+	if c.ProcessRetentionMinutes < 0 {
+		return errors.New(
+			LoadConfigError("process_retention_minutes", "Process retention minutes cannot be negative."),
+		)
+	}
+
+	if err := validateCustomCommands(c.Commands); err != nil {
+		return err
+	}
+
 	if ansi.StringWidth(c.BorderTop) != 1 {
 		return errors.New(LoadConfigError("border_top", "Border character must be exactly one cell wide."))
 	}
@@ -93,6 +127,27 @@ func ValidateConfig(c *ConfigType) error {
 	return validateBorders(c)
 }
 
+func validateFilePanelColumns(columns []FilePanelColumn) error {
+	for _, column := range columns {
+		if !slices.Contains(FilePanelColumnTypes(), column.Type) {
+			return errors.New(LoadConfigError("file_panel_columns",
+				fmt.Sprintf("Unsupported column type '%s'. Allowed values are: %s.",
+					column.Type, strings.Join(FilePanelColumnTypes(), ", "))))
+		}
+		if column.Width < 0 || column.Width > MaxFilePanelColumnWidth {
+			return errors.New(LoadConfigError("file_panel_columns",
+				fmt.Sprintf("Width of the %s column must be 0-%d.", column.Type, MaxFilePanelColumnWidth)))
+		}
+		switch column.Align {
+		case "", ColumnAlignLeft, ColumnAlignCenter, ColumnAlignRight:
+		default:
+			return errors.New(LoadConfigError("file_panel_columns",
+				fmt.Sprintf("Alignment of the %s column must be left, center or right.", column.Type)))
+		}
+	}
+	return nil
+}
+
 func validateBorders(c *ConfigType) error {
 	if ansi.StringWidth(c.BorderBottom) != 1 {
 		return errors.New(LoadConfigError("border_bottom", "Border character must be exactly one cell wide."))
@@ -125,6 +180,24 @@ func validateBorders(c *ConfigType) error {
 	return nil
 }
 
+func validateCustomCommands(commands map[string]CustomCommand) error {
+	for _, name := range slices.Sorted(maps.Keys(commands)) {
+		command := commands[name]
+		field := "commands." + name
+		switch {
+		case name == "" || strings.ContainsFunc(name, unicode.IsSpace):
+			return errors.New(LoadConfigError(field, "Command name must be a single word."))
+		case (command.Run == "") == (command.SPF == ""):
+			return errors.New(LoadConfigError(field, "Command must have exactly one of 'run' and 'spf'."))
+		case command.SPF != "" && (command.Background || command.OpenOutput):
+			return errors.New(
+				LoadConfigError(field, "'background' and 'open_output' only apply to 'run' commands."),
+			)
+		}
+	}
+	return nil
+}
+
 // Load keybinds from the hotkeys file. Compares the content
 // with the default values and modify the hotkeys if the FixHotkeys flag is on.
 func LoadHotkeysFile(ignoreMissingFields bool) {
@@ -183,6 +256,52 @@ func LoadHotkeysFile(ignoreMissingFields bool) {
 			)
 		}
 	}
+
+	if err := validateCustomCommandHotkeys(&Hotkeys, Config.Commands); err != nil {
+		utils.PrintlnAndExit(err.Error())
+	}
+}
+
+// Hotkeys of user-defined commands must not be used by any other hotkey, except
+// by the typing and search bar ones that are only active while typing
+func validateCustomCommandHotkeys(hotkeys *HotkeysType, commands map[string]CustomCommand) error {
+	typingFields := []string{"ConfirmTyping", "CancelTyping", "ToggleSearchMode", "TogglePinFilter",
+		"RecursiveSearch", "ContentSearch"}
+	used := make(map[string]string)
+	val := reflect.ValueOf(*hotkeys)
+	for i := range val.NumField() {
+		field := val.Type().Field(i)
+		if slices.Contains(typingFields, field.Name) {
+			continue
+		}
+		keys, _ := val.Field(i).Interface().([]string)
+		for _, key := range keys {
+			used[key] = field.Tag.Get("toml")
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(commands)) {
+		key := commands[name].Hotkey
+		if key == "" {
+			continue
+		}
+		if other, ok := used[key]; ok {
+			return errors.New(LoadHotkeysError("commands."+name,
+				fmt.Sprintf("Hotkey '%s' is already used by '%s'.", key, other)))
+		}
+		used[key] = "commands." + name
+	}
+	return nil
+}
+
+// CustomCommandForHotkey returns the name of the user-defined command bound to key
+func CustomCommandForHotkey(key string) (string, bool) {
+	for name, command := range Config.Commands {
+		if command.Hotkey != "" && command.Hotkey == key {
+			return name, true
+		}
+	}
+	return "", false
 }
 
 // LoadThemeFile : Load configurations from theme file into &theme
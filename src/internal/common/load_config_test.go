@@ -0,0 +1,109 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCustomCommands(t *testing.T) {
+	testdata := []struct {
+		name     string
+		commands map[string]CustomCommand
+		valid    bool
+	}{
+		{"No commands", nil, true},
+		{"Shell command", map[string]CustomCommand{"todo": {Run: "grep TODO %s", Background: true}}, true},
+		{"SPF command", map[string]CustomCommand{"backup": {SPF: "copy ~/backup"}}, true},
+		{"Name with space", map[string]CustomCommand{"my cmd": {Run: "ls"}}, false},
+		{"Empty name", map[string]CustomCommand{"": {Run: "ls"}}, false},
+		{"Neither run nor spf", map[string]CustomCommand{"x": {Hotkey: "ctrl+t"}}, false},
+		{"Both run and spf", map[string]CustomCommand{"x": {Run: "ls", SPF: "split"}}, false},
+		{"Background SPF command", map[string]CustomCommand{"x": {SPF: "split", Background: true}}, false},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomCommands(tt.commands)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateFilePanelColumns(t *testing.T) {
+	testdata := []struct {
+		name    string
+		columns []FilePanelColumn
+		valid   bool
+	}{
+		{"No columns", nil, true},
+		{"Types only", []FilePanelColumn{{Type: ColumnSize}, {Type: ColumnMimeType}}, true},
+		{"Width and alignment", []FilePanelColumn{{Type: ColumnOwner, Width: 12, Align: ColumnAlignCenter}}, true},
+		{"Unknown type", []FilePanelColumn{{Type: "color"}}, false},
+		{"Missing type", []FilePanelColumn{{Width: 10}}, false},
+		{"Negative width", []FilePanelColumn{{Type: ColumnInode, Width: -1}}, false},
+		{"Width too large", []FilePanelColumn{{Type: ColumnInode, Width: MaxFilePanelColumnWidth + 1}}, false},
+		{"Unknown alignment", []FilePanelColumn{{Type: ColumnGroup, Align: "middle"}}, false},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFilePanelColumns(tt.columns)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCustomCommandHotkeys(t *testing.T) {
+	hotkeys := HotkeysType{
+		Quit:             []string{"q", "esc"},
+		CopyItems:        []string{"ctrl+c"},
+		ConfirmTyping:    []string{"enter"},
+		ToggleSearchMode: []string{"tab"},
+		TogglePinFilter:  []string{"ctrl+p"},
+		RecursiveSearch:  []string{"ctrl+f"},
+		ContentSearch:    []string{"ctrl+g"},
+	}
+
+	testdata := []struct {
+		name     string
+		commands map[string]CustomCommand
+		conflict string
+	}{
+		{"Free hotkeys", map[string]CustomCommand{
+			"a": {Run: "ls", Hotkey: "ctrl+t"},
+			"b": {Run: "ls"},
+			"c": {Run: "ls"},
+		}, ""},
+		{"Typing hotkeys can be used", map[string]CustomCommand{"a": {Run: "ls", Hotkey: "enter"}}, ""},
+		{"Search bar hotkeys can be used", map[string]CustomCommand{
+			"a": {Run: "ls", Hotkey: "tab"},
+			"b": {Run: "ls", Hotkey: "ctrl+p"},
+			"c": {Run: "ls", Hotkey: "ctrl+f"},
+			"d": {Run: "ls", Hotkey: "ctrl+g"},
+		}, ""},
+		{"Used by a hotkey", map[string]CustomCommand{"a": {Run: "ls", Hotkey: "esc"}}, "quit"},
+		{"Used by another command", map[string]CustomCommand{
+			"a": {Run: "ls", Hotkey: "ctrl+t"},
+			"b": {Run: "ls", Hotkey: "ctrl+t"},
+		}, "commands.a"},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomCommandHotkeys(&hotkeys, tt.commands)
+			if tt.conflict == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.conflict)
+		})
+	}
+}
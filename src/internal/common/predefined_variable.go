@@ -27,6 +27,16 @@ const (
 	PermanentDeleteWarnContent = "This operation cannot be undone and your data will be completely lost."
 )
 
+const (
+	CustomCommandWarnTitle = "Are you sure you want to run %s"
+)
+
+const (
+	GitDiscardWarnTitle   = "Are you sure you want to discard the changes"
+	GitDiscardWarnContent = "Unstaged changes of the selected items will be lost. Staged changes and untracked files are kept."
+	GitActionErrorTitle   = "Cannot run git action"
+)
+
 const (
 	MinimumHeight = 24
 	MinimumWidth  = 60
@@ -64,6 +74,9 @@ var (
 	FilePreviewImageConversionErrorText     string
 	FilePreviewBatNotInstalledText          string
 	FilePreviewThumbnailGenerationErrorText string
+	FilePreviewArchiveEntryTooLargeText     string
+	FilePreviewArchiveUnreadableText        string
+	FilePreviewNoGitChangesText             string
 
 	CheckboxChecked        string
 	CheckboxCheckedFocused string
@@ -166,6 +179,12 @@ func LoadPrerenderedVariables() {
 		"'bat' is not installed or not found")
 	FilePreviewThumbnailGenerationErrorText = wrapFilePreviewErrorMsg(
 		"Thumbnail generation failed")
+	FilePreviewArchiveEntryTooLargeText = wrapFilePreviewErrorMsg(
+		"File in archive is too large to preview")
+	FilePreviewArchiveUnreadableText = wrapFilePreviewErrorMsg(
+		"Cannot read archive")
+	FilePreviewNoGitChangesText = wrapFilePreviewErrorMsg(
+		"No git changes")
 
 	CheckboxChecked = FilePanelSelectBoxStyle.
 		Foreground(FilePanelBorderColor).
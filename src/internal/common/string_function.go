@@ -77,8 +77,10 @@ func TruncateMiddleText(text string, maxChars int, tails string) string {
 	return truncatedText
 }
 
+// FilePanelItemRenderWithIcon renders label after the icon of the file name
 func FilePanelItemRenderWithIcon(
 	name string,
+	label string,
 	width int,
 	isDir bool,
 	isLink bool,
@@ -95,7 +97,7 @@ func FilePanelItemRenderWithIcon(
 	}
 	return StringColorRender(lipgloss.Color(style.Color), bgColor).
 		Background(bgColor).Render(iconData) +
-		FilePanelItemRender(name, filenameWidth, isSelected, bgColor, lipgloss.Left)
+		FilePanelItemRender(label, filenameWidth, isSelected, bgColor, lipgloss.Left)
 }
 func FilePanelItemRender(data string,
 	width int,
@@ -0,0 +1,20 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// RunTestsWithConfig is the TestMain of packages whose tests need the default
+// config, hotkeys and styles
+func RunTestsWithConfig(m *testing.M) {
+	utils.SetRootLoggerToDiscarded()
+	if err := PopulateGlobalConfigs(); err != nil {
+		fmt.Printf("error while populating config, err : %v", err)
+		os.Exit(1)
+	}
+	m.Run()
+}
@@ -0,0 +1,164 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeFormat is used when time_format is empty
+const DefaultTimeFormat = "2006-01-02 15:04"
+
+const day = 24 * time.Hour
+
+// FormatTime formats t with the time_format config, or relative to now
+// when it is younger than relative_time_hours
+func FormatTime(t time.Time) string {
+	return formatTime(t, time.Now(), Config.TimeFormat, time.Duration(Config.RelativeTimeHours)*time.Hour)
+}
+
+func formatTime(t time.Time, now time.Time, format string, relativeLimit time.Duration) string {
+	if age := now.Sub(t); age >= 0 && age < relativeLimit {
+		return formatRelativeTime(t, now)
+	}
+	if format == "" {
+		format = DefaultTimeFormat
+	}
+	if isStrftimeFormat(format) {
+		return formatStrftime(t, format)
+	}
+	return t.Format(format)
+}
+
+func formatRelativeTime(t time.Time, now time.Time) string {
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", age/time.Minute)
+	case age < day:
+		return fmt.Sprintf("%dh ago", age/time.Hour)
+	}
+	// Count calendar days, so that anything from the previous date is "yesterday"
+	year, month, date := t.In(now.Location()).Date()
+	nowYear, nowMonth, nowDate := now.Date()
+	days := time.Date(nowYear, nowMonth, nowDate, 0, 0, 0, 0, time.UTC).
+		Sub(time.Date(year, month, date, 0, 0, 0, 0, time.UTC)) / day
+	if days <= 1 {
+		return "yesterday"
+	}
+	return fmt.Sprintf("%dd ago", days)
+}
+
+// Go layouts have no '%', so any format with one is taken as strftime
+func isStrftimeFormat(format string) bool {
+	return strings.Contains(format, "%")
+}
+
+func formatStrftime(t time.Time, format string) string {
+	var builder strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			builder.WriteByte(format[i])
+			continue
+		}
+		i++
+		value, ok := strftimeDirective(t, format[i])
+		if !ok {
+			// Unknown directives are kept as they are
+			value = format[i-1 : i+1]
+		}
+		builder.WriteString(value)
+	}
+	return builder.String()
+}
+
+func strftimeDirective(t time.Time, directive byte) (string, bool) {
+	switch directive {
+	case 'j':
+		return fmt.Sprintf("%03d", t.YearDay()), true
+	case 's':
+		return strconv.FormatInt(t.Unix(), 10), true
+	case '%':
+		return "%", true
+	}
+	if layout, ok := strftimeLayout(directive); ok {
+		return t.Format(layout), true
+	}
+	return "", false
+}
+
+// Go layout of the strftime directives that have one
+func strftimeLayout(directive byte) (string, bool) {
+	switch directive {
+	case 'a':
+		return "Mon", true
+	case 'A':
+		return "Monday", true
+	case 'b', 'h':
+		return "Jan", true
+	case 'B':
+		return "January", true
+	case 'c':
+		return time.ANSIC, true
+	case 'd':
+		return "02", true
+	case 'e':
+		return "_2", true
+	case 'D', 'x':
+		return "01/02/06", true
+	case 'F':
+		return "2006-01-02", true
+	case 'H':
+		return "15", true
+	case 'I':
+		return "03", true
+	case 'm':
+		return "01", true
+	case 'M':
+		return "04", true
+	case 'p':
+		return "PM", true
+	case 'r':
+		return "03:04:05 PM", true
+	case 'R':
+		return "15:04", true
+	case 'S':
+		return "05", true
+	case 'T', 'X':
+		return "15:04:05", true
+	case 'y':
+		return "06", true
+	case 'Y':
+		return "2006", true
+	case 'z':
+		return "-0700", true
+	case 'Z':
+		return "MST", true
+	default:
+		return "", false
+	}
+}
+
+// validateTimeFormat returns an error for the strftime directives that are not supported
+func validateTimeFormat(format string) error {
+	if !isStrftimeFormat(format) {
+		return nil
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 == len(format) {
+			return errors.New("format ends with a lone '%'")
+		}
+		i++
+		if _, ok := strftimeDirective(time.Time{}, format[i]); !ok {
+			return fmt.Errorf("unsupported directive '%%%c'", format[i])
+		}
+	}
+	return nil
+}
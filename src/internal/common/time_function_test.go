@@ -0,0 +1,48 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTime(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 14, 30, 0, 0, time.UTC)
+	testdata := []struct {
+		name          string
+		t             time.Time
+		format        string
+		relativeLimit time.Duration
+		expected      string
+	}{
+		{"Default format", now, "", 0, "2025-03-10 14:30"},
+		{"Go layout", now, "02 Jan 06 3:04PM", 0, "10 Mar 25 2:30PM"},
+		{"Strftime", now, "%Y/%m/%d %H:%M:%S", 0, "2025/03/10 14:30:00"},
+		{"Strftime names", now, "%a %e %b, %I %p", 0, "Mon 10 Mar, 02 PM"},
+		{"Strftime literal text", now, "Jan %d 100%%", 0, "Jan 10 100%"},
+		{"Strftime day of year", now, "%j", 0, "069"},
+		{"Relative disabled", now.Add(-time.Minute), "", 0, "2025-03-10 14:29"},
+		{"Just now", now.Add(-20 * time.Second), "", time.Hour, "just now"},
+		{"Minutes", now.Add(-3 * time.Minute), "", time.Hour, "3m ago"},
+		{"Hours", now.Add(-5 * time.Hour), "", 48 * time.Hour, "5h ago"},
+		{"Yesterday", now.Add(-30 * time.Hour), "", 48 * time.Hour, "yesterday"},
+		{"Days", now.Add(-4 * day), "", 7 * day, "4d ago"},
+		{"Older than the limit", now.Add(-2 * time.Hour), "", time.Hour, "2025-03-10 12:30"},
+		{"Future", now.Add(time.Hour), "", time.Hour, "2025-03-10 15:30"},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatTime(tt.t, now, tt.format, tt.relativeLimit))
+		})
+	}
+}
+
+func TestValidateTimeFormat(t *testing.T) {
+	for _, format := range []string{"", "2006-01-02", "%F %T", "%d%%", "%c"} {
+		assert.NoError(t, validateTimeFormat(format), format)
+	}
+	for _, format := range []string{"%Y-%Q", "%H:%M %"} {
+		assert.Error(t, validateTimeFormat(format), format)
+	}
+}
@@ -1,5 +1,11 @@
 package common
 
+import (
+	"fmt"
+
+	"github.com/yorukot/superfile/src/pkg/archive"
+)
+
 // Placeholder inteface for now, might later move 'model' type to commons and have
 // and add an execute(model) function to this
 type ModelAction interface {
@@ -15,10 +21,12 @@ func (n NoAction) String() string {
 
 type ShellCommandAction struct {
 	Command string
+	// Run as a process of the process bar, instead of waiting for the command
+	Background bool
 }
 
 func (s ShellCommandAction) String() string {
-	return "ShellCommandAction for command " + s.Command
+	return fmt.Sprintf("ShellCommandAction for command %s, background : %t", s.Command, s.Background)
 }
 
 // We could later move 'model' type to commons and have
@@ -44,3 +52,197 @@ type OpenPanelAction struct {
 func (o OpenPanelAction) String() string {
 	return "OpenPanelAction at " + o.Location
 }
+
+type CompressAction struct {
+	Sources []string
+	Target  string
+	Format  archive.Format
+	Level   int
+	// Empty for archives without encryption
+	Password string
+}
+
+func (c CompressAction) String() string {
+	return fmt.Sprintf("CompressAction of %d items to %s as %s", len(c.Sources), c.Target, c.Format)
+}
+
+// ExtractArchiveAction extracts an encrypted archive with the given password
+type ExtractArchiveAction struct {
+	ArchivePath string
+	Password    string
+	// Keep the password, so that it isn't asked again for this archive
+	RememberPassword bool
+}
+
+// Password is left out, as actions get logged
+func (e ExtractArchiveAction) String() string {
+	return fmt.Sprintf("ExtractArchiveAction of %s, remember password : %t", e.ArchivePath, e.RememberPassword)
+}
+
+// RetryFailedAction pastes again the items a process failed on
+type RetryFailedAction struct {
+	ProcessID string
+}
+
+func (r RetryFailedAction) String() string {
+	return "RetryFailedAction for process " + r.ProcessID
+}
+
+// CopyErrorListAction copies the errors of a process to the system clipboard
+type CopyErrorListAction struct {
+	ProcessID string
+}
+
+func (c CopyErrorListAction) String() string {
+	return "CopyErrorListAction for process " + c.ProcessID
+}
+
+// CreateItemAction creates a file or directory relative to the focused panel
+type CreateItemAction struct {
+	Name  string
+	IsDir bool
+}
+
+func (c CreateItemAction) String() string {
+	return fmt.Sprintf("CreateItemAction of %s, directory : %t", c.Name, c.IsDir)
+}
+
+// RenameAction renames the focused item of the focused panel
+type RenameAction struct {
+	NewName string
+}
+
+func (r RenameAction) String() string {
+	return "RenameAction to " + r.NewName
+}
+
+// SelectAction selects the items of the focused panel whose name matches Pattern
+type SelectAction struct {
+	Pattern string
+}
+
+func (s SelectAction) String() string {
+	return "SelectAction for pattern " + s.Pattern
+}
+
+type UnselectAction struct{}
+
+func (u UnselectAction) String() string {
+	return "UnselectAction"
+}
+
+type SortAction struct {
+	// Value of sortmodel.SortKind, like the default_sort_type config
+	Kind int
+	// Order is only changed when SetOrder is true
+	SetOrder bool
+	Reversed bool
+}
+
+func (s SortAction) String() string {
+	return fmt.Sprintf("SortAction by %d, set order : %t, reversed : %t", s.Kind, s.SetOrder, s.Reversed)
+}
+
+type SetHiddenFilesAction struct {
+	Show bool
+}
+
+func (s SetHiddenFilesAction) String() string {
+	return fmt.Sprintf("SetHiddenFilesAction, show : %t", s.Show)
+}
+
+type SetPreviewAction struct {
+	Show bool
+}
+
+func (s SetPreviewAction) String() string {
+	return fmt.Sprintf("SetPreviewAction, show : %t", s.Show)
+}
+
+// CopyToAction copies, or moves if Cut is set, the selected items of the focused
+// panel to Location. The focused item is used if nothing is selected
+type CopyToAction struct {
+	Location string
+	Cut      bool
+}
+
+func (c CopyToAction) String() string {
+	return fmt.Sprintf("CopyToAction to %s, cut : %t", c.Location, c.Cut)
+}
+
+// DeleteAction asks for a confirmation to delete the items of the focused panel
+type DeleteAction struct{}
+
+func (d DeleteAction) String() string {
+	return "DeleteAction"
+}
+
+// ExtractAction extracts the focused archive of the focused panel
+type ExtractAction struct{}
+
+func (e ExtractAction) String() string {
+	return "ExtractAction"
+}
+
+// CompressToAction compresses the selected items of the focused panel, or the
+// focused item, to an archive named Name. The format is taken from the extension
+type CompressToAction struct {
+	Name string
+}
+
+func (c CompressToAction) String() string {
+	return "CompressToAction to " + c.Name
+}
+
+// TogglePinAction pins the directory of the focused panel, or unpins it
+type TogglePinAction struct{}
+
+func (t TogglePinAction) String() string {
+	return "TogglePinAction"
+}
+
+type ClosePanelAction struct{}
+
+func (c ClosePanelAction) String() string {
+	return "ClosePanelAction"
+}
+
+type FocusPanelAction struct {
+	// Zero based index of the panel
+	Index int
+}
+
+func (f FocusPanelAction) String() string {
+	return fmt.Sprintf("FocusPanelAction to %d", f.Index)
+}
+
+// CustomCommandAction runs the user-defined command Name of the [commands] table
+type CustomCommandAction struct {
+	Name string
+	// Whether the user already confirmed the command, if it asks for confirmation
+	Confirmed bool
+}
+
+func (c CustomCommandAction) String() string {
+	return fmt.Sprintf("CustomCommandAction for %s, confirmed : %t", c.Name, c.Confirmed)
+}
+
+// ReplayMacroAction replays the keys recorded in a macro register Count times
+type ReplayMacroAction struct {
+	Register string
+	Count    int
+}
+
+func (r ReplayMacroAction) String() string {
+	return fmt.Sprintf("ReplayMacroAction for register %s, count : %d", r.Register, r.Count)
+}
+
+// GitCommitAction commits the staged changes of the repository at Root
+type GitCommitAction struct {
+	Root    string
+	Message string
+}
+
+func (g GitCommitAction) String() string {
+	return "GitCommitAction in " + g.Root
+}
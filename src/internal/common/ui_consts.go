@@ -27,6 +27,8 @@ const (
 	FileNameRatioMin = 25
 	FileNameRatioMax = 100
 
+	MaxFilePanelColumnWidth = 100
+
 	RequiredGradientColorCount = 2
 
 	// UI positioning
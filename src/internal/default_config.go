@@ -1,11 +1,20 @@
 package internal
 
 import (
+	"time"
+
 	zoxidelib "github.com/lazysegtree/go-zoxide"
 
 	"github.com/yorukot/superfile/src/internal/ui/helpmenu"
 
+	"github.com/yorukot/superfile/src/internal/ui/compressmodal"
 	"github.com/yorukot/superfile/src/internal/ui/filemodel"
+	"github.com/yorukot/superfile/src/internal/ui/gitcommitmodal"
+	"github.com/yorukot/superfile/src/internal/ui/historymodal"
+	"github.com/yorukot/superfile/src/internal/ui/macro"
+	"github.com/yorukot/superfile/src/internal/ui/outputmodal"
+	"github.com/yorukot/superfile/src/internal/ui/passwordmodal"
+	"github.com/yorukot/superfile/src/internal/ui/processdetailmodal"
 	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 
 	"github.com/yorukot/superfile/src/internal/ui/metadata"
@@ -30,7 +39,7 @@ func defaultModelConfig(toggleDotFile, toggleFooter, firstUse bool,
 	firstPanelPaths []string, zClient *zoxidelib.Client) *model {
 	return &model{
 		focusPanel:      nonePanelFocus,
-		processBarModel: processbar.New(),
+		processBarModel: newProcessBarModel(),
 		sidebarModel:    sidebar.New(),
 		fileMetaData:    metadata.New(),
 		fileModel:       filemodel.New(firstPanelPaths, toggleDotFile),
@@ -38,6 +47,13 @@ func defaultModelConfig(toggleDotFile, toggleFooter, firstUse bool,
 		promptModal:     prompt.DefaultModel(prompt.PromptMinHeight, prompt.PromptMinWidth),
 		zoxideModal:     zoxideui.DefaultModel(zoxideui.ZoxideMinHeight, zoxideui.ZoxideMinWidth, zClient),
 		sortModal:       sortmodel.New(),
+		compressModal:   compressmodal.New(),
+		passwordModal:   passwordmodal.New(),
+		gitCommitModal:  gitcommitmodal.New(),
+		historyModal:    historymodal.New(),
+		detailModal:     processdetailmodal.New(),
+		outputModal:     outputmodal.New(),
+		macroModel:      macro.New(),
 		zClient:         zClient,
 		modelQuitState:  notQuitting,
 		toggleFooter:    toggleFooter,
@@ -45,3 +61,14 @@ func defaultModelConfig(toggleDotFile, toggleFooter, firstUse bool,
 		hasTrash:        common.InitTrash(),
 	}
 }
+
+// Process bar keeping finished processes as per config. History is only saved
+// by the app, see InitialModel
+func newProcessBarModel() processbar.Model {
+	m := processbar.New()
+	m.SetRetention(processbar.Retention{
+		MaxFinished: common.Config.ProcessRetentionCount,
+		TTL:         time.Duration(common.Config.ProcessRetentionMinutes) * time.Minute,
+	})
+	return m
+}
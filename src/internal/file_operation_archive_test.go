@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func zipEntryNames(t *testing.T, archivePath string) []string {
+	t.Helper()
+	reader, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer reader.Close()
+	names := make([]string, 0, len(reader.File))
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestValidateAddToArchive(t *testing.T) {
+	dir := t.TempDir()
+	subDir := filepath.Join(dir, "sub")
+	utils.SetupDirectories(t, subDir)
+	zipPath := filepath.Join(subDir, "test.zip")
+	utils.SetupZip(t, zipPath, map[string]string{"a.txt": "content of a.txt"})
+	file := filepath.Join(dir, "file.txt")
+	utils.SetupFilesWithData(t, []byte("data"), file)
+
+	testdata := []struct {
+		name        string
+		target      string
+		sources     []string
+		expectedErr bool
+	}{
+		{"Add a file", zipPath, []string{file}, false},
+		{"Archive format that can't be written", filepath.Join(dir, "test.7z"), []string{file}, true},
+		{"Directory containing the archive", zipPath, []string{subDir}, true},
+		{"Archive into itself", zipPath, []string{zipPath}, true},
+		{"Entry of another archive", zipPath, []string{filepath.Join(zipPath, "a.txt")}, true},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAddToArchive(tt.target, tt.sources)
+			if tt.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddToArchive(t *testing.T) {
+	curTestDir := t.TempDir()
+	srcDir := t.TempDir()
+	archivePath := filepath.Join(curTestDir, "data.zip")
+	utils.SetupZip(t, archivePath, map[string]string{"a.txt": "content of a.txt", "dir/b.txt": "content of dir/b.txt"})
+	newDir := filepath.Join(srcDir, "dir")
+	utils.SetupDirectories(t, newDir)
+	utils.SetupFilesWithData(t, []byte("new"), filepath.Join(srcDir, "new.txt"), filepath.Join(newDir, "c.txt"))
+
+	m := defaultTestModel(curTestDir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	setFilePanelSelectedItemByLocation(t, m.getFocusedFilePanel(), archivePath)
+	m.clipboard.SetItems([]string{filepath.Join(srcDir, "new.txt"), newDir})
+
+	p.SendKey(common.Hotkeys.AddToArchive[0])
+	ensureOneProcessDone(t, p.getModel())
+	assert.Equal(t, []string{"a.txt", "dir/", "dir/c.txt", "new.txt"}, zipEntryNames(t, archivePath),
+		"Added directory should replace the existing one")
+	assert.FileExists(t, filepath.Join(srcDir, "new.txt"), "Sources should be kept")
+}
+
+func TestTestArchive(t *testing.T) {
+	curTestDir := t.TempDir()
+	validPath := filepath.Join(curTestDir, "valid.zip")
+	utils.SetupZip(t, validPath, map[string]string{"a.txt": "a", "b.txt": "b"})
+	corruptPath := filepath.Join(curTestDir, "corrupt.zip")
+	utils.SetupZip(t, corruptPath, map[string]string{
+		"good.txt": "content of good.txt",
+		"bad.txt":  "content of bad.txt",
+	})
+	data, err := os.ReadFile(corruptPath)
+	require.NoError(t, err)
+	data = bytes.Replace(data, []byte("content of bad.txt"), []byte("CONTENT of bad.txt"), 1)
+	require.NoError(t, os.WriteFile(corruptPath, data, 0o644))
+
+	t.Run("Valid archive", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		setFilePanelSelectedItemByLocation(t, m.getFocusedFilePanel(), validPath)
+		p.SendKey(common.Hotkeys.TestArchive[0])
+		ensureOneProcessDone(t, p.getModel())
+		assert.Equal(t, 2, p.getModel().processBarModel.GetProcessesSlice()[0].Done)
+		assert.False(t, p.getModel().notifyModel.IsOpen())
+	})
+
+	t.Run("Corrupt archive", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		setFilePanelSelectedItemByLocation(t, m.getFocusedFilePanel(), corruptPath)
+		p.SendKey(common.Hotkeys.TestArchive[0])
+		assert.Eventually(t, func() bool {
+			processes := p.getModel().processBarModel.GetProcessesSlice()
+			return len(processes) == 1 && processes[0].State == processbar.Failed &&
+				p.getModel().notifyModel.IsOpen()
+		}, DefaultTestTimeout, DefaultTestTick, "Corrupt files should be reported")
+		assert.Contains(t, p.getModel().notifyModel.GetContent(), "bad.txt")
+		assert.NotContains(t, p.getModel().notifyModel.GetContent(), "good.txt")
+	})
+}
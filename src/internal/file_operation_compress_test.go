@@ -1,15 +1,20 @@
 package internal
 
 import (
+	"archive/tar"
 	"archive/zip"
+	"compress/gzip"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
@@ -89,14 +94,14 @@ func TestZipSources(t *testing.T) {
 			}
 
 			targetZip := filepath.Join(tempDir, "test.zip")
-			err = zipSources(sources, targetZip, &processBar)
+			err = compressSources(sources, targetZip, archive.Zip, archive.DefaultLevel, "", &processBar)
 
 			if tt.expectError {
-				require.Error(t, err, "zipSources should return error")
+				require.Error(t, err, "compressSources should return error")
 				return
 			}
 
-			require.NoError(t, err, "zipSources should not return error")
+			require.NoError(t, err, "compressSources should not return error")
 
 			zipReader, err := zip.OpenReader(targetZip)
 			require.NoError(t, err, "should be able to open ZIP file")
@@ -148,6 +153,44 @@ func TestZipSourcesInvalidTarget(t *testing.T) {
 	require.NoError(t, err, "should be able to create test file")
 
 	invalidTarget := "/invalid/path/test.zip"
-	err = zipSources([]string{testFile}, invalidTarget, &processBar)
-	require.Error(t, err, "zipSources should return error for invalid target")
+	err = compressSources([]string{testFile}, invalidTarget, archive.Zip, archive.DefaultLevel, "", &processBar)
+	require.Error(t, err, "compressSources should return error for invalid target")
+}
+
+func TestCompressSourcesTarGzKeepsModes(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Skipping for windows")
+	}
+	processBar := processbar.New()
+	processBar.ListenForChannelUpdates()
+	t.Cleanup(processBar.SendStopListeningMsgBlocking)
+	tempDir := t.TempDir()
+	srcDir := filepath.Join(tempDir, "deploy")
+	script := filepath.Join(srcDir, "run.sh")
+	utils.SetupDirectories(t, srcDir)
+	utils.SetupFilesWithData(t, []byte("#!/bin/sh"), script)
+	require.NoError(t, os.Chmod(script, 0o751))
+
+	target := filepath.Join(tempDir, "deploy.tar.gz")
+	err := compressSources([]string{srcDir}, target, archive.TarGz, archive.MaxLevel, "", &processBar)
+	require.NoError(t, err)
+
+	f, err := os.Open(target)
+	require.NoError(t, err)
+	defer f.Close()
+	gzReader, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzReader)
+
+	modes := make(map[string]os.FileMode)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		modes[header.Name] = header.FileInfo().Mode().Perm()
+	}
+	require.Contains(t, modes, "deploy/")
+	require.Equal(t, os.FileMode(0o751), modes["deploy/run.sh"])
 }
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/archive"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestGetExtractOutputDir(t *testing.T) {
+	dir := t.TempDir()
+	writeZip := func(name string, entries ...string) string {
+		archivePath := filepath.Join(dir, name)
+		f, err := os.Create(archivePath)
+		require.NoError(t, err)
+		w := zip.NewWriter(f)
+		for _, entry := range entries {
+			_, err = w.Create(entry)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		require.NoError(t, f.Close())
+		return archivePath
+	}
+
+	single := writeZip("single.zip", "project/a.txt", "project/sub/b.txt")
+	outputDir, strip := getExtractOutputDir(single)
+	assert.Equal(t, filepath.Join(dir, "project"), outputDir)
+	assert.Equal(t, 1, strip)
+
+	multi := writeZip("multi.zip", "project/a.txt", "b.txt")
+	outputDir, strip = getExtractOutputDir(multi)
+	assert.Equal(t, filepath.Join(dir, "multi"), outputDir)
+	assert.Equal(t, 0, strip)
+}
+
+func TestGetExtractReportContent(t *testing.T) {
+	var rejected []string
+	for i := range maxReportedEntries + 2 {
+		rejected = append(rejected, fmt.Sprintf("../file%d", i))
+	}
+	content := getExtractReportContent(archive.ExtractReport{
+		Rejected:         rejected,
+		EscapingSymlinks: []string{"link"},
+	})
+	assert.Contains(t, content, "../file0\n")
+	assert.NotContains(t, content, fmt.Sprintf("../file%d", maxReportedEntries))
+	assert.Contains(t, content, "... and 2 more")
+	assert.True(t, strings.HasSuffix(content, "pointing outside of the output directory :\nlink"))
+}
+
+func TestExtractEncryptedArchive(t *testing.T) {
+	curTestDir := t.TempDir()
+	srcFile := filepath.Join(t.TempDir(), "secret.txt")
+	utils.SetupFilesWithData(t, []byte("secret content"), srcFile)
+	archivePath := filepath.Join(curTestDir, "secret.zip")
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w, err := archive.NewWriterWithPassword(f, archive.Zip, archive.DefaultLevel, "hunter2")
+	require.NoError(t, err)
+	info, err := os.Lstat(srcFile)
+	require.NoError(t, err)
+	require.NoError(t, w.AddFile(srcFile, "secret.txt", info))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+	t.Cleanup(func() { archive.SetSessionPassword(archivePath, "") })
+
+	m := defaultTestModel(curTestDir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	setFilePanelSelectedItemByLocation(t, m.getFocusedFilePanel(), archivePath)
+
+	p.SendKey(common.Hotkeys.ExtractFile[0])
+	assert.Eventually(t, func() bool {
+		return p.getModel().passwordModal.IsOpen()
+	}, DefaultTestTimeout, DefaultTestTick, "Password should be asked for encrypted archive")
+
+	p.SendKey("wrong")
+	p.SendKey(common.Hotkeys.ConfirmTyping[0])
+	assert.Eventually(t, func() bool {
+		processes := p.getModel().processBarModel.GetProcessesSlice()
+		return len(processes) == 1 && processes[0].State == processbar.Failed &&
+			p.getModel().passwordModal.IsOpen()
+	}, DefaultTestTimeout, DefaultTestTick, "Password should be asked again after a wrong one")
+	assert.NoDirExists(t, filepath.Join(curTestDir, "secret"), "Failed attempt should be cleaned up")
+
+	// Remember the password for the session
+	p.SendKey("tab")
+	p.SendKey("hunter2")
+	p.SendKey(common.Hotkeys.ConfirmTyping[0])
+	extracted := filepath.Join(curTestDir, "secret", "secret.txt")
+	assert.Eventually(t, func() bool {
+		data, err := os.ReadFile(extracted)
+		return err == nil && string(data) == "secret content"
+	}, DefaultTestTimeout, DefaultTestTick, "Extraction with right password should succeed")
+	assert.False(t, p.getModel().passwordModal.IsOpen())
+	assert.Equal(t, "hunter2", archive.SessionPassword(archivePath))
+}
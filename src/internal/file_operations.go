@@ -3,10 +3,12 @@ package internal
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
@@ -165,16 +167,29 @@ func moveToTrash(src string) error {
 	return err
 }
 
+// pasteOptions are the settings shared by all the files of a paste operation
+type pasteOptions struct {
+	cut bool
+	// Record failed files in the process and go on with the others, instead
+	// of stopping at the first error
+	continueOnError bool
+}
+
 // pasteDir handles directory copying with progress tracking
-func pasteDir(src, dst string, p *processbar.Process, cut bool, processBarModel *processbar.Model) error {
+func pasteDir(src, dst string, p *processbar.Process, opts pasteOptions, processBarModel *processbar.Model) error {
 	dst, err := renameIfDuplicate(dst)
 	if err != nil {
 		return err
 	}
+	return pasteTree(src, dst, p, opts, processBarModel)
+}
 
+// pasteTree pastes src at dst. Directories already at dst are merged into,
+// and files already there are overwritten
+func pasteTree(src, dst string, p *processbar.Process, opts pasteOptions, processBarModel *processbar.Model) error {
 	// Check if we can do a fast move within the same partition
 	sameDev, err := isSamePartition(src, dst)
-	if err == nil && sameDev && cut {
+	if err == nil && sameDev && opts.cut {
 		// For cut operations on same partition, try fast rename first
 		err = os.Rename(src, dst)
 		if err == nil {
@@ -184,24 +199,39 @@ func pasteDir(src, dst string, p *processbar.Process, cut bool, processBarModel
 	}
 
 	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+		relPath, relErr := filepath.Rel(src, path)
+		if relErr != nil {
+			return relErr
 		}
-
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
+		newPath := filepath.Join(dst, relPath)
+		if err == nil {
+			err = actualPasteOperation(info, path, newPath, sameDev, opts, p, processBarModel)
+		}
+		if err == nil {
+			return nil
+		}
+		p.AddFailure(path, newPath, err)
+		if !opts.continueOnError {
 			return err
 		}
-		newPath := filepath.Join(dst, relPath)
-		return actualPasteOperation(info, path, newPath, cut, sameDev, p, processBarModel)
+		slog.Debug("Paste failure, going on with other files", "path", path, "error", err)
+		if info != nil && info.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
 	})
 
 	if err != nil {
 		return err
 	}
 
-	// If this was a cut operation and we had to do a manual copy, remove the source
-	if cut && !sameDev {
+	switch {
+	case opts.cut && opts.continueOnError:
+		// Files are removed as they are moved. Failed ones are left in place,
+		// along with their directories
+		removeEmptyDirs(src)
+	case opts.cut && !sameDev:
+		// If this was a cut operation and we had to do a manual copy, remove the source
 		err = os.RemoveAll(src)
 		if err != nil {
 			return fmt.Errorf("failed to remove source after move: %w", err)
@@ -211,34 +241,27 @@ func pasteDir(src, dst string, p *processbar.Process, cut bool, processBarModel
 	return nil
 }
 
-func actualPasteOperation(info os.FileInfo, path string, newPath string, cut bool, sameDev bool,
-	p *processbar.Process, processBarModel *processbar.Model) error {
+func actualPasteOperation(info os.FileInfo, path string, newPath string, sameDev bool,
+	opts pasteOptions, p *processbar.Process, processBarModel *processbar.Model) error {
 	var err error
 	if info.IsDir() {
-		// TODO - this is likely not needed because we did
-		// dst, err := renameIfDuplicate(dst) above
-		newPath, err = renameIfDuplicate(newPath)
-		if err != nil {
-			return err
-		}
-		err = os.MkdirAll(newPath, info.Mode())
-		return err
+		return os.MkdirAll(newPath, info.Mode())
 	}
 
 	// File
 	p.CurrentFile = filepath.Base(path)
-	if cut && sameDev {
+	if opts.cut && sameDev {
 		err = os.Rename(path, newPath)
 	} else {
 		err = copyFile(path, newPath, info)
+		if err == nil && opts.cut && opts.continueOnError {
+			if err = os.Remove(path); err != nil {
+				err = fmt.Errorf("failed to remove source after copy: %w", err)
+			}
+		}
 	}
 
 	if err != nil {
-		p.State = processbar.Failed
-		pSendErr := processBarModel.SendUpdateProcessMsg(*p, true)
-		if pSendErr != nil {
-			slog.Error("Error sending process update", "error", pSendErr)
-		}
 		return err
 	}
 
@@ -247,6 +270,25 @@ func actualPasteOperation(info os.FileInfo, path string, newPath string, cut boo
 	return nil
 }
 
+// removeEmptyDirs removes the directories under root, and root itself, that
+// are left empty
+func removeEmptyDirs(root string) {
+	var dirs []string
+	// Errors only mean that some directories are left, which is fine
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	// Children come after their parent in the walk
+	for _, dir := range slices.Backward(dirs) {
+		if err := os.Remove(dir); err != nil {
+			slog.Debug("Directory left in place after move", "dir", dir, "error", err)
+		}
+	}
+}
+
 // isAncestor checks if dst is the same as src or a subdirectory of src.
 // It handles symlinks by resolving them and applies case-insensitive comparison on Windows.
 func isAncestor(src, dst string) bool {
@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/archive"
+)
+
+// validateAddToArchive checks that sources can be added to the archive at target
+func validateAddToArchive(target string, sources []string) error {
+	if format := archive.DetectFormat(target); !format.Writable() {
+		return fmt.Errorf("%s archives can't be updated, only zip and tar archives can",
+			filepath.Base(target))
+	}
+	for _, src := range sources {
+		if archive.InArchive(src) {
+			return fmt.Errorf("cannot add %s, as it is inside another archive", filepath.Base(src))
+		}
+		// The updated archive is written next to target, it would be walked too
+		if isAncestor(src, target) {
+			return fmt.Errorf("cannot add %s, as it is or contains the archive itself", filepath.Base(src))
+		}
+	}
+	return nil
+}
+
+// addToArchive adds sources at the root of the archive at target. Existing
+// entries with the same names are replaced
+func addToArchive(target string, sources []string, processBar *processbar.Model) error {
+	totalFiles := 0
+	var totalSize int64
+	replaced := make([]string, 0, len(sources))
+	for _, src := range sources {
+		count, size, err := countFiles(src)
+		if err != nil {
+			slog.Error("Error while counting files to add to archive", "error", err)
+		}
+		totalFiles += count
+		totalSize += size
+		replaced = append(replaced, filepath.Base(src))
+	}
+	p, err := processBar.SendAddProcessMsg(filepath.Base(target), processbar.OpAddToArchive, totalFiles, true)
+	if err != nil {
+		return fmt.Errorf("cannot spawn process : %w", err)
+	}
+	p.Size = totalSize
+
+	updater, err := archive.NewUpdater(target, replaced)
+	if err == nil {
+		compressSourcesCore(sources, processBar, &p, updater)
+		if p.State == processbar.Failed {
+			err = errors.New(p.ErrorMsg)
+			if abortErr := updater.Abort(); abortErr != nil {
+				slog.Error("Error while discarding updated archive", "error", abortErr)
+			}
+		} else {
+			err = updater.Close()
+		}
+	}
+
+	if err != nil {
+		slog.Error("Error while adding files to archive", "target", target, "error", err)
+		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
+	} else {
+		p.State = processbar.Successful
+		p.Done = totalFiles
+	}
+	p.DoneTime = time.Now()
+	if pSendErr := processBar.SendUpdateProcessMsg(p, true); pSendErr != nil {
+		slog.Error("Error sending process update", "error", pSendErr)
+	}
+	return err
+}
+
+// testArchive reads every file of the archive at src to check its integrity,
+// without extracting anything. Cancelling stops the test early.
+func testArchive(src string, processBar *processbar.Model) (archive.VerifyResult, error) {
+	total := 1
+	if count, err := archive.CountFiles(src); err == nil {
+		total = max(count, 1)
+	}
+	p, err := processBar.SendAddProcessMsg(filepath.Base(src), processbar.OpTestArchive, total, true)
+	if err != nil {
+		return archive.VerifyResult{}, fmt.Errorf("cannot spawn process : %w", err)
+	}
+	if info, statErr := os.Stat(src); statErr == nil {
+		p.Size = info.Size()
+	}
+
+	result, err := archive.Verify(src, func(name string) error {
+		p.CurrentFile = path.Base(name)
+		p.Done++
+		processBar.TrySendingUpdateProcessMsg(p)
+		if p.CancelRequested() {
+			return errOperationCancelled
+		}
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errOperationCancelled):
+		p.State = processbar.Cancelled
+		p.ErrorMsg = err.Error()
+	case err != nil:
+		slog.Error("Error while testing archive", "path", src, "error", err)
+		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
+	case len(result.Failed) > 0:
+		slog.Warn("Archive has corrupt entries", "path", src, "failed", len(result.Failed))
+		p.State = processbar.Failed
+		p.ErrorMsg = fmt.Sprintf("%d of %d files are corrupt", len(result.Failed), result.Checked)
+	default:
+		p.State = processbar.Successful
+		p.Done = p.Total
+	}
+	p.DoneTime = time.Now()
+	if pSendErr := processBar.SendUpdateProcessMsg(p, true); pSendErr != nil {
+		slog.Error("Error sending process update", "error", pSendErr)
+	}
+	return result, err
+}
+
+// getTestArchiveReportContent lists the files that failed the archive test
+func getTestArchiveReportContent(result archive.VerifyResult) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d of %d files can't be read, or don't match their checksum :\n",
+		len(result.Failed), result.Checked)
+	names := make([]string, 0, len(result.Failed))
+	for _, failure := range result.Failed {
+		names = append(names, failure.Error())
+	}
+	writeReportedEntries(&sb, names)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
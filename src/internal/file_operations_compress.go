@@ -1,10 +1,8 @@
 package internal
 
 import (
-	"archive/zip"
 	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -12,26 +10,31 @@ import (
 	"time"
 
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/archive"
 )
 
-func zipSources(sources []string, target string, processBar *processbar.Model) error {
+func compressSources(sources []string, target string, format archive.Format, level int,
+	password string, processBar *processbar.Model) error {
 	var err error
 
 	totalFiles := 0
+	var totalSize int64
 	for _, src := range sources {
 		if _, err = os.Stat(src); os.IsNotExist(err) {
 			return fmt.Errorf("source path does not exist: %s", src)
 		}
-		count, e := countFiles(src)
+		count, size, e := countFiles(src)
 		if e != nil {
-			slog.Error("Error while zip file count files ", "error", e)
+			slog.Error("Error while compress file count files ", "error", e)
 		}
 		totalFiles += count
+		totalSize += size
 	}
 	p, err := processBar.SendAddProcessMsg(filepath.Base(target), processbar.OpCompress, totalFiles, true)
 	if err != nil {
 		return fmt.Errorf("cannot spawn process : %w", err)
 	}
+	p.Size = totalSize
 	_, err = os.Stat(target)
 	if err == nil {
 		p.ErrorMsg = "File already exists"
@@ -49,10 +52,17 @@ func zipSources(sources []string, target string, processBar *processbar.Model) e
 		return err
 	}
 	defer f.Close()
-	writer := zip.NewWriter(f)
-	defer writer.Close()
+	writer, err := archive.NewWriterWithPassword(f, format, level, password)
+	if err != nil {
+		return err
+	}
 
-	zipSourcesCore(sources, processBar, &p, writer)
+	compressSourcesCore(sources, processBar, &p, writer)
+	if err = writer.Close(); err != nil {
+		slog.Error("Error while finishing archive", "error", err)
+		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
+	}
 
 	if p.State != processbar.Failed {
 		// TODO: User p.SetSuccessful(), p.SetFailed()
@@ -67,8 +77,8 @@ func zipSources(sources []string, target string, processBar *processbar.Model) e
 	return nil
 }
 
-func zipSourcesCore(sources []string, processBar *processbar.Model,
-	p *processbar.Process, writer *zip.Writer) {
+func compressSourcesCore(sources []string, processBar *processbar.Model,
+	p *processbar.Process, writer archive.Writer) {
 	for _, src := range sources {
 		srcParentDir := filepath.Dir(src)
 		err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
@@ -81,54 +91,41 @@ func zipSourcesCore(sources []string, processBar *processbar.Model,
 				return err
 			}
 
-			err = writeZipFile(path, relPath, info, writer)
+			err = writer.AddFile(path, relPath, info)
 			if err != nil {
 				return err
 			}
 
-			p.Done++
+			if !info.IsDir() {
+				p.Done++
+			}
 			processBar.TrySendingUpdateProcessMsg(*p)
 			return nil
 		})
 		if err != nil {
-			slog.Error("Error while zip file", "error", err)
+			slog.Error("Error while compress file", "error", err)
 			p.State = processbar.Failed
+			p.ErrorMsg = err.Error()
 			break
 		}
 	}
 }
 
-func writeZipFile(path string, relPath string, info os.FileInfo, writer *zip.Writer) error {
-	header, err := zip.FileInfoHeader(info)
-	if err != nil {
-		return err
-	}
-	header.Method = zip.Deflate
-	header.Name = relPath
-	if info.IsDir() {
-		header.Name += "/"
-	}
-	headerWriter, err := writer.CreateHeader(header)
-	if err != nil {
-		return err
-	}
-	if info.IsDir() {
-		return nil
-	}
-	file, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	_, err = io.Copy(headerWriter, file)
-	if err != nil {
-		return err
+// getArchiveBaseName returns a name, without extension, for an archive of firstFile
+// inside location that doesn't collide with existing files for the given format.
+// renameIfDuplicate can't be used as it treats only the last part of ".tar.gz" as extension
+func getArchiveBaseName(location string, firstFile string, format archive.Format) (string, error) {
+	base := filepath.Base(firstFile)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	name := base
+	for i := 1; i < maxDuplicateNameAttempts; i++ {
+		_, err := os.Stat(filepath.Join(location, name+format.Extension()))
+		if os.IsNotExist(err) {
+			return name, nil
+		} else if err != nil {
+			return "", err
+		}
+		name = fmt.Sprintf("%s(%d)", base, i)
 	}
-	return nil
-}
-
-func getZipArchiveName(base string) (string, error) {
-	zipName := strings.TrimSuffix(base, filepath.Ext(base)) + ".zip"
-	zipName, err := renameIfDuplicate(zipName)
-	return zipName, err
+	return "", fmt.Errorf("could not find free archive name for %s after many attempts", base)
 }
@@ -1,39 +1,100 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"log/slog"
+	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"golift.io/xtractr"
 
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 
+	"github.com/yorukot/superfile/src/internal/ui/notify"
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
 )
 
-func extractCompressFile(src, dest string, processBar *processbar.Model) error {
-	p, err := processBar.SendAddProcessMsg(filepath.Base(src), processbar.OpExtract, 1, true)
-	if err != nil {
-		return fmt.Errorf("cannot spawn process : %w", err)
+// Maximum count of entry names listed in the extraction report
+const maxReportedEntries = 10
+
+var errOperationCancelled = errors.New("cancelled by user")
+
+// getExtractOutputDir returns the directory to extract item into, and the count
+// of leading path components to strip from the entries. Archives with a single
+// top level directory are extracted without an extra wrapping directory.
+func getExtractOutputDir(item string) (string, int) {
+	if archive.DetectFormat(item).Browsable() {
+		if topDir, ok := archive.SingleTopLevelDir(item); ok {
+			return filepath.Join(filepath.Dir(item), topDir), 1
+		}
 	}
+	return common.FileNameWithoutExtension(item), 0
+}
 
-	x := &xtractr.XFile{
-		FilePath:  src,
-		OutputDir: dest,
-		FileMode:  utils.ExtractedFileMode,
-		DirMode:   utils.ExtractedDirMode,
+func extractCompressFile(src, dest string, stripComponents int, password string,
+	processBar *processbar.Model) (archive.ExtractReport, error) {
+	var report archive.ExtractReport
+	format := archive.DetectFormat(src)
+	total := 1
+	if format.Browsable() {
+		if count, err := archive.CountFiles(src); err == nil {
+			total = max(count, 1)
+		}
+	}
+	p, err := processBar.SendAddProcessMsg(filepath.Base(src), processbar.OpExtract, total, true)
+	if err != nil {
+		return report, fmt.Errorf("cannot spawn process : %w", err)
+	}
+	if info, statErr := os.Stat(src); statErr == nil {
+		p.Size = info.Size()
 	}
 
-	_, _, _, err = xtractr.ExtractFile(x)
+	if format.Browsable() {
+		report, err = archive.ExtractArchive(src, dest, archive.ExtractOptions{
+			StripComponents: stripComponents,
+			Password:        password,
+			OnEntry: func(name string, info fs.FileInfo) error {
+				if !info.IsDir() {
+					p.CurrentFile = path.Base(name)
+					p.Done++
+					processBar.TrySendingUpdateProcessMsg(p)
+				}
+				if p.CancelRequested() {
+					return errOperationCancelled
+				}
+				return nil
+			},
+		})
+	} else {
+		x := &xtractr.XFile{
+			FilePath:  src,
+			OutputDir: dest,
+			FileMode:  utils.ExtractedFileMode,
+			DirMode:   utils.ExtractedDirMode,
+		}
+		_, _, _, err = xtractr.ExtractFile(x)
+	}
 
-	if err != nil {
+	switch {
+	case errors.Is(err, errOperationCancelled):
+		p.State = processbar.Cancelled
+		p.ErrorMsg = err.Error()
+		slog.Info("Extraction cancelled", "path", src, "extracted", p.Done, "total", p.Total)
+	case err != nil:
 		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
 		slog.Error("Error extracting", "path", src, "error", err)
-	} else {
+	default:
 		p.State = processbar.Successful
-		p.Done = 1
+		p.Done = p.Total
 	}
 
 	p.DoneTime = time.Now()
@@ -42,5 +103,63 @@ func extractCompressFile(src, dest string, processBar *processbar.Model) error {
 		slog.Error("Error sending process update", "error", pSendErr)
 	}
 
-	return err
+	return report, err
+}
+
+// getExtractResultMsg returns the message for the result of extracting item
+// into outputDir. Partially extracted files are removed if extraction was
+// cancelled, or needs a different password.
+func getExtractResultMsg(item string, outputDir string, report archive.ExtractReport, err error, reqID int) tea.Msg {
+	passwordErr := errors.Is(err, archive.ErrPasswordRequired) || errors.Is(err, archive.ErrWrongPassword)
+	if errors.Is(err, errOperationCancelled) || passwordErr {
+		if removeErr := os.RemoveAll(outputDir); removeErr != nil {
+			slog.Error("Error while removing partially extracted files", "error", removeErr)
+		}
+	}
+	switch {
+	case errors.Is(err, errOperationCancelled):
+		return NewExtractOperationMsg(processbar.Cancelled, reqID)
+	case passwordErr:
+		// A kept password that no longer works is of no use
+		archive.SetSessionPassword(item, "")
+		return NewPasswordRequiredMsg(item, errors.Is(err, archive.ErrWrongPassword), reqID)
+	case err != nil:
+		slog.Error("Error extract file", "error", err)
+		return NewExtractOperationMsg(processbar.Failed, reqID)
+	case report.HasIssues():
+		slog.Warn("Archive extracted with unsafe entries", "item", item,
+			"rejected", report.Rejected, "escapingSymlinks", report.EscapingSymlinks)
+		return NewNotifyModalMsg(notify.New(true, "Some archive entries need attention",
+			getExtractReportContent(report), notify.NoAction), reqID)
+	default:
+		return NewExtractOperationMsg(processbar.Successful, reqID)
+	}
+}
+
+// getExtractReportContent describes the entries that were skipped or need
+// attention after extracting an archive
+func getExtractReportContent(report archive.ExtractReport) string {
+	var sb strings.Builder
+	if len(report.Rejected) > 0 {
+		sb.WriteString("Skipped entries with absolute paths or paths going outside of the output directory :\n")
+		writeReportedEntries(&sb, report.Rejected)
+	}
+	if len(report.EscapingSymlinks) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("Extracted symlinks pointing outside of the output directory :\n")
+		writeReportedEntries(&sb, report.EscapingSymlinks)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func writeReportedEntries(sb *strings.Builder, names []string) {
+	for i, name := range names {
+		if i == maxReportedEntries {
+			fmt.Fprintf(sb, "... and %d more\n", len(names)-maxReportedEntries)
+			break
+		}
+		sb.WriteString(name + "\n")
+	}
 }
@@ -12,6 +12,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	"github.com/yorukot/superfile/src/internal/ui/processbar"
@@ -19,6 +20,8 @@ import (
 
 var suffixRegexp = regexp.MustCompile(`^(.*)\((\d+)\)$`)
 
+const maxDuplicateNameAttempts = 10_000
+
 // Check if the directory is external disk path
 // TODO : This function should be give two directories, and it should return
 // if the two share a different disk partition.
@@ -81,7 +84,7 @@ func renameIfDuplicate(destination string) (string, error) {
 	}
 
 	// Find first available name
-	for i := counter; i < 10_000; i++ {
+	for i := counter; i < maxDuplicateNameAttempts; i++ {
 		newName := fmt.Sprintf("%s(%d)%s", name, i, ext)
 		newPath := filepath.Join(dir, newName)
 		if _, err := os.Stat(newPath); os.IsNotExist(err) {
@@ -93,8 +96,15 @@ func renameIfDuplicate(destination string) (string, error) {
 }
 
 // Count how many file in the directory
-func countFiles(dirPath string) (int, error) {
+// countFiles returns the count of files under dirPath, and their total size.
+// The size is 0 for paths inside archives, as it isn't known
+func countFiles(dirPath string) (int, int64, error) {
+	if archive.InArchive(dirPath) {
+		count, err := archive.CountFiles(dirPath)
+		return count, 0, err
+	}
 	count := 0
+	var size int64
 
 	err := filepath.Walk(dirPath, func(_ string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -102,11 +112,12 @@ func countFiles(dirPath string) (int, error) {
 		}
 		if !info.IsDir() {
 			count++
+			size += info.Size()
 		}
 		return nil
 	})
 
-	return count, err
+	return count, size, err
 }
 
 func processCmdToTeaCmd(cmd processbar.Cmd) tea.Cmd {
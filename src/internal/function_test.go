@@ -159,3 +159,22 @@ func Benchmark_renameIfDuplicate(b *testing.B) {
 		}
 	})
 }
+
+func TestEditorFileArgs(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		line int
+		want []string
+	}{
+		{cmd: "nvim", line: 0, want: []string{"a.go"}},
+		{cmd: "nvim", line: 12, want: []string{"+12", "a.go"}},
+		{cmd: "/usr/bin/code", line: 12, want: []string{"--goto", "a.go:12"}},
+		{cmd: "hx", line: 12, want: []string{"a.go:12"}},
+		{cmd: "notepad", line: 12, want: []string{"a.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.cmd, tt.line), func(t *testing.T) {
+			assert.Equal(t, tt.want, editorFileArgs(tt.cmd, "a.go", tt.line))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/notify"
+	"github.com/yorukot/superfile/src/internal/ui/prompt"
+)
+
+// User-defined commands of the [commands] table run a shell or an SPF command
+// with the placeholders of the focused panel. They are run by their hotkey, or
+// by their name in the SPF prompt
+
+// Runs a user-defined command outside of the prompt. Errors, and the output of
+// foreground shell commands when they fail or are asked for, open the prompt
+func (m *model) runCustomCommand(action common.CustomCommandAction) tea.Cmd {
+	_, cmd, err := m.logAndExecuteAction(action)
+	if err != nil {
+		slog.Error("Error while running user-defined command", "name", action.Name, "error", err)
+		m.promptModal.ShowSPFActionResults(false, err.Error())
+	}
+	// The key that ran the command must not reach the prompt
+	if m.promptModal.IsOpen() {
+		m.firstTextInput = true
+	}
+	return cmd
+}
+
+func (m *model) executeCustomCommand(action common.CustomCommandAction) (string, tea.Cmd, error) {
+	command, ok := common.Config.Commands[action.Name]
+	if !ok {
+		return "", nil, fmt.Errorf("no user-defined command named %s", action.Name)
+	}
+	if command.Confirm && !action.Confirmed {
+		m.pendingCustomCommand = action.Name
+		m.notifyModel = notify.New(true, fmt.Sprintf(common.CustomCommandWarnTitle, action.Name),
+			command.Run+command.SPF, notify.CustomCommandAction)
+		return "", nil, nil
+	}
+
+	var (
+		successMsg string
+		cmd        tea.Cmd
+		err        error
+	)
+	switch {
+	case command.SPF != "":
+		successMsg, cmd, err = m.executeCustomSPFCommand(command.SPF)
+	case command.Background:
+		successMsg = "Command started in background"
+		cmd, err = m.startBackgroundShellCommand(command.Run, command.RefreshAfter, command.OpenOutput)
+	default:
+		err = m.executeCustomShellCommand(command)
+	}
+	// Background commands refresh once they end
+	if err == nil && command.RefreshAfter && !command.Background {
+		m.fileModel.UpdateFilePanelsIfNeeded(true)
+	}
+	return successMsg, cmd, err
+}
+
+func (m *model) executeCustomSPFCommand(template string) (string, tea.Cmd, error) {
+	action, err := prompt.GetSPFTemplateAction(template, m.getShellPlaceholders(),
+		m.getFocusedFilePanel().Location)
+	if err != nil {
+		return "", nil, err
+	}
+	return m.logAndExecuteAction(action)
+}
+
+// Results go to the prompt when the command is run from it. Otherwise, they
+// are only shown on failure, or with open_output
+func (m *model) executeCustomShellCommand(command common.CustomCommand) error {
+	retCode, output, err := m.executeShellCommand(command.Run)
+	if err != nil {
+		return err
+	}
+	switch {
+	case m.promptModal.IsOpen():
+		m.promptModal.HandleShellCommandResults(retCode, output)
+	case command.OpenOutput || retCode != 0:
+		m.promptModal.ShowShellCommandResults(retCode, output)
+	}
+	return nil
+}
+
+func (m *model) confirmCustomCommand() tea.Cmd {
+	name := m.pendingCustomCommand
+	m.pendingCustomCommand = ""
+	if name == "" {
+		slog.Error("Custom command confirmed without a pending command")
+		return nil
+	}
+	return m.runCustomCommand(common.CustomCommandAction{Name: name, Confirmed: true})
+}
@@ -1,8 +1,12 @@
 package internal
 
 import (
+	"archive/zip"
+	"net"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,14 +65,15 @@ func TestCompressSelectedFiles(t *testing.T) {
 			expectedFilesAfterExtract: []string{"file1.txt"},
 		},
 		{
-			name:                      "Single Directory Compress",
-			startDir:                  curTestDir,
-			cursor:                    0,
-			selectMode:                false,
-			selectedElem:              nil,
-			expectedZipName:           "dir1.zip",
+			name:            "Single Directory Compress",
+			startDir:        curTestDir,
+			cursor:          0,
+			selectMode:      false,
+			selectedElem:    nil,
+			expectedZipName: "dir1.zip",
+			// Single top level directory is extracted without a wrapping directory
 			extractedDirName:          "dir1(1)",
-			expectedFilesAfterExtract: []string{filepath.Join("dir1", "file2.txt")},
+			expectedFilesAfterExtract: []string{"file2.txt"},
 		},
 		{
 			name:                      "Single File Compress with select mode without selection",
@@ -119,6 +124,7 @@ func TestCompressSelectedFiles(t *testing.T) {
 			}
 
 			p.SendKey(common.Hotkeys.CompressFile[0])
+			p.SendKey(common.Hotkeys.ConfirmTyping[0])
 
 			// This is a bit of an indirect validation, but there aren't many ways.
 			// We many add a process type later, and ensure that a process of
@@ -408,3 +414,155 @@ func cleanupWithRetry(t *testing.T, path, label string) {
 		t.Fatalf("Failed to remove %s %q: %v", label, path, lastErr)
 	}
 }
+
+func TestBrowseArchive(t *testing.T) {
+	curTestDir := t.TempDir()
+	destDir := filepath.Join(curTestDir, "dest")
+	utils.SetupDirectories(t, destDir)
+	archivePath := filepath.Join(curTestDir, "archive.zip")
+
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for _, name := range []string{"dir/inner.txt", "top.txt"} {
+		fw, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte("content of " + name))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	m := defaultTestModel(curTestDir)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	panel := m.getFocusedFilePanel()
+	setFilePanelSelectedItemByLocation(t, panel, archivePath)
+
+	p.SendKey(common.Hotkeys.Confirm[0])
+	assert.Eventually(t, func() bool {
+		return p.getModel().getFocusedFilePanel().Location == archivePath
+	}, DefaultTestTimeout, DefaultTestTick, "Enter on an archive should browse it")
+	TeaUpdate(m, nil)
+	assert.True(t, panel.IsInArchive())
+	assert.Equal(t, 2, panel.ElemCount())
+
+	t.Run("Archive is read-only", func(t *testing.T) {
+		setFilePanelSelectedItemByName(t, panel, "top.txt")
+		assert.Nil(t, m.getDeleteTriggerCmd(false))
+		m.copySingleItem(true)
+		assert.NotEqual(t, []string{filepath.Join(archivePath, "top.txt")}, m.clipboard.GetItems(),
+			"Entries inside archives should not be cut")
+		require.Error(t, validatePasteOperation(archivePath, []string{destDir}, false))
+	})
+
+	t.Run("Copy and paste extracts the entry", func(t *testing.T) {
+		setFilePanelSelectedItemByName(t, panel, "dir")
+		m.copySingleItem(false)
+		navigateToTargetDir(t, m, archivePath, destDir)
+		p.SendKey(common.Hotkeys.PasteItems[0])
+
+		extracted := filepath.Join(destDir, "dir", "inner.txt")
+		verifyDestinationFiles(t, destDir, []string{filepath.Join("dir", "inner.txt")})
+		data, err := os.ReadFile(extracted)
+		require.NoError(t, err)
+		assert.Equal(t, "content of dir/inner.txt", string(data))
+	})
+}
+
+func TestPasteContinueOnError(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Unix sockets are used to make a file fail to copy")
+	}
+	originalContinueOnError := common.Config.ContinueOnError
+	t.Cleanup(func() {
+		common.Config.ContinueOnError = originalContinueOnError
+	})
+
+	// Sockets can't be opened like regular files, even by root
+	setup := func(t *testing.T) (string, string, net.Listener) {
+		t.Helper()
+		curTestDir := t.TempDir()
+		sourceDir := filepath.Join(curTestDir, "source")
+		destDir := filepath.Join(curTestDir, "dest")
+		utils.SetupDirectories(t, sourceDir, destDir)
+		utils.SetupFiles(t, filepath.Join(sourceDir, "a.txt"), filepath.Join(sourceDir, "z.txt"))
+		listener, err := net.Listen("unix", filepath.Join(sourceDir, "m.sock"))
+		require.NoError(t, err)
+		t.Cleanup(func() { listener.Close() })
+		return sourceDir, destDir, listener
+	}
+	pasteAndWaitForFailure := func(t *testing.T, sourceDir string, destDir string) (*TeaProg, processbar.Process) {
+		t.Helper()
+		// Footer is needed to focus on the process bar
+		m := defaultTestModelWithFooterAndFilePreview(destDir)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		m.clipboard.SetItems([]string{sourceDir})
+		p.SendKey(common.Hotkeys.PasteItems[0])
+		require.Eventually(t, func() bool {
+			processes := p.getModel().processBarModel.GetProcessesSlice()
+			return len(processes) == 1 && processes[0].State == processbar.Failed
+		}, DefaultTestTimeout, DefaultTestTick, "Paste process should fail")
+		return p, p.getModel().processBarModel.GetProcessesSlice()[0]
+	}
+
+	t.Run("Stop at first error", func(t *testing.T) {
+		common.Config.ContinueOnError = false
+		sourceDir, destDir, _ := setup(t)
+		_, process := pasteAndWaitForFailure(t, sourceDir, destDir)
+
+		require.Len(t, process.Failures, 1)
+		assert.Equal(t, filepath.Join(sourceDir, "m.sock"), process.Failures[0].Src)
+		assert.Equal(t, process.Failures[0].Err, process.ErrorMsg)
+		assert.FileExists(t, filepath.Join(destDir, "source", "a.txt"))
+		assert.NoFileExists(t, filepath.Join(destDir, "source", "z.txt"))
+	})
+
+	t.Run("Continue and retry failed items", func(t *testing.T) {
+		common.Config.ContinueOnError = true
+		sourceDir, destDir, listener := setup(t)
+		p, process := pasteAndWaitForFailure(t, sourceDir, destDir)
+
+		require.Len(t, process.Failures, 1)
+		sockPath := filepath.Join(sourceDir, "m.sock")
+		assert.Equal(t, sockPath, process.Failures[0].Src)
+		assert.Equal(t, filepath.Join(destDir, "source", "m.sock"), process.Failures[0].Dst)
+		assert.FileExists(t, filepath.Join(destDir, "source", "a.txt"))
+		assert.FileExists(t, filepath.Join(destDir, "source", "z.txt"))
+
+		p.SendKey(common.Hotkeys.FocusOnProcessBar[0])
+		p.SendKey(common.Hotkeys.Confirm[0])
+		require.Eventually(t, func() bool {
+			return p.getModel().detailModal.IsOpen()
+		}, DefaultTestTimeout, DefaultTestTick, "Detail modal should open")
+
+		// Closing the listener removes the socket, which lets the retry succeed
+		require.NoError(t, listener.Close())
+		utils.SetupFilesWithData(t, []byte("retried"), sockPath)
+		p.SendKey(common.Hotkeys.RetryFailedItems[0])
+		require.Eventually(t, func() bool {
+			processes := p.getModel().processBarModel.GetProcessesSlice()
+			return len(processes) == 2 && !p.getModel().detailModal.IsOpen() &&
+				slices.ContainsFunc(processes, func(p processbar.Process) bool {
+					return p.State == processbar.Successful
+				})
+		}, DefaultTestTimeout, DefaultTestTick, "Retry process should succeed")
+		data, err := os.ReadFile(filepath.Join(destDir, "source", "m.sock"))
+		require.NoError(t, err)
+		assert.Equal(t, "retried", string(data))
+	})
+}
+
+func TestRemoveEmptyDirs(t *testing.T) {
+	curTestDir := t.TempDir()
+	root := filepath.Join(curTestDir, "root")
+	utils.SetupDirectories(t, filepath.Join(root, "empty", "nested"), filepath.Join(root, "kept"))
+	utils.SetupFiles(t, filepath.Join(root, "kept", "failed.txt"))
+
+	removeEmptyDirs(root)
+	assert.NoDirExists(t, filepath.Join(root, "empty"))
+	assert.FileExists(t, filepath.Join(root, "kept", "failed.txt"))
+
+	require.NoError(t, os.Remove(filepath.Join(root, "kept", "failed.txt")))
+	removeEmptyDirs(root)
+	assert.NoDirExists(t, root)
+}
@@ -8,11 +8,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	variable "github.com/yorukot/superfile/src/config"
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	"github.com/yorukot/superfile/src/internal/common"
@@ -28,6 +31,9 @@ import (
 // that allows you to create a file. Actual creation happens here - createItem() in handle_modal.go
 func (m *model) panelCreateNewFile() {
 	panel := m.getFocusedFilePanel()
+	if m.isFocusedPanelReadOnly("create") {
+		return
+	}
 
 	m.typingModal.location = panel.Location
 	m.typingModal.open = true
@@ -44,7 +50,7 @@ func (m *model) IsRenamingConflicting() bool {
 		return false
 	}
 	oldPath := panel.GetFocusedItem().Location
-	newPath := filepath.Join(panel.Location, panel.Rename.Value())
+	newPath := filepath.Join(filepath.Dir(oldPath), panel.Rename.Value())
 
 	if oldPath == newPath {
 		return false
@@ -74,12 +80,14 @@ func (m *model) warnModalForRenaming() tea.Cmd {
 // Actual rename happens at confirmRename() in handle_modal.go
 func (m *model) panelItemRename() {
 	panel := m.getFocusedFilePanel()
-	if panel.Empty() {
+	if panel.Empty() || m.isFocusedPanelReadOnly("rename") {
 		return
 	}
 
+	// Items of the tree view and of searches can be in subfolders
+	name := filepath.Base(panel.GetFocusedItem().Location)
 	cursorPos := -1
-	nameRunes := []rune(panel.GetFocusedItem().Name)
+	nameRunes := []rune(name)
 	nameLen := len(nameRunes)
 	for i := nameLen - 1; i >= 0; i-- {
 		if nameRunes[i] == '.' {
@@ -100,7 +108,7 @@ func (m *model) panelItemRename() {
 	panel.Rename = common.GenerateRenameTextInput(
 		m.fileModel.SinglePanelWidth-common.InnerPadding,
 		cursorPos,
-		panel.GetFocusedItem().Name)
+		name)
 }
 
 func (m *model) getDeleteCmd(permDelete bool) tea.Cmd {
@@ -167,7 +175,8 @@ func deleteOperation(processBarModel *processbar.Model, items []string, useTrash
 func (m *model) getDeleteTriggerCmd(deletePermanent bool) tea.Cmd {
 	panel := m.getFocusedFilePanel()
 	if (panel.PanelMode == filepanel.SelectMode && panel.SelectedCount() == 0) ||
-		(panel.PanelMode == filepanel.BrowserMode && panel.Empty()) {
+		(panel.PanelMode == filepanel.BrowserMode && panel.Empty()) ||
+		m.isFocusedPanelReadOnly("delete") {
 		return nil
 	}
 
@@ -192,6 +201,9 @@ func (m *model) getDeleteTriggerCmd(deletePermanent bool) tea.Cmd {
 // set cut to true/false accordingly
 func (m *model) copySingleItem(cut bool) {
 	panel := m.getFocusedFilePanel()
+	if cut && m.isFocusedPanelReadOnly("cut") {
+		return
+	}
 	m.clipboard.Reset(cut)
 	if panel.Empty() {
 		return
@@ -204,6 +216,9 @@ func (m *model) copySingleItem(cut bool) {
 // Copy all selected file or directory's paths to the clipboard
 func (m *model) copyMultipleItem(cut bool) {
 	panel := m.getFocusedFilePanel()
+	if cut && m.isFocusedPanelReadOnly("cut") {
+		return
+	}
 	m.clipboard.Reset(cut)
 	if panel.SelectedCount() == 0 {
 		return
@@ -215,16 +230,18 @@ func (m *model) copyMultipleItem(cut bool) {
 
 func (m *model) getPasteItemCmd() tea.Cmd {
 	copyItems := m.clipboard.PruneInaccessibleItemsAndGet()
-	cut := m.clipboard.IsCut()
 	if len(copyItems) == 0 {
 		return nil
 	}
+	return m.getPasteCmd(copyItems, m.getFocusedFilePanel().Location, m.clipboard.IsCut())
+}
 
+// Paste copyItems to the panelLocation directory in the background
+func (m *model) getPasteCmd(copyItems []string, panelLocation string, cut bool) tea.Cmd {
 	// TODO: Do it via m.getNewReqID()
 	// TODO: Have an IO Req Management, collecting info about pending IO Req too
 	reqID := m.ioReqCnt
 	m.ioReqCnt++
-	panelLocation := m.getFocusedFilePanel().Location
 
 	slog.Debug("Submitting pasteItems request", "id", reqID, "items cnt", len(copyItems), "dest", panelLocation)
 	return func() tea.Msg {
@@ -239,6 +256,9 @@ func (m *model) getPasteItemCmd() tea.Cmd {
 }
 
 func validatePasteOperation(panelLocation string, copyItems []string, cut bool) error {
+	if _, _, inArchive := archive.SplitPath(panelLocation); inArchive {
+		return errors.New("cannot paste into an archive, archives are read-only")
+	}
 	// Check if trying to paste into source or subdirectory for both cut and copy operations
 	for _, srcPath := range copyItems {
 		// Check if trying to cut and paste into the same directory - this would be a no-op
@@ -263,12 +283,65 @@ func validatePasteOperation(panelLocation string, copyItems []string, cut bool)
 // new func to check and return an error that will go in m.content
 // create a new error type
 
+// pasteItem is a file or directory to paste, and where to paste it
+type pasteItem struct {
+	src string
+	dst string
+}
+
 // Paste all clipboard items
 func executePasteOperation(processBarModel *processbar.Model,
 	panelLocation string, copyItems []string, cut bool,
 ) processbar.ProcessState {
 	slog.Debug("executePasteOperation", "items", copyItems, "cut", cut, "panel location", panelLocation)
 
+	items := make([]pasteItem, 0, len(copyItems))
+	for _, filePath := range copyItems {
+		items = append(items, pasteItem{src: filePath, dst: filepath.Join(panelLocation, filepath.Base(filePath))})
+	}
+	return runPasteProcess(processBarModel, items, cut, false)
+}
+
+// executeRetryOperation pastes again the items a copy or move process failed
+// on, to the same destinations
+func executeRetryOperation(processBarModel *processbar.Model, failures []processbar.FileError,
+	cut bool) processbar.ProcessState {
+	slog.Debug("executeRetryOperation", "items cnt", len(failures), "cut", cut)
+
+	items := make([]pasteItem, 0, len(failures))
+	for _, failure := range failures {
+		items = append(items, pasteItem{src: failure.Src, dst: failure.Dst})
+	}
+	return runPasteProcess(processBarModel, items, cut, true)
+}
+
+// pasteSingleItem pastes one of the items of a paste process. It returns the
+// message to log along with the error, if it fails
+func pasteSingleItem(item pasteItem, opts pasteOptions, retry bool, p *processbar.Process,
+	processBarModel *processbar.Model) (string, error) {
+	switch {
+	case archive.InArchive(item.src):
+		// Entries in archives are copied by extracting them. They can't be cut
+		return "extract archive entry error", pasteArchiveEntry(item.src, item.dst, p, processBarModel)
+	case retry:
+		err := os.MkdirAll(filepath.Dir(item.dst), utils.UserDirPerm)
+		if err == nil {
+			err = pasteTree(item.src, item.dst, p, opts, processBarModel)
+		}
+		return "retry paste item error", err
+	case opts.cut && !isExternalDiskPath(item.src) && !opts.continueOnError:
+		return "cut item error", moveElement(item.src, item.dst)
+	default:
+		// TODO : These error cases are hard to test. We have to somehow make the paste operations fail,
+		// which is time consuming and manual. We should test these with automated testcases
+		return "paste item error", pasteDir(item.src, item.dst, p, opts, processBarModel)
+	}
+}
+
+// runPasteProcess pastes the items as a single process. When retrying, items
+// are pasted at their exact destination, which may already exist
+func runPasteProcess(processBarModel *processbar.Model, items []pasteItem, cut bool,
+	retry bool) processbar.ProcessState {
 	var operation processbar.OperationType
 	if cut {
 		operation = processbar.OpCut
@@ -276,40 +349,45 @@ func executePasteOperation(processBarModel *processbar.Model,
 		operation = processbar.OpCopy
 	}
 
+	srcs := make([]string, 0, len(items))
+	for _, item := range items {
+		srcs = append(srcs, item.src)
+	}
+	totalFiles, totalSize := getTotalFilesCnt(srcs)
 	p, err := processBarModel.SendAddProcessMsg(
-		filepath.Base(copyItems[0]),
+		filepath.Base(items[0].src),
 		operation,
-		getTotalFilesCnt(copyItems), true)
+		totalFiles, true)
 	if err != nil {
 		slog.Error("Cannot spawn a new process", "error", err)
 		return processbar.Failed
 	}
+	p.Size = totalSize
 
-	for _, filePath := range copyItems {
-		errMessage := "cut item error"
-		if cut && !isExternalDiskPath(filePath) {
-			err = moveElement(filePath, filepath.Join(panelLocation, filepath.Base(filePath)))
-		} else {
-			// TODO : These error cases are hard to test. We have to somehow make the paste operations fail,
-			// which is time consuming and manual. We should test these with automated testcases
-			err = pasteDir(filePath, filepath.Join(panelLocation, filepath.Base(filePath)), &p, cut, processBarModel)
-			if err != nil {
-				errMessage = "paste item error"
+	opts := pasteOptions{cut: cut, continueOnError: common.Config.ContinueOnError}
+	for _, item := range items {
+		failedCount := p.FailedCount
+		errMessage, pasteErr := pasteSingleItem(item, opts, retry, &p, processBarModel)
+		p.CurrentFile = filepath.Base(item.src)
+		if pasteErr != nil {
+			slog.Debug("model.pasteItem - paste failure", "error", pasteErr,
+				"current item", item.src, "errMessage", errMessage)
+			slog.Error(errMessage, "error", pasteErr)
+			// Failures of single files are already recorded
+			if p.FailedCount == failedCount {
+				p.AddFailure(item.src, item.dst, pasteErr)
+			}
+			if !opts.continueOnError {
+				break
 			}
-		}
-
-		p.CurrentFile = filepath.Base(filePath)
-		if err != nil {
-			slog.Debug("model.pasteItem - paste failure", "error", err,
-				"current item", filePath, "errMessage", errMessage)
-			p.State = processbar.Failed
-			slog.Error(errMessage, "error", err)
-			break
 		}
 		processBarModel.TrySendingUpdateProcessMsg(p)
 	}
 
-	if p.State != processbar.Failed {
+	if p.FailedCount > 0 {
+		p.State = processbar.Failed
+		p.ErrorMsg = p.FailureSummary()
+	} else {
 		p.State = processbar.Successful
 		p.Done = p.Total
 	}
@@ -322,8 +400,22 @@ func executePasteOperation(processBarModel *processbar.Model,
 	return p.State
 }
 
-func getTotalFilesCnt(copyItems []string) int {
+func pasteArchiveEntry(src, dst string, p *processbar.Process, processBarModel *processbar.Model) error {
+	dst, err := renameIfDuplicate(dst)
+	if err != nil {
+		return err
+	}
+	return archive.Extract(src, dst, func(name string) {
+		p.CurrentFile = filepath.Base(name)
+		p.Done++
+		processBarModel.TrySendingUpdateProcessMsg(*p)
+	})
+}
+
+// getTotalFilesCnt returns the count of files to paste, and their total size
+func getTotalFilesCnt(copyItems []string) (int, int64) {
 	totalFiles := 0
+	var totalSize int64
 	for _, folderPath := range copyItems {
 		// TODO : Fix this. This is inefficient
 		// In case of a cut operations for a directory with a lot of files
@@ -337,38 +429,60 @@ func getTotalFilesCnt(copyItems []string) int {
 		// instead, we could just track progress based on total items in
 		// copyItems
 		// efficiency should be prioritized over more detailed feedback.
-		count, err := countFiles(folderPath)
+		count, size, err := countFiles(folderPath)
 		if err != nil {
 			slog.Error("Error in countFiles", "error", err)
 			continue
 		}
 		totalFiles += count
+		totalSize += size
 	}
-	return totalFiles
+	return totalFiles, totalSize
 }
 
 // Extract compressed file
-// TODO : err should be returned and properly handled by the caller
-func (m *model) getExtractFileCmd() tea.Cmd {
+func (m *model) getExtractFileCmd() (tea.Cmd, error) {
 	panel := m.getFocusedFilePanel()
 	if panel.Empty() {
-		return nil
+		return nil, errors.New("no item to extract")
 	}
 
+	if err := m.checkFocusedPanelWritable("extract"); err != nil {
+		return nil, err
+	}
 	item := panel.GetFocusedItem().Location
 
 	ext := strings.ToLower(filepath.Ext(item))
-	if !common.IsExtensionExtractable(ext) {
-		slog.Error("Error unexpected file", "extension type", ext, "item", item, "error", errors.ErrUnsupported)
-		return nil
+	if !common.IsExtensionExtractable(ext) && archive.DetectFormat(item) == archive.Unknown {
+		return nil, fmt.Errorf("cannot extract %s : %w", filepath.Base(item), errors.ErrUnsupported)
 	}
+	return m.getExtractArchiveCmd(item, "", false), nil
+}
+
+// getExtractArchiveCmd extracts item in the background. password is only used
+// for encrypted archives, and the password modal is opened if it is missing or
+// wrong. With rememberPassword, the password is kept for the session.
+func (m *model) getExtractArchiveCmd(item string, password string, rememberPassword bool) tea.Cmd {
 	reqID := m.ioReqCnt
 	m.ioReqCnt++
 
 	slog.Debug("Submitting Extract file request", "reqID", reqID, "item", item)
 
 	return func() tea.Msg {
-		outputDir := common.FileNameWithoutExtension(item)
+		if password == "" && archive.SessionPassword(item) == "" {
+			if encrypted, err := archive.IsEncrypted(item); err != nil {
+				slog.Error("Error while checking archive encryption", "item", item, "error", err)
+			} else if encrypted {
+				return NewPasswordRequiredMsg(item, false, reqID)
+			}
+		}
+		if rememberPassword {
+			// Kept before extracting, so that archives with encrypted headers
+			// can be listed for smart extract
+			archive.SetSessionPassword(item, password)
+		}
+
+		outputDir, stripComponents := getExtractOutputDir(item)
 		outputDir, err := renameIfDuplicate(outputDir)
 		if err != nil {
 			slog.Error("Error while renaming for duplicates", "error", err)
@@ -383,20 +497,18 @@ func (m *model) getExtractFileCmd() tea.Cmd {
 			slog.Error("Error while making directory for extracting files", "error", err)
 			return NewExtractOperationMsg(processbar.Failed, reqID)
 		}
-		err = extractCompressFile(item, outputDir, &m.processBarModel)
-		if err != nil {
-			slog.Error("Error extract file", "error", err)
-			return NewExtractOperationMsg(processbar.Failed, reqID)
-		}
-		return NewExtractOperationMsg(processbar.Successful, reqID)
+		report, err := extractCompressFile(item, outputDir, stripComponents, password, &m.processBarModel)
+		return getExtractResultMsg(item, outputDir, report, err, reqID)
 	}
 }
 
-func (m *model) getCompressSelectedFilesCmd() tea.Cmd {
+// Open the compress modal for selected files, or the focused file if nothing
+// is selected. Actual compression happens in getCompressCmd()
+func (m *model) openCompressModal() {
 	panel := m.getFocusedFilePanel()
 
-	if panel.Empty() {
-		return nil
+	if panel.Empty() || m.isFocusedPanelReadOnly("compress") {
+		return
 	}
 	var filesToCompress []string
 	var firstFile string
@@ -409,24 +521,100 @@ func (m *model) getCompressSelectedFilesCmd() tea.Cmd {
 		filesToCompress = panel.GetSelectedLocations()
 	}
 
+	baseName, err := getArchiveBaseName(panel.Location, firstFile, m.compressModal.GetFormat())
+	if err != nil {
+		slog.Error("Error in getArchiveBaseName", "error", err)
+		return
+	}
+	m.compressModal.Open(panel.Location, filesToCompress, baseName)
+	m.firstTextInput = true
+}
+
+func (m *model) getCompressCmd(action common.CompressAction) tea.Cmd {
 	reqID := m.ioReqCnt
 	m.ioReqCnt++
 
+	slog.Debug("Submitting compress request", "reqID", reqID, "target", action.Target,
+		"format", action.Format, "items cnt", len(action.Sources))
 	return func() tea.Msg {
-		zipName, err := getZipArchiveName(filepath.Base(firstFile))
+		err := compressSources(action.Sources, action.Target, action.Format, action.Level, action.Password,
+			&m.processBarModel)
 		if err != nil {
-			slog.Error("Error in getZipArchiveName", "error", err)
+			slog.Error("Error in compressing files", "error", err)
 			return NewCompressOperationMsg(processbar.Failed, reqID)
 		}
-		zipPath := filepath.Join(panel.Location, zipName)
-		if err := zipSources(filesToCompress, zipPath, &m.processBarModel); err != nil {
-			slog.Error("Error in zipping files", "error", err)
+		return NewCompressOperationMsg(processbar.Successful, reqID)
+	}
+}
+
+// Add the clipboard items, or the selected items if the clipboard is empty, to
+// the focused archive. Cut items are only copied into it
+func (m *model) getAddToArchiveCmd() tea.Cmd {
+	panel := m.getFocusedFilePanel()
+	if panel.Empty() || m.isFocusedPanelReadOnly("add to archive") {
+		return nil
+	}
+	target := panel.GetFocusedItem().Location
+	sources := m.clipboard.PruneInaccessibleItemsAndGet()
+	if len(sources) == 0 {
+		sources = slices.DeleteFunc(panel.GetSelectedLocations(), func(location string) bool {
+			return location == target
+		})
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+
+	slog.Debug("Submitting add to archive request", "reqID", reqID, "target", target,
+		"items cnt", len(sources))
+	return func() tea.Msg {
+		if err := validateAddToArchive(target, sources); err != nil {
+			return NewNotifyModalMsg(notify.New(true, "Cannot add to archive", err.Error(), notify.NoAction),
+				reqID)
+		}
+		if err := addToArchive(target, sources, &m.processBarModel); err != nil {
 			return NewCompressOperationMsg(processbar.Failed, reqID)
 		}
 		return NewCompressOperationMsg(processbar.Successful, reqID)
 	}
 }
 
+// Test the integrity of the focused archive in the background. Corrupt files
+// are listed once the test finishes
+func (m *model) getTestArchiveCmd() tea.Cmd {
+	panel := m.getFocusedFilePanel()
+	if panel.Empty() || m.isFocusedPanelReadOnly("test archive") {
+		return nil
+	}
+	item := panel.GetFocusedItem().Location
+	if !archive.DetectFormat(item).Browsable() {
+		slog.Debug("Focused item is not an archive that can be tested", "item", item)
+		return nil
+	}
+
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+
+	slog.Debug("Submitting test archive request", "reqID", reqID, "item", item)
+	return func() tea.Msg {
+		result, err := testArchive(item, &m.processBarModel)
+		switch {
+		case errors.Is(err, errOperationCancelled):
+			return NewTestArchiveOperationMsg(processbar.Cancelled, reqID)
+		case err != nil:
+			return NewTestArchiveOperationMsg(processbar.Failed, reqID)
+		case len(result.Failed) > 0:
+			return NewNotifyModalMsg(notify.New(true, "Archive test failed",
+				getTestArchiveReportContent(result), notify.NoAction), reqID)
+		default:
+			return NewTestArchiveOperationMsg(processbar.Successful, reqID)
+		}
+	}
+}
+
 func (m *model) chooserFileWriteAndQuit(path string) error {
 	// Attempt to write to the file
 	err := os.WriteFile(variable.ChooserFile, []byte(path), utils.ConfigFilePerm)
@@ -441,7 +629,7 @@ func (m *model) chooserFileWriteAndQuit(path string) error {
 func (m *model) openFileWithEditor() tea.Cmd {
 	panel := m.getFocusedFilePanel()
 	// Check if panel is empty
-	if panel.Empty() {
+	if panel.Empty() || m.isFocusedPanelReadOnly("open with editor") {
 		return nil
 	}
 
@@ -472,8 +660,9 @@ func (m *model) openFileWithEditor() tea.Cmd {
 	parts := strings.Fields(editor)
 	cmd := parts[0]
 
+	item := panel.GetFocusedItem()
 	//nolint:gocritic // appendAssign: intentionally creating a new slice
-	args := append(parts[1:], panel.GetFocusedItem().Location)
+	args := append(parts[1:], editorFileArgs(cmd, item.Location, item.Line)...)
 
 	c := exec.Command(cmd, args...)
 
@@ -482,8 +671,31 @@ func (m *model) openFileWithEditor() tea.Cmd {
 	})
 }
 
+// Arguments opening path in the editor cmd, at the line if it is set. Editors
+// have no common way to take a line, the `+line` of vi, nano and emacs is used
+// for the unknown ones
+func editorFileArgs(cmd string, path string, line int) []string {
+	if line <= 0 {
+		return []string{path}
+	}
+	lineStr := strconv.Itoa(line)
+	switch strings.TrimSuffix(strings.ToLower(filepath.Base(cmd)), ".exe") {
+	case "code", "codium", "code-insiders", "cursor":
+		return []string{"--goto", path + ":" + lineStr}
+	case "hx", "helix", "subl", "zed":
+		return []string{path + ":" + lineStr}
+	case "notepad":
+		return []string{path}
+	default:
+		return []string{"+" + lineStr, path}
+	}
+}
+
 // Open directory with default editor
 func (m *model) openDirectoryWithEditor() tea.Cmd {
+	if m.isFocusedPanelReadOnly("open directory with editor") {
+		return nil
+	}
 	if variable.ChooserFile != "" {
 		err := m.chooserFileWriteAndQuit(m.getFocusedFilePanel().Location)
 		if err == nil {
@@ -518,6 +730,36 @@ func (m *model) openDirectoryWithEditor() tea.Cmd {
 	})
 }
 
+// Archives are browsed read-only, and their entries only exist virtually.
+// Operations that need real files are skipped there
+func (m *model) isFocusedPanelReadOnly(operation string) bool {
+	if !m.getFocusedFilePanel().IsInArchive() {
+		return false
+	}
+	slog.Warn("Operation is not supported inside archives", "operation", operation)
+	return true
+}
+
+// Same as isFocusedPanelReadOnly, for callers that report errors to the user
+func (m *model) checkFocusedPanelWritable(operation string) error {
+	if m.isFocusedPanelReadOnly(operation) {
+		return fmt.Errorf("%s is not supported inside archives", operation)
+	}
+	return nil
+}
+
+// Selected items of the focused panel, or its focused item if nothing is selected
+func (m *model) getSelectedOrFocusedItems() []string {
+	panel := m.getFocusedFilePanel()
+	if panel.SelectedCount() > 0 {
+		return panel.GetSelectedLocations()
+	}
+	if panel.Empty() {
+		return nil
+	}
+	return []string{panel.GetFocusedItem().Location}
+}
+
 // Copy file path
 // TODO: This is also an IO operations, do it via tea.Cmd
 func (m *model) copyPath() {
@@ -539,3 +781,67 @@ func (m *model) copyPWD() {
 		slog.Error("Error while copy present working directory", "error", err)
 	}
 }
+
+// getProcessHistoryCmd reads the process history file, to show it in the history modal
+func (m *model) getProcessHistoryCmd() tea.Cmd {
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+
+	slog.Debug("Submitting process history request", "reqID", reqID)
+	return func() tea.Msg {
+		entries, err := processbar.ReadHistory(variable.ProcessHistoryFile)
+		if err != nil {
+			slog.Error("Error while reading process history", "error", err)
+		}
+		return NewProcessHistoryMsg(entries, err, reqID)
+	}
+}
+
+// openProcessDetail shows the process under the cursor of the process bar in the detail modal
+func (m *model) openProcessDetail() {
+	p, ok := m.processBarModel.SelectedProcess()
+	if !ok {
+		return
+	}
+	// Shell commands have no failed items, but their output
+	if p.Output != nil {
+		m.outputModal.Open(p)
+		return
+	}
+	m.detailModal.Open(p)
+}
+
+// getRetryFailedCmd pastes again the items a copy or move process failed on
+func (m *model) getRetryFailedCmd(processID string) (tea.Cmd, error) {
+	p, ok := m.processBarModel.GetByID(processID)
+	if !ok {
+		return nil, errors.New("process not found")
+	}
+	if !p.CanRetry() {
+		return nil, errors.New("only failed items of finished copy and move operations can be retried")
+	}
+	failures := slices.Clone(p.Failures)
+	cut := p.Operation == processbar.OpCut
+
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+
+	slog.Debug("Submitting retry request", "reqID", reqID, "process", processID, "items cnt", len(failures))
+	return func() tea.Msg {
+		state := executeRetryOperation(&m.processBarModel, failures, cut)
+		return NewRetryOperationMsg(state, reqID)
+	}, nil
+}
+
+// copyErrorList copies the errors of the process to the system clipboard
+func (m *model) copyErrorList(processID string) error {
+	p, ok := m.processBarModel.GetByID(processID)
+	if !ok {
+		return errors.New("process not found")
+	}
+	if err := clipboard.WriteAll(p.ErrorReport()); err != nil {
+		slog.Error("Error while copying error list", "error", err)
+		return fmt.Errorf("cannot copy error list : %w", err)
+	}
+	return nil
+}
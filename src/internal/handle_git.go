@@ -0,0 +1,184 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/notify"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/git"
+)
+
+// Git actions work on the repository of the focused panel. Changes are run as
+// processes of the process bar, and the panels and their git status are
+// refreshed once they end
+
+var errNotInGitRepo = errors.New("the focused panel is not inside a git repository")
+
+// Repository of the focused panel, and the selected items, or the focused item,
+// relative to its root
+func (m *model) getGitTargets() (string, []string, error) {
+	panel := m.getFocusedFilePanel()
+	root, ok := git.FindRoot(panel.Location)
+	if !ok {
+		return "", nil, errNotInGitRepo
+	}
+	items := m.getSelectedOrFocusedItems()
+	paths := make([]string, 0, len(items))
+	for _, item := range items {
+		relPath, err := filepath.Rel(root, item)
+		if err != nil {
+			return "", nil, err
+		}
+		paths = append(paths, relPath)
+	}
+	return root, paths, nil
+}
+
+func (m *model) getGitErrorCmd(err error) tea.Cmd {
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+	return func() tea.Msg {
+		return NewNotifyModalMsg(notify.New(true, common.GitActionErrorTitle, err.Error(), notify.NoAction), reqID)
+	}
+}
+
+// Stage, unstage or discard the changes of the selected items, or the focused
+// item, in the background
+func (m *model) getGitOperationCmd(op processbar.OperationType) tea.Cmd {
+	if m.isFocusedPanelReadOnly(op.String()) {
+		return nil
+	}
+	root, paths, err := m.getGitTargets()
+	if err != nil {
+		return m.getGitErrorCmd(err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	var run func(string, []string) error
+	switch op { //nolint:exhaustive // Only the operations on items
+	case processbar.OpGitStage:
+		run = git.Stage
+	case processbar.OpGitUnstage:
+		run = git.Unstage
+	case processbar.OpGitDiscard:
+		run = git.Discard
+	default:
+		slog.Error("Unsupported git operation", "operation", op)
+		return nil
+	}
+
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+
+	slog.Debug("Submitting git operation request", "reqID", reqID, "operation", op,
+		"root", root, "items cnt", len(paths))
+	return func() tea.Msg {
+		state := runGitOperation(getGitProcessName(paths), op, &m.processBarModel, func() error {
+			return run(root, paths)
+		})
+		return NewGitOperationMsg(state, root, reqID)
+	}
+}
+
+// Ask for a confirmation before discarding changes, as they cannot be recovered
+func (m *model) getGitDiscardTriggerCmd() tea.Cmd {
+	if m.isFocusedPanelReadOnly(processbar.OpGitDiscard.String()) {
+		return nil
+	}
+	_, paths, err := m.getGitTargets()
+	if err != nil {
+		return m.getGitErrorCmd(err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+	return func() tea.Msg {
+		return NewNotifyModalMsg(notify.New(true, common.GitDiscardWarnTitle, common.GitDiscardWarnContent,
+			notify.GitDiscardAction), reqID)
+	}
+}
+
+// Show the diff of the selected items, or the focused item, in the preview panel
+func (m *model) getGitDiffCmd() tea.Cmd {
+	if m.isFocusedPanelReadOnly("git diff") {
+		return nil
+	}
+	root, paths, err := m.getGitTargets()
+	if err != nil {
+		return m.getGitErrorCmd(err)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return m.fileModel.GetGitDiffCmd(root, paths)
+}
+
+func (m *model) openGitCommitModal() tea.Cmd {
+	if m.isFocusedPanelReadOnly(processbar.OpGitCommit.String()) {
+		return nil
+	}
+	panel := m.getFocusedFilePanel()
+	root, ok := git.FindRoot(panel.Location)
+	if !ok {
+		return m.getGitErrorCmd(errNotInGitRepo)
+	}
+	m.gitCommitModal.Open(root, panel.GitBranch())
+	return nil
+}
+
+// Commit the staged changes in the background
+func (m *model) getGitCommitCmd(action common.GitCommitAction) tea.Cmd {
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+
+	slog.Debug("Submitting git commit request", "reqID", reqID, "root", action.Root)
+	return func() tea.Msg {
+		state := runGitOperation(filepath.Base(action.Root), processbar.OpGitCommit, &m.processBarModel,
+			func() error {
+				return git.Commit(action.Root, action.Message)
+			})
+		return NewGitOperationMsg(state, action.Root, reqID)
+	}
+}
+
+func getGitProcessName(paths []string) string {
+	if len(paths) == 1 {
+		return filepath.Base(paths[0])
+	}
+	return fmt.Sprintf("%d items", len(paths))
+}
+
+// runGitOperation runs the git command run as a process of the process bar
+func runGitOperation(name string, op processbar.OperationType, processBar *processbar.Model,
+	run func() error) processbar.ProcessState {
+	p, err := processBar.SendAddProcessMsg(name, op, 1, true)
+	if err != nil {
+		slog.Error("Cannot spawn git process", "operation", op, "error", err)
+		return processbar.Failed
+	}
+
+	if err = run(); err != nil {
+		slog.Error("Error while running git operation", "operation", op, "error", err)
+		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
+	} else {
+		p.State = processbar.Successful
+		p.Done = p.Total
+	}
+	p.DoneTime = time.Now()
+	if pSendErr := processBar.SendUpdateProcessMsg(p, true); pSendErr != nil {
+		slog.Error("Error sending process update", "error", pSendErr)
+	}
+	return p.State
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/ui/macro"
+)
+
+// Macros are sequences of keys recorded into a register, and sent again as
+// replayedKeyMsg, so they go through the same handling as typed keys
+
+// replayedKeyMsg is a key sent by a macro replay. depth is the number of
+// nested replays it comes from, to stop macros from replaying themselves forever
+type replayedKeyMsg struct {
+	key   tea.KeyMsg
+	depth int
+}
+
+// Starts waiting for the register to record into, or stops the recording
+func (m *model) recordMacroKey() {
+	if !m.macroModel.IsRecording() {
+		m.macroModel.AwaitRecordRegister()
+		return
+	}
+	register := m.macroModel.RecordingRegister()
+	if err := m.macroModel.StopRecording(); err != nil {
+		slog.Error("Error while saving macros", "register", register, "error", err)
+	}
+}
+
+// Handles the key pressed after the record or replay key
+func (m *model) macroRegisterKey(key string) tea.Cmd {
+	input, ok := m.macroModel.HandleRegisterKey(key)
+	if !ok {
+		return nil
+	}
+	if !input.Replay {
+		m.macroModel.StartRecording(input.Register)
+		return nil
+	}
+	cmd, err := m.replayMacro(input.Register, input.Count)
+	if err != nil {
+		slog.Error("Error while replaying macro", "register", input.Register, "error", err)
+		m.promptModal.ShowSPFActionResults(false, err.Error())
+		// The register key must not reach the prompt
+		m.firstTextInput = true
+	}
+	return cmd
+}
+
+func (m *model) replayMacro(register string, count int) (tea.Cmd, error) {
+	keys, ok := m.macroModel.Keys(register)
+	if !ok {
+		return nil, fmt.Errorf("no macro recorded in register %s", register)
+	}
+	if m.macroReplayDepth >= macro.MaxReplayDepth {
+		return nil, fmt.Errorf("macro %s is nested in more than %d replays", register, macro.MaxReplayDepth)
+	}
+	depth := m.macroReplayDepth + 1
+	cmds := make([]tea.Cmd, 0, count*len(keys))
+	for range count {
+		for _, key := range keys {
+			cmds = append(cmds, func() tea.Msg {
+				return replayedKeyMsg{key: key, depth: depth}
+			})
+		}
+	}
+	return tea.Sequence(cmds...), nil
+}
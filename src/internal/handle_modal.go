@@ -73,7 +73,7 @@ func (m *model) confirmRename() {
 	}
 
 	oldPath := panel.GetFocusedItem().Location
-	newPath := filepath.Join(panel.Location, panel.Rename.Value())
+	newPath := filepath.Join(filepath.Dir(oldPath), panel.Rename.Value())
 
 	// Rename the file
 	err := os.Rename(oldPath, newPath)
@@ -92,11 +92,9 @@ func (m *model) confirmSortOptions() {
 	m.sortModal.Close()
 }
 
-// Cancel search, this will clear all searchbar input
+// Cancel search, this will clear all searchbar input and unpin the filter
 func (m *model) cancelSearch() {
-	panel := m.getFocusedFilePanel()
-	panel.SearchBar.Blur()
-	panel.SearchBar.SetValue("")
+	m.getFocusedFilePanel().ClearSearch()
 }
 
 // Confirm search. This will exit the search bar and filter the files
@@ -10,6 +10,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	variable "github.com/yorukot/superfile/src/config"
@@ -34,6 +35,10 @@ func (m *model) enterPanel() {
 	}
 	selectedItem := panel.GetFocusedItem()
 	if selectedItem.Directory {
+		// Collapsed folders of the tree view are expanded before being entered
+		if panel.ExpandFocusedDirectory() {
+			return
+		}
 		targetPath := selectedItem.Location
 
 		if selectedItem.Info.Mode()&os.ModeSymlink != 0 {
@@ -64,6 +69,19 @@ func (m *model) enterPanel() {
 		// Continue with preview if file is not writable
 		slog.Error("Error while writing to chooser file, continuing with file open", "error", chooserErr)
 	}
+
+	if panel.IsInArchive() {
+		// Files inside archives only exist virtually, so external apps can't open them
+		slog.Warn("Cannot open files inside archives", "item", selectedItem.Location)
+		return
+	}
+	if archive.DetectFormat(selectedItem.Location).Browsable() {
+		err := m.updateCurrentFilePanelDir(selectedItem.Location)
+		if err != nil {
+			slog.Error("Error while browsing archive", "error", err, "target", selectedItem.Location)
+		}
+		return
+	}
 	m.executeOpenCommand()
 }
 
@@ -154,7 +172,54 @@ func (m *model) searchBarFocus() {
 	}
 
 	// config search bar width
-	panel.SearchBar.Width = m.fileModel.SinglePanelWidth - common.InnerPadding
+	panel.SearchBar.Width = panel.SearchBarWidth()
+}
+
+// Search the tree under the focused panel for the search bar value, and show
+// the results in the panel as they are found
+func (m *model) recursiveSearch() tea.Cmd {
+	panel := m.getFocusedFilePanel()
+	cmd, err := panel.StartRecursiveSearch(m.fileModel.DisplayDotFiles, common.Config.SearchIncludeIgnored)
+	if err != nil {
+		slog.Error("Error while starting recursive search", "error", err)
+		return nil
+	}
+	panel.SearchBar.Blur()
+	return cmd
+}
+
+func (m *model) contentSearch() tea.Cmd {
+	panel := m.getFocusedFilePanel()
+	cmd, err := panel.StartContentSearch(m.fileModel.DisplayDotFiles, common.Config.SearchIncludeIgnored)
+	if err != nil {
+		slog.Error("Error while starting content search", "error", err)
+		return nil
+	}
+	panel.SearchBar.Blur()
+	return cmd
+}
+
+// Move to the directory containing the focused item, with the cursor on it.
+// Used to leave the results of a recursive search
+func (m *model) jumpToContainingFolder() {
+	panel := m.getFocusedFilePanel()
+	if panel.Empty() {
+		return
+	}
+	location := panel.GetFocusedItem().Location
+	dir := filepath.Dir(location)
+	if !panel.ShowingSearchResults() && dir == panel.Location {
+		return
+	}
+	// Results directly under the panel location are in the same directory
+	panel.StopRecursiveSearch()
+	panel.TargetFile = filepath.Base(location)
+	if dir == panel.Location && !panel.FilterPinned {
+		panel.SearchBar.SetValue("")
+	}
+	if err := m.updateCurrentFilePanelDir(dir); err != nil {
+		slog.Error("Error while jumping to containing folder", "error", err, "target", dir)
+	}
 }
 
 func (m *model) sidebarSearchBarFocus() {
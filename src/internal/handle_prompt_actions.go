@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+	"github.com/yorukot/superfile/src/pkg/archive"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Actions for the file and panel commands of the SPF prompt
+
+func (m *model) executeFileCommandAction(action common.ModelAction) (string, tea.Cmd, error) {
+	switch action := action.(type) {
+	case common.CreateItemAction:
+		return "Item created", nil, m.createItemFromPrompt(action.Name, action.IsDir)
+	case common.RenameAction:
+		return "Item renamed", nil, m.renameFocusedItem(action.NewName)
+	case common.CopyToAction:
+		cmd, err := m.getCopyToCmd(action.Location, action.Cut)
+		if action.Cut {
+			return "Move started", cmd, err
+		}
+		return "Copy started", cmd, err
+	case common.DeleteAction:
+		cmd := m.getDeleteTriggerCmd(false)
+		if cmd == nil {
+			return "", nil, errors.New("no item to delete")
+		}
+		return "Confirm to delete", cmd, nil
+	case common.ExtractAction:
+		cmd, err := m.getExtractFileCmd()
+		return "Extraction started", cmd, err
+	case common.CompressToAction:
+		compressAction, err := m.getCompressToAction(action.Name)
+		if err != nil {
+			return "", nil, err
+		}
+		return m.logAndExecuteAction(compressAction)
+	default:
+		return "", nil, errors.New("unhandled file command action")
+	}
+}
+
+func (m *model) executePanelCommandAction(action common.ModelAction) (string, tea.Cmd, error) {
+	panel := m.getFocusedFilePanel()
+	switch action := action.(type) {
+	case common.SelectAction:
+		matched, err := panel.SelectMatchingItems(action.Pattern)
+		if err != nil {
+			return "", nil, err
+		}
+		if matched > 0 {
+			panel.PanelMode = filepanel.SelectMode
+		}
+		return fmt.Sprintf("%d items selected", matched), nil, nil
+	case common.UnselectAction:
+		panel.ResetSelected()
+		return "Selection cleared", nil, nil
+	case common.SortAction:
+		panel.SortKind = sortmodel.SortKind(action.Kind)
+		if action.SetOrder {
+			panel.SortReversed = action.Reversed
+		}
+		return "Sort options changed", nil, nil
+	case common.SetHiddenFilesAction:
+		if m.fileModel.DisplayDotFiles != action.Show {
+			m.toggleDotFileController()
+		}
+		return "Hidden files display changed", nil, nil
+	case common.SetPreviewAction:
+		var cmd tea.Cmd
+		if m.fileModel.FilePreview.IsOpen() != action.Show {
+			cmd = m.fileModel.ToggleFilePreviewPanel()
+		}
+		return "File preview display changed", cmd, nil
+	case common.TogglePinAction:
+		return "Pinned directories updated", nil, m.sidebarModel.TogglePinnedDirectory(panel.Location)
+	case common.ClosePanelAction:
+		cmd, err := m.fileModel.CloseFilePanel()
+		return "Panel closed", cmd, err
+	case common.FocusPanelAction:
+		if action.Index >= m.fileModel.PanelCount() {
+			return "", nil, fmt.Errorf("there are only %d panels", m.fileModel.PanelCount())
+		}
+		m.fileModel.MoveFocusedPanelBy(action.Index - m.fileModel.FocusedPanelIndex)
+		return "Panel focused", nil, nil
+	default:
+		return "", nil, errors.New("unhandled panel command action")
+	}
+}
+
+// Create a file, or a directory, at a path relative to the focused panel
+func (m *model) createItemFromPrompt(name string, isDir bool) error {
+	if err := m.checkFocusedPanelWritable("create"); err != nil {
+		return err
+	}
+	if err := checkFileNameValidity(name); err != nil {
+		return err
+	}
+	path := utils.ResolveAbsPath(m.getFocusedFilePanel().Location, name)
+	if _, err := os.Lstat(path); err == nil {
+		return fmt.Errorf("%s already exists", name)
+	}
+	if isDir {
+		return os.MkdirAll(path, utils.UserDirPerm)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), utils.UserDirPerm); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func (m *model) renameFocusedItem(newName string) error {
+	panel := m.getFocusedFilePanel()
+	if panel.Empty() {
+		return errors.New("no item to rename")
+	}
+	if err := m.checkFocusedPanelWritable("rename"); err != nil {
+		return err
+	}
+	if err := checkFileNameValidity(newName); err != nil {
+		return err
+	}
+	if strings.ContainsRune(newName, filepath.Separator) || strings.ContainsRune(newName, '/') {
+		return errors.New("new name cannot contain '/'")
+	}
+	newPath := filepath.Join(panel.Location, newName)
+	if _, err := os.Lstat(newPath); err == nil {
+		return fmt.Errorf("%s already exists", newName)
+	}
+	return os.Rename(panel.GetFocusedItem().Location, newPath)
+}
+
+// Copy, or move, the selected or focused items to a directory relative to the
+// focused panel
+func (m *model) getCopyToCmd(location string, cut bool) (tea.Cmd, error) {
+	items := m.getSelectedOrFocusedItems()
+	if len(items) == 0 {
+		return nil, errors.New("no item to copy")
+	}
+	if cut {
+		if err := m.checkFocusedPanelWritable("move"); err != nil {
+			return nil, err
+		}
+	}
+	dest := utils.ResolveAbsPath(m.getFocusedFilePanel().Location, location)
+	info, err := os.Stat(dest)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", location)
+	}
+	return m.getPasteCmd(items, dest, cut), nil
+}
+
+// The archive format is taken from the extension of name. Without a known
+// extension, the format last chosen in the compress modal is used
+func (m *model) getCompressToAction(name string) (common.CompressAction, error) {
+	sources := m.getSelectedOrFocusedItems()
+	if len(sources) == 0 {
+		return common.CompressAction{}, errors.New("no item to compress")
+	}
+	if err := m.checkFocusedPanelWritable("compress"); err != nil {
+		return common.CompressAction{}, err
+	}
+	target := utils.ResolveAbsPath(m.getFocusedFilePanel().Location, name)
+	format := archive.DetectFormat(name)
+	switch {
+	case format == archive.Unknown:
+		format = m.compressModal.GetFormat()
+		target += format.Extension()
+	case !format.Writable():
+		return common.CompressAction{}, fmt.Errorf("cannot create %s archives", format)
+	}
+	return common.CompressAction{
+		Sources: sources,
+		Target:  target,
+		Format:  format,
+		Level:   m.compressModal.GetLevel(),
+	}, nil
+}
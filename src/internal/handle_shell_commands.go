@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/internal/ui/prompt"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// New output of background shell commands is rendered at most this often
+const shellOutputRefreshInterval = 200 * time.Millisecond
+
+// Shell commands run in background are processes of the process bar. Their
+// output can be followed with the output modal, and they are killed like any
+// other process is cancelled
+
+func (m *model) getBackgroundShellCmd(shellCommand string) (tea.Cmd, error) {
+	return m.startBackgroundShellCommand(shellCommand, false, false)
+}
+
+// startBackgroundShellCommand adds the process of the command right away, so
+// that the output modal can follow it. With refreshAfter, the file panels are
+// refreshed once the command ends
+func (m *model) startBackgroundShellCommand(shellCommand string, refreshAfter bool,
+	openOutput bool) (tea.Cmd, error) {
+	dir := m.getFocusedFilePanel().Location
+	placeholders := m.getShellPlaceholders()
+	expanded, err := prompt.ExpandShellPlaceholders(shellCommand, placeholders)
+	if err != nil {
+		return nil, err
+	}
+	// The process is named after the command typed by the user
+	p, err := m.processBarModel.StartProcess(shellCommand, processbar.OpShellCommand, 1)
+	if err != nil {
+		return nil, err
+	}
+	if openOutput {
+		m.outputModal.Open(p)
+	}
+
+	reqID := m.ioReqCnt
+	m.ioReqCnt++
+
+	slog.Debug("Submitting background shell command request", "reqID", reqID, "command", expanded)
+	return func() tea.Msg {
+		state := runShellCommand(p, expanded, dir, placeholders.Env(), &m.processBarModel)
+		return NewShellCommandOperationMsg(state, refreshAfter, reqID)
+	}, nil
+}
+
+// runShellCommand runs expanded till it ends, as the process p
+func runShellCommand(p processbar.Process, expanded string, dir string, env []string,
+	processBar *processbar.Model) processbar.ProcessState {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Go(func() {
+		watchShellCommand(p, cancel, done, processBar)
+	})
+	var err error
+	p.ExitCode, err = utils.RunCommandInShell(ctx, dir, env, expanded, p.Output)
+	close(done)
+	// No update of the watcher should come after the final one
+	wg.Wait()
+
+	switch {
+	case p.CancelRequested():
+		p.State = processbar.Cancelled
+		p.ErrorMsg = "killed"
+	case err != nil:
+		slog.Error("Error while running shell command", "command", expanded, "error", err)
+		p.State = processbar.Failed
+		p.ErrorMsg = err.Error()
+	case p.ExitCode != 0:
+		p.State = processbar.Failed
+		p.ErrorMsg = fmt.Sprintf("exited with status %d", p.ExitCode)
+	default:
+		p.State = processbar.Successful
+		p.Done = p.Total
+	}
+	p.DoneTime = time.Now()
+	if pSendErr := processBar.SendUpdateProcessMsg(p, true); pSendErr != nil {
+		slog.Error("Error sending process update", "error", pSendErr)
+	}
+	return p.State
+}
+
+// watchShellCommand kills the command once cancelled from the process bar, and
+// sends updates so that new output is rendered. It returns once done is closed
+func watchShellCommand(p processbar.Process, kill context.CancelFunc, done <-chan struct{},
+	processBar *processbar.Model) {
+	ticker := time.NewTicker(shellOutputRefreshInterval)
+	defer ticker.Stop()
+	version := p.Output.Version()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if p.CancelRequested() {
+				kill()
+			}
+			if newVersion := p.Output.Version(); newVersion != version {
+				version = newVersion
+				processBar.TrySendingUpdateProcessMsg(p)
+			}
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 
 	"github.com/yorukot/superfile/src/internal/ui/notify"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -58,6 +59,9 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 	case slices.Contains(common.Hotkeys.ChangePanelMode, msg):
 		m.getFocusedFilePanel().ChangeFilePanelMode()
 
+	case slices.Contains(common.Hotkeys.ToggleTreeView, msg):
+		m.getFocusedFilePanel().ToggleTreeView()
+
 	case slices.Contains(common.Hotkeys.NextFilePanel, msg):
 		if m.focusPanel == nonePanelFocus {
 			m.fileModel.NextFilePanel()
@@ -112,11 +116,50 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 	case slices.Contains(common.Hotkeys.ToggleFooter, msg):
 		return m.toggleFooterController()
 
+	case slices.Contains(common.Hotkeys.CancelProcess, msg):
+		if m.focusPanel != processBarFocus {
+			return nil
+		}
+		if err := m.processBarModel.CancelSelectedProcess(); err != nil {
+			slog.Debug("Cannot cancel process", "error", err)
+		}
+
+	case slices.Contains(common.Hotkeys.ClearFinishedProcesses, msg):
+		if m.focusPanel != processBarFocus {
+			return nil
+		}
+		cleared := m.processBarModel.ClearFinished()
+		slog.Debug("Cleared finished processes", "count", cleared)
+
+	case slices.Contains(common.Hotkeys.OpenProcessHistory, msg):
+		return m.getProcessHistoryCmd()
+
 	case slices.Contains(common.Hotkeys.ExtractFile, msg):
-		return m.getExtractFileCmd()
+		cmd, err := m.getExtractFileCmd()
+		if err != nil {
+			slog.Error("Error while extracting file", "error", err)
+		}
+		return cmd
 
 	case slices.Contains(common.Hotkeys.CompressFile, msg):
-		return m.getCompressSelectedFilesCmd()
+		m.openCompressModal()
+
+	case slices.Contains(common.Hotkeys.AddToArchive, msg):
+		return m.getAddToArchiveCmd()
+
+	case slices.Contains(common.Hotkeys.TestArchive, msg):
+		return m.getTestArchiveCmd()
+
+	case slices.Contains(common.Hotkeys.GitStage, msg):
+		return m.getGitOperationCmd(processbar.OpGitStage)
+	case slices.Contains(common.Hotkeys.GitUnstage, msg):
+		return m.getGitOperationCmd(processbar.OpGitUnstage)
+	case slices.Contains(common.Hotkeys.GitDiscard, msg):
+		return m.getGitDiscardTriggerCmd()
+	case slices.Contains(common.Hotkeys.GitDiff, msg):
+		return m.getGitDiffCmd()
+	case slices.Contains(common.Hotkeys.GitCommit, msg):
+		return m.openGitCommitModal()
 
 	case slices.Contains(common.Hotkeys.OpenCommandLine, msg):
 		m.promptModal.Open(true)
@@ -128,6 +171,11 @@ func (m *model) mainKey(msg string) tea.Cmd { //nolint: gocyclo,cyclop,funlen //
 	case slices.Contains(common.Hotkeys.OpenHelpMenu, msg):
 		m.helpMenu.Open()
 
+	case slices.Contains(common.Hotkeys.RecordMacro, msg):
+		m.recordMacroKey()
+	case slices.Contains(common.Hotkeys.ReplayMacro, msg):
+		m.macroModel.AwaitReplayRegister()
+
 	case slices.Contains(common.Hotkeys.OpenSortOptionsMenu, msg):
 		m.sortModal.Open(m.getFocusedFilePanel().SortKind)
 
@@ -159,8 +207,14 @@ func (m *model) normalAndBrowserModeKey(msg string) tea.Cmd {
 		if m.focusPanel == sidebarFocus && slices.Contains(common.Hotkeys.SearchBar, msg) {
 			m.sidebarSearchBarFocus()
 		}
+		if m.focusPanel == processBarFocus && slices.Contains(common.Hotkeys.Confirm, msg) {
+			m.openProcessDetail()
+		}
 		return nil
 	}
+	if name, ok := common.CustomCommandForHotkey(msg); ok {
+		return m.runCustomCommand(common.CustomCommandAction{Name: name})
+	}
 	// Check if in the select mode and focusOn filepanel
 	if m.getFocusedFilePanel().PanelMode == filepanel.SelectMode {
 		switch {
@@ -186,6 +240,10 @@ func (m *model) normalAndBrowserModeKey(msg string) tea.Cmd {
 
 	switch {
 	case slices.Contains(common.Hotkeys.Confirm, msg):
+		// Matching lines of a content search open at their line
+		if !m.getFocusedFilePanel().Empty() && m.getFocusedFilePanel().GetFocusedItem().Line > 0 {
+			return m.openFileWithEditor()
+		}
 		m.enterPanel()
 	case slices.Contains(common.Hotkeys.ParentDirectory, msg):
 		m.parentDirectory()
@@ -201,6 +259,8 @@ func (m *model) normalAndBrowserModeKey(msg string) tea.Cmd {
 		m.panelItemRename()
 	case slices.Contains(common.Hotkeys.SearchBar, msg):
 		m.searchBarFocus()
+	case slices.Contains(common.Hotkeys.JumpToContainingFolder, msg):
+		m.jumpToContainingFolder()
 	case slices.Contains(common.Hotkeys.CopyPath, msg):
 		m.copyPath()
 	case slices.Contains(common.Hotkeys.CopyPWD, msg):
@@ -242,7 +302,9 @@ func (m *model) handleNotifyModelCancel(action notify.ConfirmActionType) tea.Cmd
 		m.cancelRename()
 	case notify.QuitAction:
 		m.modelQuitState = notQuitting
-	case notify.DeleteAction, notify.NoAction, notify.PermanentDeleteAction:
+	case notify.CustomCommandAction:
+		m.pendingCustomCommand = ""
+	case notify.DeleteAction, notify.NoAction, notify.PermanentDeleteAction, notify.GitDiscardAction:
 		// Do nothing
 	default:
 		slog.Error("Unknown type of action", "action", action)
@@ -260,6 +322,10 @@ func (m *model) handleNotifyModelConfirm(action notify.ConfirmActionType) tea.Cm
 		m.confirmRename()
 	case notify.QuitAction:
 		m.modelQuitState = quitConfirmationReceived
+	case notify.CustomCommandAction:
+		return m.confirmCustomCommand()
+	case notify.GitDiscardAction:
+		return m.getGitOperationCmd(processbar.OpGitDiscard)
 	case notify.NoAction:
 		// Ignore
 	default:
@@ -308,11 +374,20 @@ func (m *model) sidebarRenamingKey(msg string) {
 }
 
 // Check the key input and cancel or confirms the search
-func (m *model) focusOnSearchbarKey(msg string) {
+func (m *model) focusOnSearchbarKey(msg string) tea.Cmd {
 	switch {
 	case slices.Contains(common.Hotkeys.CancelTyping, msg):
 		m.cancelSearch()
 	case slices.Contains(common.Hotkeys.ConfirmTyping, msg):
 		m.confirmSearch()
+	case slices.Contains(common.Hotkeys.ToggleSearchMode, msg):
+		m.getFocusedFilePanel().CycleSearchMode()
+	case slices.Contains(common.Hotkeys.TogglePinFilter, msg):
+		m.getFocusedFilePanel().ToggleFilterPin()
+	case slices.Contains(common.Hotkeys.RecursiveSearch, msg):
+		return m.recursiveSearch()
+	case slices.Contains(common.Hotkeys.ContentSearch, msg):
+		return m.contentSearch()
 	}
+	return nil
 }
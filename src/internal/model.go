@@ -19,9 +19,12 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
+	"github.com/yorukot/superfile/src/internal/ui/macro"
 	"github.com/yorukot/superfile/src/internal/ui/metadata"
 	"github.com/yorukot/superfile/src/internal/ui/notify"
 	"github.com/yorukot/superfile/src/internal/ui/preview"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/internal/ui/prompt"
 
 	variable "github.com/yorukot/superfile/src/config"
 	zoxideui "github.com/yorukot/superfile/src/internal/ui/zoxide"
@@ -41,7 +44,23 @@ var (
 // be aware of it, and use it directly
 func InitialModel(firstPanelPaths []string, firstUseCheck bool) tea.Model {
 	toggleDotFile, toggleFooter, zClient := initialConfig(firstPanelPaths)
-	return defaultModelConfig(toggleDotFile, toggleFooter, firstUseCheck, firstPanelPaths, zClient)
+	m := defaultModelConfig(toggleDotFile, toggleFooter, firstUseCheck, firstPanelPaths, zClient)
+	if common.Config.ProcessHistory {
+		m.processBarModel.SetHistory(processbar.NewHistory(variable.ProcessHistoryFile))
+	}
+	if err := m.promptModal.LoadHistory(variable.SPFPromptHistoryFile, variable.ShellPromptHistoryFile); err != nil {
+		slog.Error("Error while loading prompt history", "error", err)
+	}
+	if err := m.macroModel.Load(variable.MacrosFile); err != nil {
+		slog.Error("Error while loading macros", "error", err)
+	}
+	startupCommands, err := getStartupCommands()
+	if err != nil {
+		slog.Error("Error while reading startup commands", "error", err)
+		m.promptModal.ShowSPFActionResults(false, err.Error())
+	}
+	m.startupCommands = startupCommands
+	return m
 }
 
 // Init function to be called by Bubble tea framework, sets windows title,
@@ -63,7 +82,16 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	slog.Debug("model.Update() called", "msgType", reflect.TypeOf(msg))
 
 	var sidebarCmd, inputCmd, updateCmd, panelCmd,
-		metadataCmd, filePreviewCmd, helpMenuCmd, resizeCmd tea.Cmd
+		metadataCmd, filePreviewCmd, columnValuesCmd, gitStatusCmd, helpMenuCmd, resizeCmd tea.Cmd
+
+	// Replayed keys are handled like typed keys, but are not recorded again
+	m.macroReplayDepth = 0
+	if replayed, ok := msg.(replayedKeyMsg); ok {
+		msg = replayed.key
+		m.macroReplayDepth = replayed.depth
+	} else if key, ok := msg.(tea.KeyMsg); ok {
+		m.macroModel.Record(key)
+	}
 
 	// These are above the key message handing to prevent issues with firstKeyInput
 	// if someone presses `/` to focus to searchBar, searchBar will otherwise
@@ -75,6 +103,9 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		resizeCmd = m.handleWindowResize(msg)
+		if len(m.startupCommands) > 0 {
+			resizeCmd = tea.Batch(resizeCmd, m.runStartupCommands())
+		}
 	case tea.MouseMsg:
 		m.handleMouseMsg(msg)
 	case tea.KeyMsg:
@@ -91,6 +122,12 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case preview.UpdateMsg:
 		slog.Debug("Got ModelUpdate message", "id", msg.GetReqID())
 		m.fileModel.UpdatePreviewPanel(msg)
+	case filepanel.ColumnValueMsg:
+		m.fileModel.ApplyColumnValue(msg)
+	case filepanel.GitStatusMsg:
+		m.fileModel.ApplyGitStatus(msg)
+	case filepanel.SearchResultsMsg:
+		updateCmd = m.fileModel.ApplySearchResults(msg)
 	case ModelUpdateMessage:
 		slog.Debug("Got ModelUpdate message", "id", msg.GetReqID())
 		updateCmd = msg.ApplyToModel(m)
@@ -104,11 +141,13 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	m.updateModelStateAfterMsg()
 	filePreviewCmd = m.fileModel.GetFilePreviewCmd(false)
+	columnValuesCmd = m.fileModel.GetColumnValuesCmd()
+	gitStatusCmd = m.fileModel.GetGitStatusCmd()
 
 	metadataCmd = m.getMetadataCmd()
 
 	return m, tea.Batch(sidebarCmd, helpMenuCmd, inputCmd, updateCmd,
-		panelCmd, metadataCmd, filePreviewCmd, resizeCmd)
+		panelCmd, metadataCmd, filePreviewCmd, columnValuesCmd, gitStatusCmd, resizeCmd)
 }
 
 func (m *model) handleMouseMsg(msg tea.MouseMsg) {
@@ -122,6 +161,18 @@ func (m *model) handleMouseMsg(msg tea.MouseMsg) {
 
 func (m *model) updateModelStateAfterMsg() {
 	m.sidebarModel.UpdateDirectories()
+	m.processBarModel.RemoveExpiredProcesses(time.Now())
+	// The process shown in the detail modal can still be running
+	if m.detailModal.IsOpen() {
+		if p, ok := m.processBarModel.GetByID(m.detailModal.ProcessID()); ok {
+			m.detailModal.SetProcess(p)
+		}
+	}
+	if m.outputModal.IsOpen() {
+		if p, ok := m.processBarModel.GetByID(m.outputModal.ProcessID()); ok {
+			m.outputModal.SetProcess(p)
+		}
+	}
 	m.fileModel.UpdateFilePanelsIfNeeded(false)
 	// TODO: Move to utility
 	if m.focusPanel != metadataFocus {
@@ -210,6 +261,9 @@ func (m *model) updateComponentDimensions() tea.Cmd {
 	m.setPromptModelSize()
 	m.setZoxideModelSize()
 	m.setFooterComponentSize()
+	m.historyModal.SetMaxDimensions(m.fullWidth, m.fullHeight)
+	m.detailModal.SetMaxDimensions(m.fullWidth, m.fullHeight)
+	m.outputModal.SetMaxDimensions(m.fullWidth, m.fullHeight)
 
 	// File preview panel requires explicit height update, unlike sidebar/file panels
 	// which receive height as render parameters and update automatically on each frame
@@ -278,9 +332,15 @@ func (m *model) handleKeyInput(msg tea.KeyMsg) tea.Cmd {
 		"typingModal.open", m.typingModal.open,
 		"notifyModel.open", m.notifyModel.IsOpen(),
 		"promptModal.open", m.promptModal.IsOpen(),
+		"compressModal.open", m.compressModal.IsOpen(),
+		"passwordModal.open", m.passwordModal.IsOpen(),
+		"gitCommitModal.open", m.gitCommitModal.IsOpen(),
 		"fileModel.renaming", m.fileModel.Renaming,
 		"searchBar.focused", m.getFocusedFilePanel().SearchBar.Focused(),
 		"helpMenu.open", m.helpMenu.IsOpen(),
+		"historyModal.open", m.historyModal.IsOpen(),
+		"detailModal.open", m.detailModal.IsOpen(),
+		"outputModal.open", m.outputModal.IsOpen(),
 		"firstTextInput", m.firstTextInput,
 		"focusPanel", m.focusPanel,
 	)
@@ -291,6 +351,8 @@ func (m *model) handleKeyInput(msg tea.KeyMsg) tea.Cmd {
 	var cmd tea.Cmd
 	cdOnQuit := common.Config.CdOnQuit
 	switch {
+	case m.macroModel.IsAwaitingRegister():
+		cmd = m.macroRegisterKey(msg.String())
 	case m.typingModal.open:
 		m.typingModalOpenKey(msg.String())
 	case m.promptModal.IsOpen():
@@ -300,6 +362,15 @@ func (m *model) handleKeyInput(msg tea.KeyMsg) tea.Cmd {
 	case m.zoxideModal.IsOpen():
 		// Ignore keypress. It will be handled in Update call via
 		// updateFilePanelState
+	case m.compressModal.IsOpen():
+		// Ignore keypress. It will be handled in Update call via
+		// updateFilePanelState
+	case m.passwordModal.IsOpen():
+		// Ignore keypress. It will be handled in Update call via
+		// updateFilePanelState
+	case m.gitCommitModal.IsOpen():
+		// Ignore keypress. It will be handled in Update call via
+		// updateFilePanelState
 
 	// Handles all warn models except the warn model for confirming to quit
 	case m.notifyModel.IsOpen():
@@ -312,7 +383,7 @@ func (m *model) handleKeyInput(msg tea.KeyMsg) tea.Cmd {
 		m.sidebarRenamingKey(msg.String())
 	// If search bar is open
 	case m.getFocusedFilePanel().SearchBar.Focused():
-		m.focusOnSearchbarKey(msg.String())
+		cmd = m.focusOnSearchbarKey(msg.String())
 	// If sort options menu is open
 	case m.sidebarModel.SearchBarFocused():
 		m.sidebarModel.HandleSearchBarKey(msg.String())
@@ -321,6 +392,12 @@ func (m *model) handleKeyInput(msg tea.KeyMsg) tea.Cmd {
 	// If help menu is open
 	case m.helpMenu.IsOpen():
 		m.helpMenu.HandleKey(msg.String())
+	case m.historyModal.IsOpen():
+		m.historyModal.HandleKey(msg.String())
+	case m.detailModal.IsOpen():
+		cmd = m.applyDetailModalAction(m.detailModal.HandleKey(msg.String()))
+	case m.outputModal.IsOpen():
+		m.outputModal.HandleKey(msg.String())
 
 	case slices.Contains(common.Hotkeys.Quit, msg.String()):
 		m.modelQuitState = quitInitiated
@@ -375,6 +452,15 @@ func (m *model) updateComponentState(msg tea.Msg) tea.Cmd {
 	case m.zoxideModal.IsOpen():
 		action, cmd = m.zoxideModal.HandleUpdate(msg)
 		cmd = tea.Batch(cmd, m.applyZoxideModalAction(action))
+	case m.compressModal.IsOpen():
+		action, cmd = m.compressModal.HandleUpdate(msg)
+		cmd = tea.Batch(cmd, m.applyCompressModalAction(action))
+	case m.passwordModal.IsOpen():
+		action, cmd = m.passwordModal.HandleUpdate(msg)
+		cmd = tea.Batch(cmd, m.applyPasswordModalAction(action))
+	case m.gitCommitModal.IsOpen():
+		action, cmd = m.gitCommitModal.HandleUpdate(msg)
+		cmd = tea.Batch(cmd, m.applyGitCommitModalAction(action))
 	}
 	return cmd
 }
@@ -387,6 +473,10 @@ func (m *model) applyPromptModalAction(action common.ModelAction) tea.Cmd {
 	} else if successMsg != "" {
 		m.promptModal.HandleSPFActionResults(true, successMsg)
 	}
+	// Confirmations can't get any key press while the prompt is open
+	if _, ok := action.(common.DeleteAction); (ok || m.notifyModel.IsOpen()) && actionErr == nil {
+		m.promptModal.Close()
+	}
 	return cmd
 }
 
@@ -401,9 +491,21 @@ func (m *model) logAndExecuteAction(action common.ModelAction) (string, tea.Cmd,
 	case common.NoAction:
 		return "", nil, nil
 	case common.ShellCommandAction:
-		// Shell commands are handled separately and don't return here
-		m.applyShellCommandAction(action.Command)
-		return "", nil, nil
+		if action.Background {
+			cmd, err := m.getBackgroundShellCmd(action.Command)
+			return "Command started in background", cmd, err
+		}
+		// Results of shell commands are handled separately, only errors are returned here
+		return "", nil, m.applyShellCommandAction(action.Command)
+	case common.CustomCommandAction:
+		return m.executeCustomCommand(action)
+	case common.ReplayMacroAction:
+		cmd, err := m.replayMacro(action.Register, action.Count)
+		// Replayed keys are meant for the panels, not for the prompt it may come from
+		if err == nil {
+			m.promptModal.Close()
+		}
+		return "", cmd, err
 	case common.SplitPanelAction:
 		cmd, err := m.splitPanel()
 		return "Panel successfully split", cmd, err
@@ -412,6 +514,27 @@ func (m *model) logAndExecuteAction(action common.ModelAction) (string, tea.Cmd,
 	case common.OpenPanelAction:
 		cmd, err := m.createNewFilePanelRelativeToCurrent(action.Location)
 		return "New panel opened", cmd, err
+	case common.CompressAction:
+		if _, err := os.Stat(action.Target); err == nil {
+			return "", nil, errors.New("file already exists")
+		}
+		return "Compression started", m.getCompressCmd(action), nil
+	case common.ExtractArchiveAction:
+		return "Extraction started", m.getExtractArchiveCmd(action.ArchivePath, action.Password,
+			action.RememberPassword), nil
+	case common.RetryFailedAction:
+		cmd, err := m.getRetryFailedCmd(action.ProcessID)
+		return "Retry started", cmd, err
+	case common.CopyErrorListAction:
+		return "Error list copied", nil, m.copyErrorList(action.ProcessID)
+	case common.GitCommitAction:
+		return "Commit started", m.getGitCommitCmd(action), nil
+	case common.CreateItemAction, common.RenameAction, common.CopyToAction, common.DeleteAction,
+		common.ExtractAction, common.CompressToAction:
+		return m.executeFileCommandAction(action)
+	case common.SelectAction, common.UnselectAction, common.SortAction, common.SetHiddenFilesAction,
+		common.SetPreviewAction, common.TogglePinAction, common.ClosePanelAction, common.FocusPanelAction:
+		return m.executePanelCommandAction(action)
 	default:
 		return "", nil, errors.New("unhandled action type")
 	}
@@ -423,19 +546,103 @@ func (m *model) applyZoxideModalAction(action common.ModelAction) tea.Cmd {
 	return cmd
 }
 
-// TODO : Move them around to appropriate places
-func (m *model) applyShellCommandAction(shellCommand string) {
-	focusPanelDir := m.getFocusedFilePanel().Location
+// Apply the Action for compress modal. Modal stays open if the action fails
+// so that the user could fix the archive name
+func (m *model) applyCompressModalAction(action common.ModelAction) tea.Cmd {
+	if _, ok := action.(common.NoAction); ok {
+		return nil
+	}
+	_, cmd, err := m.logAndExecuteAction(action)
+	if err != nil {
+		m.compressModal.SetError(err.Error())
+		return nil
+	}
+	m.compressModal.Close()
+	return cmd
+}
+
+// Apply the Action for password modal. A wrong password opens the modal again
+// once the extraction fails
+func (m *model) applyPasswordModalAction(action common.ModelAction) tea.Cmd {
+	if _, ok := action.(common.NoAction); ok {
+		return nil
+	}
+	_, cmd, _ := m.logAndExecuteAction(action)
+	m.passwordModal.Close()
+	return cmd
+}
+
+// Apply the Action for git commit modal. The modal closes itself on confirm
+func (m *model) applyGitCommitModalAction(action common.ModelAction) tea.Cmd {
+	if _, ok := action.(common.NoAction); ok {
+		return nil
+	}
+	_, cmd, _ := m.logAndExecuteAction(action)
+	return cmd
+}
 
-	retCode, output, err := utils.ExecuteCommandInShell(common.DefaultCommandTimeout, focusPanelDir, shellCommand)
+// Apply the Action for the process detail modal. The modal closes once a retry
+// starts, and shows the result of other actions
+func (m *model) applyDetailModalAction(action common.ModelAction) tea.Cmd {
+	if _, ok := action.(common.NoAction); ok {
+		return nil
+	}
+	successMsg, cmd, err := m.logAndExecuteAction(action)
+	if err != nil {
+		m.detailModal.SetNotice(err.Error(), true)
+		return nil
+	}
+	if _, ok := action.(common.RetryFailedAction); ok {
+		m.detailModal.Close()
+		return cmd
+	}
+	m.detailModal.SetNotice(successMsg, false)
+	return cmd
+}
 
+// TODO : Move them around to appropriate places
+func (m *model) applyShellCommandAction(shellCommand string) error {
+	retCode, output, err := m.executeShellCommand(shellCommand)
+	if err != nil {
+		return err
+	}
 	m.promptModal.HandleShellCommandResults(retCode, output)
+	return nil
+}
+
+// executeShellCommand runs the command with its placeholders expanded, and
+// waits for it. Only the expansion errors are returned
+func (m *model) executeShellCommand(shellCommand string) (int, string, error) {
+	focusPanelDir := m.getFocusedFilePanel().Location
+	placeholders := m.getShellPlaceholders()
+	shellCommand, err := prompt.ExpandShellPlaceholders(shellCommand, placeholders)
+	if err != nil {
+		return 0, "", err
+	}
 
+	retCode, output, err := utils.ExecuteCommandInShellWithEnv(common.DefaultCommandTimeout, focusPanelDir,
+		placeholders.Env(), shellCommand)
 	if err != nil {
 		slog.Error("Command execution failed", "retCode", retCode,
 			"error", err, "output", output)
-		return
 	}
+	return retCode, output, nil
+}
+
+// Values of %f, %s, %d and %D for shell commands
+func (m *model) getShellPlaceholders() prompt.ShellPlaceholders {
+	panel := m.getFocusedFilePanel()
+	placeholders := prompt.ShellPlaceholders{
+		SelectedFiles: m.getSelectedOrFocusedItems(),
+		CurrentDir:    panel.Location,
+	}
+	if !panel.Empty() {
+		placeholders.FocusedFile = panel.GetFocusedItem().Location
+	}
+	if count := m.fileModel.PanelCount(); count > 1 {
+		placeholders.OtherDir = m.fileModel.FilePanels[(m.fileModel.FocusedPanelIndex+1)%count].Location
+	}
+	return placeholders
 }
 
 func (m *model) splitPanel() (tea.Cmd, error) {
@@ -451,7 +658,8 @@ func (m *model) createNewFilePanelRelativeToCurrent(path string) (tea.Cmd, error
 func (m *model) updateCurrentFilePanelDir(path string) error {
 	panel := m.getFocusedFilePanel()
 	err := panel.UpdateCurrentFilePanelDir(path)
-	if err == nil {
+	// Directories inside archives don't exist for zoxide
+	if err == nil && !panel.IsInArchive() {
 		// Track the directory change with zoxide
 		m.trackDirectoryWithZoxide(panel.Location)
 	}
@@ -507,7 +715,12 @@ func (m *model) View() string {
 		slog.Error("Invalid layout", "error", err)
 	}
 
-	return m.updateRenderForOverlay(m.mainComponentsRender())
+	finalRender := m.mainComponentsRender()
+	if m.macroModel.IsRecording() {
+		finalRender = stringfunction.PlaceOverlay(macro.IndicatorOffset, m.fullHeight-1,
+			m.macroModel.RenderIndicator(), finalRender)
+	}
+	return m.updateRenderForOverlay(finalRender)
 }
 
 func (m *model) updateRenderForOverlay(finalRender string) string {
@@ -533,6 +746,48 @@ func (m *model) updateRenderForOverlay(finalRender string) string {
 		return stringfunction.PlaceOverlay(overlayX, overlayY, zoxideModal, finalRender)
 	}
 
+	if m.compressModal.IsOpen() {
+		compressModal := m.compressModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.compressModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.compressModal.GetHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, compressModal, finalRender)
+	}
+
+	if m.outputModal.IsOpen() {
+		outputModal := m.outputModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.outputModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.outputModal.GetHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, outputModal, finalRender)
+	}
+
+	if m.detailModal.IsOpen() {
+		detailModal := m.detailModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.detailModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.detailModal.GetHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, detailModal, finalRender)
+	}
+
+	if m.historyModal.IsOpen() {
+		historyModal := m.historyModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.historyModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.historyModal.GetHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, historyModal, finalRender)
+	}
+
+	if m.passwordModal.IsOpen() {
+		passwordModal := m.passwordModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.passwordModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.passwordModal.GetHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, passwordModal, finalRender)
+	}
+
+	if m.gitCommitModal.IsOpen() {
+		gitCommitModal := m.gitCommitModal.Render()
+		overlayX := m.fullWidth/common.CenterDivisor - m.gitCommitModal.GetWidth()/common.CenterDivisor
+		overlayY := m.fullHeight/common.CenterDivisor - m.gitCommitModal.GetHeight()/common.CenterDivisor
+		return stringfunction.PlaceOverlay(overlayX, overlayY, gitCommitModal, finalRender)
+	}
+
 	if m.sortModal.IsOpen() {
 		sortOptions := m.sortModal.Render()
 		overlayX := m.fullWidth/common.CenterDivisor - m.sortModal.Width/common.CenterDivisor
@@ -587,6 +842,7 @@ func (m *model) quitSuperfile(cdOnQuit bool) {
 		_ = et.Close()
 	}
 	m.fileModel.FilePreview.CleanUp()
+	m.processBarModel.FlushHistory()
 
 	// cd on quit
 	currentDir := m.getFocusedFilePanel().Location
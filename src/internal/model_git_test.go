@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/notify"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/git"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func altKeyMsg(key rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}, Alt: true}
+}
+
+func TestGitActions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.name", "test"},
+		{"config", "user.email", "test@test"},
+	} {
+		require.NoError(t, exec.Command("git", append([]string{"-C", root}, args...)...).Run())
+	}
+	utils.SetupFilesWithData(t, []byte("content"), filepath.Join(root, "file.txt"))
+	getState := func() git.FileState {
+		status, err := git.GetStatus(root)
+		require.NoError(t, err)
+		return status.State("file.txt", false)
+	}
+	// Returns the latest process, once count processes are finished
+	waitForProcess := func(m *model, count int) processbar.Process {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			processes := m.processBarModel.GetProcessesSlice()
+			return len(processes) == count && !slices.ContainsFunc(processes, func(p processbar.Process) bool {
+				return !p.Finished()
+			})
+		}, DefaultTestTimeout, DefaultTestTick, "Git process should finish")
+		return slices.MaxFunc(m.processBarModel.GetProcessesSlice(), func(a, b processbar.Process) int {
+			return a.StartTime.Compare(b.StartTime)
+		})
+	}
+
+	m := defaultTestModel(root)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	setFilePanelSelectedItemByName(t, m.getFocusedFilePanel(), "file.txt")
+
+	p.Send(altKeyMsg('a'))
+	process := waitForProcess(m, 1)
+	assert.Equal(t, processbar.OpGitStage, process.Operation)
+	assert.Equal(t, processbar.Successful, process.State)
+	assert.Equal(t, git.StateStaged, getState())
+
+	p.Send(altKeyMsg('c'))
+	require.Eventually(t, m.gitCommitModal.IsOpen, DefaultTestTimeout, DefaultTestTick,
+		"Commit modal should open")
+	p.SendKey("Add file")
+	p.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	process = waitForProcess(m, 2)
+	assert.Equal(t, processbar.Successful, process.State)
+	assert.Equal(t, git.StateClean, getState())
+
+	// Discarding asks for a confirmation
+	utils.SetupFilesWithData(t, []byte("changed"), filepath.Join(root, "file.txt"))
+	p.Send(altKeyMsg('r'))
+	require.Eventually(t, m.notifyModel.IsOpen, DefaultTestTimeout, DefaultTestTick,
+		"Confirmation should open")
+	assert.Equal(t, notify.GitDiscardAction, m.notifyModel.GetConfirmAction())
+	p.SendKey(common.Hotkeys.ConfirmTyping[0])
+	waitForProcess(m, 3)
+	assert.Equal(t, git.StateClean, getState())
+
+	// Outside of a repository
+	m = defaultTestModel(t.TempDir())
+	msg := ExecuteTeaCmdWithTimeout(TeaUpdate(m, altKeyMsg('a')), DefaultTestTimeout)
+	require.IsType(t, NotifyModalUpdateMsg{}, msg)
+	TeaUpdate(m, msg)
+	assert.True(t, m.notifyModel.IsOpen())
+	assert.Empty(t, m.processBarModel.GetProcessesSlice())
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/macro"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestMacros(t *testing.T) {
+	curTestDir := t.TempDir()
+	files := make([]string, 0, 30)
+	for i := range 30 {
+		files = append(files, filepath.Join(curTestDir, fmt.Sprintf("file%02d.txt", i)))
+	}
+	utils.SetupFiles(t, files...)
+
+	recordKey := common.Hotkeys.RecordMacro[0]
+	replayKey := common.Hotkeys.ReplayMacro[0]
+	down := common.Hotkeys.ListDown[0]
+
+	// Records keys into register, and puts the cursor back at the top
+	recordMacro := func(t *testing.T, m *model, register string, keys ...string) {
+		t.Helper()
+		TeaUpdate(m, utils.TeaRuneKeyMsg(recordKey))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(register))
+		require.True(t, m.macroModel.IsRecording())
+		assert.Contains(t, m.View(), "recording @"+register)
+		for _, key := range keys {
+			TeaUpdate(m, utils.TeaRuneKeyMsg(key))
+		}
+		TeaUpdate(m, utils.TeaRuneKeyMsg(recordKey))
+		require.False(t, m.macroModel.IsRecording())
+		m.getFocusedFilePanel().SetCursorPosition(0)
+	}
+	waitForCursor := func(t *testing.T, p *TeaProg, cursor int) {
+		t.Helper()
+		assert.Eventually(t, func() bool {
+			return p.getModel().getFocusedFilePanel().GetCursor() == cursor
+		}, DefaultTestTimeout, DefaultTestTick, "Cursor should reach %d", cursor)
+	}
+
+	t.Run("Record and replay with a count", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		recordMacro(t, m, "a", down, down)
+		keys, ok := m.macroModel.Keys("a")
+		require.True(t, ok)
+		assert.Equal(t, []tea.KeyMsg{utils.TeaRuneKeyMsg(down), utils.TeaRuneKeyMsg(down)}, keys)
+
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.SendKey(replayKey)
+		p.SendKey("a")
+		waitForCursor(t, p, 2)
+		p.SendKey(replayKey)
+		p.SendKey("3")
+		p.SendKey("a")
+		waitForCursor(t, p, 8)
+		assert.False(t, p.getModel().macroModel.IsRecording())
+	})
+
+	t.Run("Replayed keys are not recorded again", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		recordMacro(t, m, "a", down)
+		recordMacro(t, m, "b", down, replayKey, "a")
+
+		keys, ok := m.macroModel.Keys("b")
+		require.True(t, ok)
+		assert.Len(t, keys, 3)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.SendKey(replayKey)
+		p.SendKey("b")
+		waitForCursor(t, p, 2)
+	})
+
+	t.Run("Recursive macro stops at the depth limit", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		// Replaying an empty register while recording would open the prompt
+		recordMacro(t, m, "a", down)
+		recordMacro(t, m, "a", down, replayKey, "a")
+
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.SendKey(replayKey)
+		p.SendKey("a")
+		assert.Eventually(t, func() bool {
+			return p.getModel().promptModal.IsOpen()
+		}, DefaultTestTimeout, DefaultTestTick, "Depth limit error should be shown")
+		m = p.getModel()
+		assert.False(t, m.promptModal.LastActionSucceeded())
+		assert.Equal(t, macro.MaxReplayDepth, m.getFocusedFilePanel().GetCursor())
+	})
+
+	t.Run("Empty register shows an error", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		TeaUpdate(m, utils.TeaRuneKeyMsg(replayKey))
+		TeaUpdate(m, utils.TeaRuneKeyMsg("z"))
+		require.True(t, m.promptModal.IsOpen())
+		assert.False(t, m.promptModal.LastActionSucceeded())
+		assert.Contains(t, m.promptModal.Render(), "no macro recorded in register z")
+	})
+
+	t.Run("Other keys cancel the register input", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		TeaUpdate(m, utils.TeaRuneKeyMsg(recordKey))
+		TeaUpdate(m, tea.KeyMsg{Type: tea.KeyEsc})
+		assert.False(t, m.macroModel.IsAwaitingRegister())
+		assert.False(t, m.macroModel.IsRecording())
+		TeaUpdate(m, utils.TeaRuneKeyMsg(down))
+		assert.Equal(t, 1, m.getFocusedFilePanel().GetCursor())
+	})
+
+	t.Run("Replay from the prompt", func(t *testing.T) {
+		m := defaultTestModel(curTestDir)
+		recordMacro(t, m, "a", down)
+
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.SendKey(common.Hotkeys.OpenSPFPrompt[0])
+		p.SendKey("macro a 5")
+		p.Send(tea.KeyMsg{Type: tea.KeyEnter})
+		waitForCursor(t, p, 5)
+		assert.False(t, p.getModel().promptModal.IsOpen(), "Prompt should close for the replayed keys")
+	})
+}
@@ -118,6 +118,141 @@ func (msg ExtractOperationMsg) ApplyToModel(_ *model) tea.Cmd {
 	return nil
 }
 
+type TestArchiveOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+}
+
+func NewTestArchiveOperationMsg(state processbar.ProcessState, reqID int) TestArchiveOperationMsg {
+	return TestArchiveOperationMsg{
+		state: state,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg TestArchiveOperationMsg) ApplyToModel(_ *model) tea.Cmd {
+	return nil
+}
+
+type ShellCommandOperationMsg struct {
+	BaseMessage
+
+	state        processbar.ProcessState
+	refreshAfter bool
+}
+
+func NewShellCommandOperationMsg(state processbar.ProcessState, refreshAfter bool,
+	reqID int) ShellCommandOperationMsg {
+	return ShellCommandOperationMsg{
+		state:        state,
+		refreshAfter: refreshAfter,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg ShellCommandOperationMsg) ApplyToModel(m *model) tea.Cmd {
+	if msg.refreshAfter {
+		m.fileModel.UpdateFilePanelsIfNeeded(true)
+	}
+	return nil
+}
+
+type GitOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+	root  string
+}
+
+func NewGitOperationMsg(state processbar.ProcessState, root string, reqID int) GitOperationMsg {
+	return GitOperationMsg{
+		state: state,
+		root:  root,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+// Failed operations can still have changed the repository partially
+func (msg GitOperationMsg) ApplyToModel(m *model) tea.Cmd {
+	m.fileModel.InvalidateGitStatus(msg.root)
+	m.fileModel.UpdateFilePanelsIfNeeded(true)
+	return nil
+}
+
+type RetryOperationMsg struct {
+	BaseMessage
+
+	state processbar.ProcessState
+}
+
+func NewRetryOperationMsg(state processbar.ProcessState, reqID int) RetryOperationMsg {
+	return RetryOperationMsg{
+		state: state,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg RetryOperationMsg) ApplyToModel(_ *model) tea.Cmd {
+	return nil
+}
+
+// ProcessHistoryMsg opens the history modal with the entries read from the
+// process history file
+type ProcessHistoryMsg struct {
+	BaseMessage
+
+	entries []processbar.HistoryEntry
+	err     error
+}
+
+func NewProcessHistoryMsg(entries []processbar.HistoryEntry, err error, reqID int) ProcessHistoryMsg {
+	return ProcessHistoryMsg{
+		entries: entries,
+		err:     err,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg ProcessHistoryMsg) ApplyToModel(m *model) tea.Cmd {
+	m.historyModal.Open(msg.entries, msg.err)
+	return nil
+}
+
+// PasswordRequiredMsg asks for the password of an encrypted archive before
+// extracting it
+type PasswordRequiredMsg struct {
+	BaseMessage
+
+	archivePath   string
+	wrongPassword bool
+}
+
+func NewPasswordRequiredMsg(archivePath string, wrongPassword bool, reqID int) PasswordRequiredMsg {
+	return PasswordRequiredMsg{
+		archivePath:   archivePath,
+		wrongPassword: wrongPassword,
+		BaseMessage: BaseMessage{
+			reqID: reqID,
+		},
+	}
+}
+
+func (msg PasswordRequiredMsg) ApplyToModel(m *model) tea.Cmd {
+	m.passwordModal.Open(msg.archivePath, msg.wrongPassword)
+	return nil
+}
+
 type MetadataMsg struct {
 	BaseMessage
 
@@ -1,6 +1,20 @@
 package internal
 
-import "testing"
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
 
 func TestProcess(_ *testing.T) {
 	// TODO :
@@ -13,3 +27,101 @@ func TestProcess(_ *testing.T) {
 	// 3 - Process progress tracking
 
 }
+
+func TestClearFinishedProcesses(t *testing.T) {
+	m := defaultTestModel(testDir)
+	TeaUpdate(m, tea.WindowSizeMsg{Width: 2 * common.MinimumWidth, Height: 2 * common.MinimumHeight})
+	running := processbar.NewProcess("1", "running.txt", processbar.OpCopy, 2)
+	finished := processbar.NewProcess("2", "finished.txt", processbar.OpCopy, 1)
+	finished.State = processbar.Successful
+	finished.DoneTime = time.Now()
+	require.NoError(t, m.processBarModel.AddProcess(running))
+	require.NoError(t, m.processBarModel.AddProcess(finished))
+
+	clearKey := common.Hotkeys.ClearFinishedProcesses[0]
+	TeaUpdate(m, utils.TeaRuneKeyMsg(clearKey))
+	assert.Len(t, m.processBarModel.GetProcessesSlice(), 2,
+		"Processes should only be cleared when the process bar is focused")
+
+	m.focusPanel = processBarFocus
+	TeaUpdate(m, utils.TeaRuneKeyMsg(clearKey))
+	processes := m.processBarModel.GetProcessesSlice()
+	require.Len(t, processes, 1)
+	assert.Equal(t, "1", processes[0].ID)
+}
+
+func TestProcessHistoryModal(t *testing.T) {
+	m := defaultTestModel(testDir)
+	TeaUpdate(m, tea.WindowSizeMsg{Width: 2 * common.MinimumWidth, Height: 2 * common.MinimumHeight})
+
+	entry := processbar.HistoryEntry{
+		Operation: processbar.OpDelete.String(),
+		Name:      "old.txt",
+		State:     processbar.Failed.String(),
+		Files:     1,
+		Error:     "permission denied",
+	}
+	TeaUpdate(m, NewProcessHistoryMsg([]processbar.HistoryEntry{entry}, nil, 0))
+	require.True(t, m.historyModal.IsOpen())
+	assert.Contains(t, m.View(), "permission denied")
+
+	TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.ListDown[0]))
+	assert.True(t, m.historyModal.IsOpen(), "Keys should go to the history modal")
+	TeaUpdate(m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.historyModal.IsOpen())
+}
+
+func TestBackgroundShellCommand(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Commands use /bin/sh")
+	}
+	curTestDir := t.TempDir()
+	utils.SetupFiles(t, filepath.Join(curTestDir, "file.txt"))
+
+	startCommand := func(t *testing.T, command string) (*TeaProg, processbar.Process) {
+		t.Helper()
+		// Footer is needed to focus on the process bar
+		m := defaultTestModelWithFooterAndFilePreview(curTestDir)
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.SendKey(common.Hotkeys.OpenCommandLine[0])
+		p.SendKey(command)
+		p.Send(tea.KeyMsg{Type: tea.KeyEnter})
+		require.Eventually(t, func() bool {
+			return len(m.processBarModel.GetProcessesSlice()) == 1
+		}, DefaultTestTimeout, DefaultTestTick, "Command should be added to the process bar")
+		assert.True(t, m.promptModal.LastActionSucceeded())
+		p.Send(tea.KeyMsg{Type: tea.KeyEsc})
+		return p, m.processBarModel.GetProcessesSlice()[0]
+	}
+
+	t.Run("Exit code and output are kept", func(t *testing.T) {
+		p, process := startCommand(t, "& echo $fx; exit 3")
+		m := p.getModel()
+		assert.Equal(t, "echo $fx; exit 3", process.Name, "Process should be named after the typed command")
+		require.Eventually(t, func() bool {
+			return m.processBarModel.GetProcessesSlice()[0].Finished()
+		}, DefaultTestTimeout, DefaultTestTick, "Command should finish")
+
+		process = m.processBarModel.GetProcessesSlice()[0]
+		assert.Equal(t, processbar.Failed, process.State)
+		assert.Equal(t, 3, process.ExitCode)
+		assert.Equal(t, filepath.Join(curTestDir, "file.txt"), strings.TrimSpace(process.Output.String()))
+
+		// The output can be opened from the process bar
+		p.SendKey(common.Hotkeys.FocusOnProcessBar[0])
+		p.SendKey(common.Hotkeys.Confirm[0])
+		require.Eventually(t, m.outputModal.IsOpen, DefaultTestTimeout, DefaultTestTick,
+			"Output modal should open")
+		assert.Contains(t, m.outputModal.Render(), "file.txt")
+	})
+
+	t.Run("Command is killed from the process bar", func(t *testing.T) {
+		p, _ := startCommand(t, "& sleep 30")
+		m := p.getModel()
+		p.SendKey(common.Hotkeys.FocusOnProcessBar[0])
+		p.SendKey(common.Hotkeys.CancelProcess[0])
+		require.Eventually(t, func() bool {
+			return m.processBarModel.GetProcessesSlice()[0].State == processbar.Cancelled
+		}, 2*DefaultTestTimeout, DefaultTestTick, "Command should be killed")
+	})
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui/prompt"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 )
 
 func TestModel_Update_Prompt(t *testing.T) {
@@ -403,3 +404,245 @@ func testShellCommandsWithQuotes(t *testing.T, curTestDir, dir1 string) {
 		})
 	})
 }
+
+func TestPromptFileAndPanelCommands(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	file1 := filepath.Join(dir1, "file1.txt")
+	file2 := filepath.Join(dir1, "file2.txt")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, file1, file2, filepath.Join(dir1, "notes.md"))
+
+	runCommand := func(m *model, command string) {
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.OpenSPFPrompt[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(command))
+		TeaUpdate(m, tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	t.Run("Create and rename items", func(t *testing.T) {
+		m := defaultTestModel(dir2)
+		runCommand(m, prompt.MkdirCommand+" a/b")
+		assert.True(t, m.promptModal.LastActionSucceeded())
+		assert.DirExists(t, filepath.Join(dir2, "a", "b"))
+
+		runCommand(m, prompt.TouchCommand+" a/new.txt")
+		assert.True(t, m.promptModal.LastActionSucceeded())
+		assert.FileExists(t, filepath.Join(dir2, "a", "new.txt"))
+
+		runCommand(m, prompt.TouchCommand+" a/new.txt")
+		assert.False(t, m.promptModal.LastActionSucceeded(), "Existing files should not be overwritten")
+
+		m = defaultTestModel(dir1)
+		setFilePanelSelectedItemByName(t, m.getFocusedFilePanel(), "notes.md")
+		runCommand(m, prompt.RenameCommand+" file1.txt")
+		assert.False(t, m.promptModal.LastActionSucceeded(), "Rename should not overwrite existing files")
+		runCommand(m, prompt.RenameCommand+" todo.md")
+		assert.True(t, m.promptModal.LastActionSucceeded())
+		assert.FileExists(t, filepath.Join(dir1, "todo.md"))
+		assert.NoFileExists(t, filepath.Join(dir1, "notes.md"))
+		require.NoError(t, os.Rename(filepath.Join(dir1, "todo.md"), filepath.Join(dir1, "notes.md")))
+	})
+
+	t.Run("Select, sort and panel commands", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		panel := m.getFocusedFilePanel()
+		runCommand(m, prompt.SelectCommand+" *.txt")
+		assert.True(t, m.promptModal.LastActionSucceeded())
+		assert.ElementsMatch(t, []string{file1, file2}, panel.GetSelectedLocations())
+		runCommand(m, prompt.UnselectCommand)
+		assert.Empty(t, panel.GetSelectedLocations())
+
+		runCommand(m, prompt.SortCommand+" size desc")
+		assert.Equal(t, sortmodel.SortBySize, panel.SortKind)
+		assert.True(t, panel.SortReversed)
+		runCommand(m, prompt.SortCommand+" date")
+		assert.Equal(t, sortmodel.SortByDate, panel.SortKind)
+		assert.True(t, panel.SortReversed, "Order should be kept when not given")
+
+		runCommand(m, prompt.PreviewCommand+" on")
+		assert.True(t, m.fileModel.FilePreview.IsOpen())
+
+		runCommand(m, prompt.SplitCommand)
+		require.Equal(t, 2, m.fileModel.PanelCount())
+		runCommand(m, prompt.FocusCommand+" 1")
+		assert.Equal(t, 0, m.fileModel.FocusedPanelIndex)
+		runCommand(m, prompt.FocusCommand+" 3")
+		assert.False(t, m.promptModal.LastActionSucceeded())
+		runCommand(m, prompt.CloseCommand)
+		assert.True(t, m.promptModal.LastActionSucceeded())
+		assert.Equal(t, 1, m.fileModel.PanelCount())
+		runCommand(m, prompt.CloseCommand)
+		assert.False(t, m.promptModal.LastActionSucceeded(), "Last panel should not be closed")
+	})
+
+	t.Run("Copy and delete", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		setFilePanelSelectedItemByName(t, m.getFocusedFilePanel(), "file2.txt")
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.SendKey(common.Hotkeys.OpenSPFPrompt[0])
+		p.SendKey(prompt.CopyCommand + " ../dir2")
+		p.Send(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(filepath.Join(dir2, "file2.txt"))
+			return err == nil
+		}, DefaultTestTimeout, DefaultTestTick, "file2.txt should be copied to dir2")
+
+		// Trailing space, as "delete" alone is the key name of the delete key
+		p.SendKey(prompt.DeleteCommand + " ")
+		p.Send(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Eventually(t, func() bool {
+			return !p.getModel().promptModal.IsOpen() && p.getModel().notifyModel.IsOpen()
+		}, DefaultTestTimeout, DefaultTestTick, "Prompt should make way for the delete confirmation")
+	})
+}
+
+func TestShellCommandPlaceholders(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Commands use /bin/sh")
+	}
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, filepath.Join(dir1, "file one.txt"), filepath.Join(dir1, "file2.txt"))
+
+	runShellCommand := func(m *model, command string) {
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.OpenCommandLine[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg(command))
+		TeaUpdate(m, tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	t.Run("Selected files are copied to the other panel", func(t *testing.T) {
+		m := defaultTestModel(dir1, dir2)
+		m.getFocusedFilePanel().SelectAllItem()
+		runShellCommand(m, "cp %s %D")
+		require.True(t, m.promptModal.LastActionSucceeded())
+		assert.FileExists(t, filepath.Join(dir2, "file one.txt"))
+		assert.FileExists(t, filepath.Join(dir2, "file2.txt"))
+	})
+
+	t.Run("Focused file is exposed as $fx", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		runShellCommand(m, `test "$fx" = %f && test "$fs" = "$fx"`)
+		assert.True(t, m.promptModal.LastActionSucceeded())
+	})
+
+	t.Run("Missing other panel is an error", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		runShellCommand(m, "ls %D")
+		assert.False(t, m.promptModal.LastActionSucceeded())
+		assert.True(t, m.promptModal.IsOpen())
+	})
+}
+
+func TestCustomCommands(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Commands use /bin/sh")
+	}
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	utils.SetupDirectories(t, dir1, dir2)
+	utils.SetupFiles(t, filepath.Join(dir1, "file.txt"))
+
+	originalCommands := common.Config.Commands
+	t.Cleanup(func() {
+		common.Config.Commands = originalCommands
+	})
+	altKey := func(key string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key), Alt: true}
+	}
+	common.Config.Commands = map[string]common.CustomCommand{
+		"mark":   {Run: "touch marked", Hotkey: "ctrl+g", RefreshAfter: true},
+		"fail":   {Run: "echo broken; exit 2", Hotkey: "alt+f"},
+		"twin":   {SPF: "mkdir twin", Hotkey: "alt+t", Confirm: true},
+		"follow": {Run: "echo %f", Hotkey: "alt+o", Background: true, OpenOutput: true},
+	}
+
+	t.Run("Hotkey runs the command and refreshes panels", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		TeaUpdate(m, tea.KeyMsg{Type: tea.KeyCtrlG})
+		assert.FileExists(t, filepath.Join(dir1, "marked"))
+		assert.False(t, m.promptModal.IsOpen(), "Successful commands should not open the prompt")
+		panel := m.getFocusedFilePanel()
+		names := []string{}
+		for i := range panel.ElemCount() {
+			names = append(names, panel.GetElementAtIdx(i).Name)
+		}
+		assert.Contains(t, names, "marked", "Panel should be refreshed")
+	})
+
+	t.Run("Failed command shows its output", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		TeaUpdate(m, altKey("f"))
+		require.True(t, m.promptModal.IsOpen())
+		assert.False(t, m.promptModal.LastActionSucceeded())
+		assert.Contains(t, m.promptModal.Render(), "broken")
+	})
+
+	t.Run("Command waits for confirmation", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		TeaUpdate(m, altKey("t"))
+		require.True(t, m.notifyModel.IsOpen())
+		assert.NoDirExists(t, filepath.Join(dir1, "twin"))
+		TeaUpdate(m, tea.KeyMsg{Type: tea.KeyEnter})
+		assert.DirExists(t, filepath.Join(dir1, "twin"))
+	})
+
+	t.Run("Background command opens its output", func(t *testing.T) {
+		m := defaultTestModel(dir1)
+		focused := m.getFocusedFilePanel().GetFocusedItem().Location
+		p := NewTestTeaProgWithEventLoop(t, m)
+		p.Send(altKey("o"))
+		require.Eventually(t, func() bool {
+			processes := p.getModel().processBarModel.GetProcessesSlice()
+			return len(processes) == 1 && processes[0].Finished()
+		}, DefaultTestTimeout, DefaultTestTick, "Command should finish")
+		m = p.getModel()
+		assert.True(t, m.outputModal.IsOpen())
+		assert.Contains(t, m.outputModal.Render(), focused)
+	})
+
+	t.Run("Command is run by name from the prompt", func(t *testing.T) {
+		m := defaultTestModel(dir2)
+		TeaUpdate(m, utils.TeaRuneKeyMsg(common.Hotkeys.OpenSPFPrompt[0]))
+		TeaUpdate(m, utils.TeaRuneKeyMsg("mark"))
+		TeaUpdate(m, tea.KeyMsg{Type: tea.KeyEnter})
+		assert.True(t, m.promptModal.LastActionSucceeded())
+		assert.FileExists(t, filepath.Join(dir2, "marked"))
+	})
+}
+
+func TestStartupCommands(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir1 := filepath.Join(curTestDir, "dir1")
+	dir2 := filepath.Join(curTestDir, "dir2")
+	utils.SetupDirectories(t, dir1, dir2)
+
+	startModel := func(commands ...string) *model {
+		m := defaultModelConfig(false, false, false, []string{dir1}, nil)
+		m.startupCommands = commands
+		return setModelParamsForTest(m, true)
+	}
+
+	t.Run("Commands run in order once the window size is known", func(t *testing.T) {
+		m := startModel("split", "cd "+dir2, "mkdir created", "sort size desc")
+		assert.Equal(t, 2, m.fileModel.PanelCount())
+		assert.Equal(t, dir1, m.fileModel.FilePanels[0].Location)
+		assert.Equal(t, dir2, m.getFocusedFilePanel().Location)
+		assert.DirExists(t, filepath.Join(dir2, "created"))
+		assert.True(t, m.getFocusedFilePanel().SortReversed)
+		assert.False(t, m.promptModal.IsOpen())
+		assert.Empty(t, m.startupCommands)
+	})
+
+	t.Run("First failure stops the commands", func(t *testing.T) {
+		m := startModel("split", "cd /nonexistent/dir", "mkdir never")
+		require.True(t, m.promptModal.IsOpen())
+		assert.False(t, m.promptModal.LastActionSucceeded())
+		assert.Contains(t, m.promptModal.Render(), "cd /nonexistent/dir")
+		assert.NoDirExists(t, filepath.Join(dir1, "never"))
+		assert.Equal(t, 2, m.fileModel.PanelCount())
+	})
+}
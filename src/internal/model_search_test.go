@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestRecursiveSearch(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	utils.SetupDirectories(t, nested)
+	utils.SetupFiles(t, filepath.Join(root, "top.txt"), filepath.Join(nested, "target.txt"),
+		filepath.Join(nested, "other.go"))
+
+	m := defaultTestModel(root)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	panel := m.getFocusedFilePanel()
+
+	p.SendKey(common.Hotkeys.SearchBar[0])
+	p.SendKey("target")
+	p.SendKey(common.Hotkeys.RecursiveSearch[0])
+	require.Eventually(t, func() bool {
+		return panel.ShowingSearchResults() && panel.ElemCount() == 1
+	}, DefaultTestTimeout, DefaultTestTick, "Search results should be shown")
+	assert.Equal(t, filepath.Join("a", "b", "target.txt"), panel.GetFocusedItem().Name)
+	assert.False(t, panel.SearchBar.Focused())
+
+	p.SendKey(common.Hotkeys.JumpToContainingFolder[0])
+	require.Eventually(t, func() bool {
+		return panel.Location == nested && panel.GetFocusedItem().Name == "target.txt"
+	}, DefaultTestTimeout, DefaultTestTick, "Cursor should be on the result in its folder")
+	assert.False(t, panel.ShowingSearchResults())
+	assert.Empty(t, panel.SearchBar.Value())
+}
+
+func TestContentSearch(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a")
+	utils.SetupDirectories(t, nested)
+	require.NoError(t, os.WriteFile(filepath.Join(nested, "notes.txt"), []byte("first\nneedle here\n"), 0o644))
+	utils.SetupFiles(t, filepath.Join(root, "empty.txt"))
+
+	m := defaultTestModel(root)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	panel := m.getFocusedFilePanel()
+
+	p.SendKey(common.Hotkeys.SearchBar[0])
+	p.SendKey("needle")
+	p.SendKey(common.Hotkeys.ContentSearch[0])
+	require.Eventually(t, func() bool {
+		return panel.ShowingSearchResults() && panel.ElemCount() == 1
+	}, DefaultTestTimeout, DefaultTestTick, "Matching lines should be shown")
+	item := panel.GetFocusedItem()
+	assert.Equal(t, filepath.Join("a", "notes.txt"), item.Name)
+	assert.Equal(t, 2, item.Line)
+	assert.Equal(t, "needle here", item.LineText)
+}
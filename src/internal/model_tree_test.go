@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestTreeView(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Skipping for windows")
+	}
+	root := t.TempDir()
+	dir1 := filepath.Join(root, "dir1")
+	nested := filepath.Join(dir1, "nested")
+	dest := filepath.Join(root, "dest")
+	file1 := filepath.Join(dir1, "file1.txt")
+	file2 := filepath.Join(nested, "file2.txt")
+	utils.SetupDirectories(t, nested, dest)
+	utils.SetupFiles(t, file1, file2)
+
+	m := defaultTestModel(root)
+	p := NewTestTeaProgWithEventLoop(t, m)
+	panel := m.getFocusedFilePanel()
+
+	p.SendKeyDirectly(common.Hotkeys.ToggleTreeView[0])
+	require.True(t, panel.TreeView)
+	setFilePanelSelectedItemByLocation(t, panel, dir1)
+	p.SendKeyDirectly(common.Hotkeys.Confirm[0])
+	require.NotEqual(t, -1, panel.FindElementIndexByLocation(nested), "Confirm should expand the folder")
+	assert.Equal(t, root, panel.Location)
+	setFilePanelSelectedItemByLocation(t, panel, nested)
+	p.SendKeyDirectly(common.Hotkeys.Confirm[0])
+	require.NotEqual(t, -1, panel.FindElementIndexByLocation(file2))
+
+	t.Run("Rename nested item", func(t *testing.T) {
+		setFilePanelSelectedItemByLocation(t, panel, file2)
+		p.SendKey(common.Hotkeys.FilePanelItemRename[0])
+		p.Send(tea.KeyMsg{Type: tea.KeyBackspace})
+		p.SendKey("3")
+		p.Send(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(filepath.Join(nested, "file3.txt"))
+			return err == nil
+		}, DefaultTestTimeout, DefaultTestTick, "Item should be renamed in its folder")
+		file2 = filepath.Join(nested, "file3.txt")
+	})
+
+	t.Run("Copy items of several levels", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			return panel.FindElementIndexByLocation(file2) != -1
+		}, DefaultTestTimeout, DefaultTestTick)
+		p.SendKeyDirectly(common.Hotkeys.ChangePanelMode[0])
+		setFilePanelSelectedItemByLocation(t, panel, file1)
+		p.SendKeyDirectly(common.Hotkeys.Confirm[0])
+		setFilePanelSelectedItemByLocation(t, panel, file2)
+		p.SendKeyDirectly(common.Hotkeys.Confirm[0])
+		p.SendKeyDirectly(common.Hotkeys.CopyItems[0])
+		p.SendKeyDirectly(common.Hotkeys.ChangePanelMode[0])
+
+		setFilePanelSelectedItemByLocation(t, panel, dest)
+		p.SendKeyDirectly(common.Hotkeys.Confirm[0])
+		p.SendKeyDirectly(common.Hotkeys.Confirm[0])
+		require.Eventually(t, func() bool { return panel.Location == dest },
+			DefaultTestTimeout, DefaultTestTick, "Confirm should enter an expanded folder")
+		p.SendKey(common.Hotkeys.PasteItems[0])
+		assert.Eventually(t, func() bool {
+			_, err1 := os.Stat(filepath.Join(dest, "file1.txt"))
+			_, err2 := os.Stat(filepath.Join(dest, "file3.txt"))
+			return err1 == nil && err2 == nil
+		}, DefaultTestTimeout, DefaultTestTick, "Selected items should be pasted")
+	})
+
+	t.Run("Delete nested item", func(t *testing.T) {
+		p.SendKey(common.Hotkeys.ParentDirectory[0])
+		require.Eventually(t, func() bool {
+			return panel.Location == root && panel.FindElementIndexByLocation(file1) != -1
+		}, DefaultTestTimeout, DefaultTestTick, "Expanded folders should be remembered")
+		setFilePanelSelectedItemByLocation(t, panel, file1)
+		p.SendKey(common.Hotkeys.PermanentlyDeleteItems[0])
+		require.Eventually(t, m.notifyModel.IsOpen, DefaultTestTimeout, DefaultTestTick)
+		p.Send(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(file1)
+			return os.IsNotExist(err)
+		}, DefaultTestTimeout, DefaultTestTick, "Nested item should be deleted")
+	})
+}
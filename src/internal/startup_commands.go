@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	variable "github.com/yorukot/superfile/src/config"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/prompt"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Startup commands are SPF prompt commands of --script and --command, or else
+// of the startup_script config option. They run once the first window size is
+// known, as the count of panels that fit is unknown before
+
+// getStartupCommands returns the commands of the script, followed by those of
+// --command
+func getStartupCommands() ([]string, error) {
+	script := variable.StartupScript
+	if script == "" && variable.StartupCommand == "" && common.Config.StartupScript != "" {
+		// Relative paths are relative to the config file
+		script = utils.ResolveAbsPath(filepath.Dir(variable.ConfigFile), common.Config.StartupScript)
+	}
+
+	var commands []string
+	if script != "" {
+		data, err := os.ReadFile(script)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read startup script : %w", err)
+		}
+		commands = prompt.SplitPromptCommands(string(data))
+	}
+	return append(commands, prompt.SplitPromptCommands(variable.StartupCommand)...), nil
+}
+
+// runStartupCommands runs the startup commands in order. The first failure stops
+// them, and is shown in the prompt
+func (m *model) runStartupCommands() tea.Cmd {
+	commands := m.startupCommands
+	m.startupCommands = nil
+
+	cmds := make([]tea.Cmd, 0, len(commands))
+	for _, command := range commands {
+		cmd, err := m.runStartupCommand(command)
+		cmds = append(cmds, cmd)
+		if err != nil {
+			slog.Error("Startup command failed", "command", command, "error", err)
+			m.promptModal.ShowSPFActionResults(false,
+				fmt.Sprintf("Startup command '%s' failed : %s", command, err.Error()))
+			break
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *model) runStartupCommand(command string) (tea.Cmd, error) {
+	action, err := prompt.GetSPFAction(command, m.getFocusedFilePanel().Location)
+	if err != nil {
+		return nil, err
+	}
+	_, cmd, err := m.logAndExecuteAction(action)
+	return cmd, err
+}
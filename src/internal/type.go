@@ -6,6 +6,13 @@ import (
 	"github.com/yorukot/superfile/src/internal/ui/helpmenu"
 
 	"github.com/yorukot/superfile/src/internal/ui/clipboard"
+	"github.com/yorukot/superfile/src/internal/ui/compressmodal"
+	"github.com/yorukot/superfile/src/internal/ui/gitcommitmodal"
+	"github.com/yorukot/superfile/src/internal/ui/historymodal"
+	"github.com/yorukot/superfile/src/internal/ui/macro"
+	"github.com/yorukot/superfile/src/internal/ui/outputmodal"
+	"github.com/yorukot/superfile/src/internal/ui/passwordmodal"
+	"github.com/yorukot/superfile/src/internal/ui/processdetailmodal"
 	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 
 	"github.com/yorukot/superfile/src/internal/ui/filemodel"
@@ -63,6 +70,23 @@ type model struct {
 	zoxideModal zoxideui.Model
 	sortModal   sortmodel.Model
 
+	compressModal  compressmodal.Model
+	passwordModal  passwordmodal.Model
+	gitCommitModal gitcommitmodal.Model
+	historyModal   historymodal.Model
+	detailModal    processdetailmodal.Model
+	outputModal    outputmodal.Model
+
+	// User-defined command waiting for the confirmation of notifyModel
+	pendingCustomCommand string
+	// SPF prompt commands run on the first window resize
+	startupCommands []string
+
+	macroModel macro.Model
+	// Number of macro replays the key being handled comes from, 0 for keys
+	// typed by the user
+	macroReplayDepth int
+
 	// Zoxide client for directory tracking
 	zClient *zoxidelib.Client
 
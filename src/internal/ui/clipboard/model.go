@@ -8,6 +8,7 @@ import (
 
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui"
+	"github.com/yorukot/superfile/src/pkg/archive"
 )
 
 // The fact that its visible in UI or not, is controlled by the main model
@@ -44,7 +45,7 @@ func (m *Model) Render() string {
 				// TODO: Avoid Lstat during render for performance
 				// Add IsDir/IsLink information in the item type or
 				// better use filepanel's Element strcut as-is
-				fileInfo, err := os.Lstat(m.items.items[i])
+				fileInfo, err := archive.Lstat(m.items.items[i])
 				if err != nil {
 					slog.Error("Clipboard render function get item state ", "error", err)
 					continue
@@ -78,7 +79,7 @@ func (m *Model) SetItems(items []string) {
 
 func (m *Model) pruneInaccessibleItems() {
 	m.items.items = slices.DeleteFunc(m.items.items, func(item string) bool {
-		_, err := os.Lstat(item)
+		_, err := archive.Lstat(item)
 		return err != nil
 	})
 }
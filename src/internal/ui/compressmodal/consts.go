@@ -0,0 +1,25 @@
+package compressmodal
+
+const (
+	compressHeadlineText = "Compress"
+
+	// Including borders
+	modalWidth  = 60
+	modalHeight = 13
+
+	// Label column width, so that the values line up
+	labelWidth = 8
+
+	emptyNameError = "Archive name cannot be empty"
+	slashNameError = "Archive name cannot contain a path separator"
+)
+
+type field int
+
+const (
+	nameField field = iota
+	formatField
+	levelField
+	passwordField
+	fieldCount
+)
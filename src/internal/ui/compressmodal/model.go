@@ -0,0 +1,183 @@
+package compressmodal
+
+import (
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/archive"
+)
+
+func New() Model {
+	m := Model{
+		headline:      icon.CompressFile + icon.Space + compressHeadlineText,
+		formats:       archive.WritableFormats(),
+		nameInput:     common.GeneratePromptTextInput(),
+		passwordInput: common.GeneratePromptTextInput(),
+		level:         archive.DefaultLevel,
+		width:         modalWidth,
+		height:        modalHeight,
+	}
+	m.nameInput.Width = modalWidth - labelWidth - common.InnerPadding
+	m.passwordInput.Width = m.nameInput.Width
+	m.passwordInput.EchoMode = textinput.EchoPassword
+	m.passwordInput.EchoCharacter = '*'
+	m.passwordInput.Placeholder = "none"
+	return m
+}
+
+// Open the modal for compressing sources into location. baseName is the
+// initial archive name without the extension.
+func (m *Model) Open(location string, sources []string, baseName string) {
+	m.open = true
+	m.location = location
+	m.sources = sources
+	m.errorMsg = ""
+	m.nameInput.SetValue(baseName)
+	m.nameInput.CursorEnd()
+	// Password is never remembered across openings
+	m.passwordInput.SetValue("")
+	m.setFocus(nameField)
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.sources = nil
+	m.errorMsg = ""
+	m.nameInput.Blur()
+	m.nameInput.SetValue("")
+	m.passwordInput.Blur()
+	m.passwordInput.SetValue("")
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// SetError is used to report errors that are found after the action was
+// returned, like an already existing archive
+func (m *Model) SetError(msg string) {
+	m.errorMsg = msg
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetHeight() int {
+	return m.height
+}
+
+// Format and level are remembered across openings, so that users don't need
+// to choose them for every archive
+func (m *Model) GetFormat() archive.Format {
+	return m.formats[m.formatIdx]
+}
+
+func (m *Model) GetLevel() int {
+	return m.level
+}
+
+func (m *Model) HandleUpdate(msg tea.Msg) (common.ModelAction, tea.Cmd) {
+	var action common.ModelAction = common.NoAction{}
+	var cmd tea.Cmd
+	if !m.IsOpen() {
+		slog.Error("HandleUpdate called on closed compress modal")
+		return action, cmd
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// Non keypress updates like Cursor Blink
+		if m.focus == passwordField {
+			m.passwordInput, cmd = m.passwordInput.Update(msg)
+		} else {
+			m.nameInput, cmd = m.nameInput.Update(msg)
+		}
+		return action, cmd
+	}
+
+	key := keyMsg.String()
+	switch {
+	case slices.Contains(common.Hotkeys.ConfirmTyping, key):
+		action = m.handleConfirm()
+	case slices.Contains(common.Hotkeys.CancelTyping, key):
+		m.Close()
+	case key == "tab" || key == "down":
+		m.setFocus((m.focus + 1) % fieldCount)
+	case key == "shift+tab" || key == "up":
+		m.setFocus((m.focus - 1 + fieldCount) % fieldCount)
+	case m.focus == formatField && (key == "left" || key == "right"):
+		m.cycleFormat(key == "right")
+	case m.focus == levelField && (key == "left" || key == "right"):
+		m.changeLevel(key == "right")
+	case m.focus == nameField:
+		m.errorMsg = ""
+		m.nameInput, cmd = m.nameInput.Update(msg)
+	case m.focus == passwordField && m.GetFormat().SupportsEncryption():
+		m.passwordInput, cmd = m.passwordInput.Update(msg)
+	}
+	return action, cmd
+}
+
+// setFocus moves the focus to f. Only the focused input shows the cursor
+func (m *Model) setFocus(f field) {
+	m.focus = f
+	m.nameInput.Blur()
+	m.passwordInput.Blur()
+	switch f {
+	case nameField:
+		_ = m.nameInput.Focus()
+	case passwordField:
+		_ = m.passwordInput.Focus()
+	case formatField, levelField, fieldCount:
+	}
+}
+
+func (m *Model) handleConfirm() common.ModelAction {
+	name := strings.TrimSpace(m.nameInput.Value())
+	switch {
+	case name == "":
+		m.errorMsg = emptyNameError
+		return common.NoAction{}
+	case strings.ContainsRune(name, filepath.Separator) || strings.ContainsRune(name, '/'):
+		m.errorMsg = slashNameError
+		return common.NoAction{}
+	}
+	password := ""
+	if m.GetFormat().SupportsEncryption() {
+		password = m.passwordInput.Value()
+	}
+	return common.CompressAction{
+		Sources:  m.sources,
+		Target:   filepath.Join(m.location, name+m.GetFormat().Extension()),
+		Format:   m.GetFormat(),
+		Level:    m.level,
+		Password: password,
+	}
+}
+
+func (m *Model) cycleFormat(forward bool) {
+	step := -1
+	if forward {
+		step = 1
+	}
+	m.formatIdx = (m.formatIdx + step + len(m.formats)) % len(m.formats)
+}
+
+func (m *Model) changeLevel(increase bool) {
+	if !m.GetFormat().SupportsLevel() {
+		return
+	}
+	if increase {
+		m.level = min(m.level+1, archive.MaxLevel)
+	} else {
+		m.level = max(m.level-1, archive.MinLevel)
+	}
+}
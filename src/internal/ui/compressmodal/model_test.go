@@ -0,0 +1,102 @@
+package compressmodal
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/archive"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestMain(m *testing.M) {
+	common.RunTestsWithConfig(m)
+}
+
+func sendKeys(m *Model, keys ...tea.KeyType) common.ModelAction {
+	var action common.ModelAction = common.NoAction{}
+	for _, k := range keys {
+		action, _ = m.HandleUpdate(tea.KeyMsg{Type: k})
+	}
+	return action
+}
+
+func TestCompressModal(t *testing.T) {
+	location := filepath.Join("tmp", "dir")
+	sources := []string{filepath.Join(location, "a.txt")}
+
+	t.Run("Confirm with defaults", func(t *testing.T) {
+		m := New()
+		m.Open(location, sources, "a")
+		action := sendKeys(&m, tea.KeyEnter)
+		assert.Equal(t, common.CompressAction{
+			Sources: sources,
+			Target:  filepath.Join(location, "a.zip"),
+			Format:  archive.Zip,
+			Level:   archive.DefaultLevel,
+		}, action)
+	})
+
+	t.Run("Change format and level", func(t *testing.T) {
+		m := New()
+		m.Open(location, sources, "a")
+		// Move to format, pick tar.gz, then move to level and increase it
+		action := sendKeys(&m, tea.KeyDown, tea.KeyRight, tea.KeyRight,
+			tea.KeyDown, tea.KeyRight, tea.KeyEnter)
+		require.IsType(t, common.CompressAction{}, action)
+		compressAction, _ := action.(common.CompressAction)
+		assert.Equal(t, archive.TarGz, compressAction.Format)
+		assert.Equal(t, archive.DefaultLevel+1, compressAction.Level)
+		assert.Equal(t, filepath.Join(location, "a.tar.gz"), compressAction.Target)
+	})
+
+	t.Run("Password for zip", func(t *testing.T) {
+		m := New()
+		m.Open(location, sources, "a")
+		sendKeys(&m, tea.KeyDown, tea.KeyDown, tea.KeyDown)
+		m.HandleUpdate(utils.TeaRuneKeyMsg("secret"))
+		action := sendKeys(&m, tea.KeyEnter)
+		require.IsType(t, common.CompressAction{}, action)
+		compressAction, _ := action.(common.CompressAction)
+		assert.Equal(t, "secret", compressAction.Password)
+		assert.NotContains(t, compressAction.String(), "secret",
+			"Password must not be part of logged action")
+
+		m.Open(location, sources, "a")
+		action = sendKeys(&m, tea.KeyEnter)
+		compressAction, _ = action.(common.CompressAction)
+		assert.Empty(t, compressAction.Password, "Password should not be kept across openings")
+	})
+
+	t.Run("Password is ignored for formats without encryption", func(t *testing.T) {
+		m := New()
+		m.Open(location, sources, "a")
+		// Pick tar, then move to password
+		sendKeys(&m, tea.KeyDown, tea.KeyRight, tea.KeyDown, tea.KeyDown)
+		m.HandleUpdate(utils.TeaRuneKeyMsg("secret"))
+		action := sendKeys(&m, tea.KeyEnter)
+		compressAction, _ := action.(common.CompressAction)
+		assert.Equal(t, archive.Tar, compressAction.Format)
+		assert.Empty(t, compressAction.Password)
+	})
+
+	t.Run("Empty name is rejected", func(t *testing.T) {
+		m := New()
+		m.Open(location, sources, "")
+		action := sendKeys(&m, tea.KeyEnter)
+		assert.Equal(t, common.NoAction{}, action)
+		assert.Equal(t, emptyNameError, m.errorMsg)
+		assert.True(t, m.IsOpen())
+	})
+
+	t.Run("Cancel closes the modal", func(t *testing.T) {
+		m := New()
+		m.Open(location, sources, "a")
+		sendKeys(&m, tea.KeyEsc)
+		assert.False(t, m.IsOpen())
+	})
+}
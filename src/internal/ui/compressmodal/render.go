@@ -0,0 +1,57 @@
+package compressmodal
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+)
+
+func (m *Model) Render() string {
+	r := ui.CompressModalRenderer(m.height, m.width)
+	r.SetBorderTitle(m.headline)
+	r.SetBorderInfoItems(fmt.Sprintf("%d items", len(m.sources)))
+
+	r.AddLines(m.renderLabel(nameField, "Name") + m.nameInput.View())
+	r.AddSection()
+
+	format := m.GetFormat()
+	r.AddLines(m.renderLabel(formatField, "Format") + m.renderChoice(formatField, format.String()))
+	level := "n/a"
+	if format.SupportsLevel() {
+		level = strconv.Itoa(m.level)
+	}
+	r.AddLines(m.renderLabel(levelField, "Level") + m.renderChoice(levelField, level))
+	if format.SupportsEncryption() {
+		r.AddLines(m.renderLabel(passwordField, "Password") + m.passwordInput.View())
+	} else {
+		r.AddLines(m.renderLabel(passwordField, "Password") + m.renderChoice(passwordField, "n/a"))
+	}
+	r.AddSection()
+
+	r.AddLines(" " + common.TruncateTextBeginning(
+		m.nameInput.Value()+format.Extension(), m.width-common.InnerPadding, "..."))
+	if m.errorMsg != "" {
+		r.AddLines(common.ModalErrorStyle.Render(" " + m.errorMsg))
+	}
+	r.AddLines("", " "+common.ModalConfirm.Render(" ("+common.Hotkeys.ConfirmTyping[0]+") Compress ")+
+		common.ModalInputSpacingText+
+		common.ModalCancel.Render(" ("+common.Hotkeys.CancelTyping[0]+") Cancel "))
+	return r.Render()
+}
+
+func (m *Model) renderLabel(f field, label string) string {
+	cursor := "  "
+	if m.focus == f {
+		cursor = common.ModalCursorStyle.Render("> ")
+	}
+	return cursor + common.ModalStyle.Render(fmt.Sprintf("%-*s", labelWidth, label))
+}
+
+func (m *Model) renderChoice(f field, value string) string {
+	if m.focus != f {
+		return common.ModalStyle.Render("  " + value)
+	}
+	return common.ModalCursorStyle.Render("< " + value + " >")
+}
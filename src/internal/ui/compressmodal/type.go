@@ -0,0 +1,32 @@
+package compressmodal
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/yorukot/superfile/src/pkg/archive"
+)
+
+// Modal to choose name, format and compression level of a new archive.
+// No need to name it as CompressModel. It will be imported as compressmodal.Model
+type Model struct {
+	// Configuration
+	headline string
+	formats  []archive.Format
+
+	// State
+	open      bool
+	focus     field
+	nameInput textinput.Model
+	formatIdx int
+	// Only used for formats supporting encryption
+	passwordInput textinput.Model
+	level         int
+	errorMsg      string
+
+	// Directory in which the archive will be created, and the items to be added
+	location string
+	sources  []string
+
+	width  int
+	height int
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui/filepanel"
 	"github.com/yorukot/superfile/src/internal/ui/preview"
+	"github.com/yorukot/superfile/src/pkg/git"
 )
 
 func (m *Model) CreateNewFilePanel(location string) (tea.Cmd, error) {
@@ -41,6 +42,7 @@ func (m *Model) CloseFilePanel() (tea.Cmd, error) {
 		return nil, ErrMinimumPanelCount
 	}
 
+	m.FilePanels[m.FocusedPanelIndex].StopRecursiveSearch()
 	m.FilePanels = append(m.FilePanels[:m.FocusedPanelIndex],
 		m.FilePanels[m.FocusedPanelIndex+1:]...)
 
@@ -65,9 +67,9 @@ func (m *Model) UpdatePreviewPanel(msg preview.UpdateMsg) {
 		slog.Debug("Panel empty or cursor invalid. Ignoring FilePreviewUpdateMsg")
 		return
 	}
-	if selectedItem.Location != msg.GetLocation() {
+	if selectedItem.PreviewLocation() != msg.GetLocation() {
 		slog.Debug("FilePreviewUpdateMsg for older files. Ignoring",
-			"curLocation", selectedItem.Location, "msgLocation", msg.GetLocation())
+			"curLocation", selectedItem.PreviewLocation(), "msgLocation", msg.GetLocation())
 		return
 	}
 
@@ -92,11 +94,12 @@ func (m *Model) GetFilePreviewCmd(forcePreviewRender bool) tea.Cmd {
 		return nil
 	}
 	selectedItem := panel.GetFocusedItem()
-	if m.FilePreview.GetLocation() == selectedItem.Location && !forcePreviewRender {
+	previewLocation := selectedItem.PreviewLocation()
+	if m.FilePreview.GetLocation() == previewLocation && !forcePreviewRender {
 		return nil
 	}
 
-	m.FilePreview.SetLocation(selectedItem.Location)
+	m.FilePreview.SetLocation(previewLocation)
 	m.FilePreview.SetLoading()
 
 	// HACK!!!. fileModel must not be aware of other dimensions. but...
@@ -109,6 +112,7 @@ func (m *Model) GetFilePreviewCmd(forcePreviewRender bool) tea.Cmd {
 	}
 	width := m.ExpectedPreviewWidth
 	height := m.Height
+	matchIndex := panel.ContentMatchIndex()
 
 	reqCnt := m.ioReqCnt
 	m.ioReqCnt++
@@ -116,8 +120,13 @@ func (m *Model) GetFilePreviewCmd(forcePreviewRender bool) tea.Cmd {
 		"path", selectedItem.Location, "w", width, "h", height)
 
 	return func() tea.Msg {
-		content := m.FilePreview.RenderWithPath(selectedItem.Location, width, height, fullModalWidth)
-		return preview.NewUpdateMsg(selectedItem.Location, content,
+		var content string
+		if selectedItem.Line > 0 {
+			content = m.FilePreview.RenderMatch(selectedItem.Location, selectedItem.Line, matchIndex, width, height)
+		} else {
+			content = m.FilePreview.RenderWithPath(selectedItem.Location, width, height, fullModalWidth)
+		}
+		return preview.NewUpdateMsg(previewLocation, content,
 			width, height, reqCnt)
 	}
 }
@@ -127,8 +136,116 @@ func (m *Model) ToggleDotFile() {
 	m.UpdateFilePanelsIfNeeded(true)
 }
 
+// GetGitDiffCmd renders the git diff of paths, relative to root, in the preview
+// panel in place of the preview of the focused item, until the focus moves.
+// The preview panel is opened if needed.
+func (m *Model) GetGitDiffCmd(root string, paths []string) tea.Cmd {
+	var openCmd tea.Cmd
+	if !m.FilePreview.IsOpen() {
+		openCmd = m.ToggleFilePreviewPanel()
+	}
+	panel := m.GetFocusedFilePanel()
+	if panel.EmptyOrInvalid() {
+		return openCmd
+	}
+	location := panel.GetFocusedItem().PreviewLocation()
+	// Keeps the regular preview from being rendered over the diff
+	m.FilePreview.SetLocation(location)
+	m.FilePreview.SetLoading()
+
+	width := m.ExpectedPreviewWidth
+	height := m.Height
+	reqCnt := m.ioReqCnt
+	m.ioReqCnt++
+	slog.Debug("Submitting git diff render request", "id", reqCnt, "root", root, "paths", paths)
+
+	diffCmd := func() tea.Msg {
+		var content string
+		diff, err := git.Diff(root, paths)
+		if err != nil {
+			slog.Error("Error while getting git diff", "root", root, "error", err)
+			content = m.FilePreview.RenderTextWithDimension(common.FilePreviewError, height, width)
+		} else {
+			content = m.FilePreview.RenderDiff(diff, width, height)
+		}
+		return preview.NewUpdateMsg(location, content, width, height, reqCnt)
+	}
+	// The preview opened right before must not be rendered after the diff
+	return tea.Sequence(openCmd, diffCmd)
+}
+
 func (m *Model) UpdateFilePanelsIfNeeded(force bool) {
 	for i := range m.FilePanels {
 		m.FilePanels[i].UpdateElementsIfNeeded(force, m.DisplayDotFiles)
 	}
 }
+
+// GetColumnValuesCmd loads the missing values of the background columns of all panels
+func (m *Model) GetColumnValuesCmd() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(m.FilePanels))
+	for i := range m.FilePanels {
+		cmds = append(cmds, m.FilePanels[i].GetColumnValuesCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) ApplyColumnValue(msg filepanel.ColumnValueMsg) {
+	for i := range m.FilePanels {
+		m.FilePanels[i].ApplyColumnValue(msg)
+	}
+}
+
+// GetGitStatusCmd loads the git status of the repositories of the panels that
+// need it, once per repository
+func (m *Model) GetGitStatusCmd() tea.Cmd {
+	var cmds []tea.Cmd
+	requested := make(map[string]bool)
+	// Only the branch is needed without the git_status column
+	getStatus := git.GetBranchStatus
+	if filepanel.GitFileStatusEnabled() {
+		getStatus = git.GetStatus
+	}
+	for i := range m.FilePanels {
+		root, ok := m.FilePanels[i].GitStatusRequest()
+		if !ok || requested[root] {
+			continue
+		}
+		requested[root] = true
+		cmds = append(cmds, func() tea.Msg {
+			status, err := getStatus(root)
+			if err != nil {
+				slog.Debug("Cannot get git status", "root", root, "error", err)
+			}
+			return filepanel.GitStatusMsg{Root: root, Status: status}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m *Model) ApplyGitStatus(msg filepanel.GitStatusMsg) {
+	for i := range m.FilePanels {
+		m.FilePanels[i].ApplyGitStatus(msg)
+	}
+}
+
+func (m *Model) InvalidateGitStatus(root string) {
+	for i := range m.FilePanels {
+		m.FilePanels[i].InvalidateGitStatus(root)
+	}
+}
+
+// ApplySearchResults adds the recursive search results of msg to the panel
+// that started the search, and returns the command waiting for the next ones
+func (m *Model) ApplySearchResults(msg filepanel.SearchResultsMsg) tea.Cmd {
+	applied := false
+	for i := range m.FilePanels {
+		if m.FilePanels[i].ApplySearchResults(msg) {
+			applied = true
+		}
+	}
+	// The search was stopped, it drops its remaining results
+	if !applied {
+		return nil
+	}
+	return msg.NextCmd()
+}
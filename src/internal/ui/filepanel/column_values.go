@@ -0,0 +1,138 @@
+package filepanel
+
+import (
+	"io"
+	"log/slog"
+	"maps"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Bytes read to detect the MIME type of files, as much as http.DetectContentType uses
+const mimeSniffLen = 512
+
+// GetColumnValuesCmd returns the command loading the values of the background
+// columns for the visible elements, when they are missing or outdated. Each
+// value comes back as a ColumnValueMsg.
+func (m *Model) GetColumnValuesCmd() tea.Cmd {
+	if m.Empty() {
+		return nil
+	}
+	if len(m.columnValues) > maxColumnValues {
+		maps.DeleteFunc(m.columnValues, func(_ columnValueKey, entry columnValueEntry) bool {
+			return !entry.loading
+		})
+	}
+	var cmds []tea.Cmd
+	end := min(m.renderIndex+m.PanelElementHeight(), m.ElemCount())
+	for _, col := range m.columns {
+		if col.backgroundType == "" {
+			continue
+		}
+		for i := m.renderIndex; i < end; i++ {
+			elem := m.GetElementAtIdx(i)
+			key := columnValueKey{columnType: col.backgroundType, location: elem.Location}
+			entry, ok := m.columnValues[key]
+			if ok && (entry.loading || (entry.modTime.Equal(elem.Info.ModTime()) &&
+				time.Since(entry.loadedAt) < columnValueTTL)) {
+				continue
+			}
+			// The old value stays shown while the new one loads
+			entry.loading = true
+			m.columnValues[key] = entry
+			cmds = append(cmds, func() tea.Msg {
+				return ColumnValueMsg{
+					key:     key,
+					value:   loadColumnValue(key.columnType, elem),
+					modTime: elem.Info.ModTime(),
+				}
+			})
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// ApplyColumnValue stores the value of msg, if this panel asked for it
+func (m *Model) ApplyColumnValue(msg ColumnValueMsg) {
+	if _, ok := m.columnValues[msg.key]; !ok {
+		return
+	}
+	m.columnValues[msg.key] = columnValueEntry{
+		value:    msg.value,
+		modTime:  msg.modTime,
+		loadedAt: time.Now(),
+	}
+}
+
+func loadColumnValue(columnType string, elem Element) string {
+	switch columnType {
+	case common.ColumnOwner:
+		owner, _ := getOwnerAndGroup(elem.Info)
+		return owner
+	case common.ColumnGroup:
+		_, group := getOwnerAndGroup(elem.Info)
+		return group
+	case common.ColumnSymlinkTarget:
+		if elem.Info.Mode()&os.ModeSymlink == 0 {
+			return ""
+		}
+		target, err := os.Readlink(elem.Location)
+		if err != nil {
+			slog.Debug("Cannot read symlink target", "location", elem.Location, "error", err)
+			return ""
+		}
+		return target
+	case common.ColumnDirSize:
+		if elem.Info.IsDir() {
+			return common.FormatFileSize(utils.DirSize(elem.Location))
+		}
+		return common.FormatFileSize(elem.Info.Size())
+	case common.ColumnMimeType:
+		return getMimeType(elem)
+	default:
+		slog.Error("Unexpected background column type", "type", columnType)
+		return ""
+	}
+}
+
+// MIME type from the extension, or from the content when the extension is unknown
+func getMimeType(elem Element) string {
+	switch {
+	case elem.Info.Mode()&os.ModeSymlink != 0:
+		return "inode/symlink"
+	case elem.Info.IsDir():
+		return "inode/directory"
+	case !elem.Info.Mode().IsRegular():
+		return ""
+	}
+	mimeType := mime.TypeByExtension(filepath.Ext(elem.Name))
+	if mimeType == "" {
+		mimeType = sniffMimeType(elem.Location)
+	}
+	// Drop parameters like "; charset=utf-8"
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return mimeType
+}
+
+func sniffMimeType(location string) string {
+	file, err := os.Open(location)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	buf := make([]byte, mimeSniffLen)
+	n, err := io.ReadFull(file, buf)
+	if err != nil && n == 0 {
+		return ""
+	}
+	return http.DetectContentType(buf[:n])
+}
@@ -2,6 +2,7 @@ package filepanel
 
 import (
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -25,16 +26,30 @@ func (m *Model) renderFileName(indexElement int, columnWidth int) string {
 	// Calculate the actual prefix width for proper alignment
 	prefixWidth := ansi.StringWidth(cursor+" ") + ansi.StringWidth(selectBox)
 
+	treePrefix := ""
+	if m.showingTree() {
+		// Guides of deep elements are cut to leave room for their name
+		treePrefix = common.TruncateTextBeginning(m.treePrefix(elem), max(0, (columnWidth-prefixWidth)/2), "")
+		prefixWidth += ansi.StringWidth(treePrefix)
+	}
+
 	isLink := elem.Info.Mode()&os.ModeSymlink != 0
+	label := elem.Name
+	// Content search matches show the matching line after the file
+	if elem.Line > 0 {
+		label = elem.Name + ":" + strconv.Itoa(elem.Line) + ": " + elem.LineText
+	}
 	renderedName := common.FilePanelItemRenderWithIcon(
 		elem.Name,
+		label,
 		columnWidth-prefixWidth,
 		elem.Directory,
 		isLink,
 		isSelected,
 		common.FilePanelBGColor,
 	)
-	return common.FilePanelCursorStyle.Render(cursor+" ") + selectBox + renderedName
+	return common.FilePanelCursorStyle.Render(cursor+" ") + selectBox +
+		common.FilePanelStyle.Render(treePrefix) + renderedName
 }
 
 // The renderer of delimiter spaces. It has a strict fixed size that depends only on the delimiter string.
@@ -49,46 +64,85 @@ func (m *Model) renderDelimiter(indexElement int, columnWidth int) string {
 	)
 }
 
-func (m *Model) renderFileSize(indexElement int, columnWidth int) string {
-	elem := m.GetElementAtIdx(indexElement)
-	isSelected := m.CheckSelected(elem.Location)
-	sizeValue := common.FormatFileSize(elem.Info.Size())
+// Renderer of the columns showing one value per element, like the size or the owner
+func (m *Model) valueRenderer(value func(elem Element) string, align lipgloss.Position) columnRenderer {
+	return func(indexElement int, columnWidth int) string {
+		elem := m.GetElementAtIdx(indexElement)
+		return common.FilePanelItemRender(
+			value(elem),
+			columnWidth,
+			m.CheckSelected(elem.Location),
+			common.FilePanelBGColor,
+			align,
+		)
+	}
+}
+
+// Renderer of the columns whose values are loaded in background, see GetColumnValuesCmd
+func (m *Model) backgroundValueRenderer(columnType string, align lipgloss.Position) columnRenderer {
+	return m.valueRenderer(func(elem Element) string {
+		entry, ok := m.columnValues[columnValueKey{columnType: columnType, location: elem.Location}]
+		if !ok || entry.loadedAt.IsZero() {
+			return columnLoadingText
+		}
+		return entry.value
+	}, align)
+}
+
+func fileSizeValue(elem Element) string {
 	if elem.Info.IsDir() {
-		sizeValue = ""
+		return ""
 	}
-	return common.FilePanelItemRender(
-		sizeValue,
-		columnWidth,
-		isSelected,
-		common.FilePanelBGColor,
-		lipgloss.Right,
-	)
+	return common.FormatFileSize(elem.Info.Size())
 }
 
-// TODO: make time template configurable
-func (m *Model) renderModifyTime(indexElement int, columnWidth int) string {
-	elem := m.GetElementAtIdx(indexElement)
-	isSelected := m.CheckSelected(elem.Location)
-	modifyTime := elem.Info.ModTime().Format("2006-01-02 15:04")
-	return common.FilePanelItemRender(
-		modifyTime,
-		columnWidth,
-		isSelected,
-		common.FilePanelBGColor,
-		lipgloss.Right,
-	)
+func modifyTimeValue(elem Element) string {
+	return common.FormatTime(elem.Info.ModTime())
 }
 
-func (m *Model) renderPermissions(indexElement int, columnWidth int) string {
-	elem := m.GetElementAtIdx(indexElement)
-	isSelected := m.CheckSelected(elem.Location)
-	return common.FilePanelItemRender(
-		elem.Info.Mode().Perm().String(),
-		columnWidth,
-		isSelected,
-		common.FilePanelBGColor,
-		lipgloss.Right,
-	)
+func permissionsValue(elem Element) string {
+	return elem.Info.Mode().Perm().String()
+}
+
+func createTimeValue(elem Element) string {
+	if createTime, ok := getCreateTime(elem.Location, elem.Info); ok {
+		return common.FormatTime(createTime)
+	}
+	return ""
+}
+
+func accessTimeValue(elem Element) string {
+	if accessTime, ok := getAccessTime(elem.Info); ok {
+		return common.FormatTime(accessTime)
+	}
+	return ""
+}
+
+// Extension without the dot. Directories and dotfiles like .bashrc have none
+func extensionValue(elem Element) string {
+	if elem.Directory {
+		return ""
+	}
+	name := strings.TrimPrefix(elem.Name, ".")
+	dot := strings.LastIndexByte(name, '.')
+	if dot == -1 {
+		return ""
+	}
+	return name[dot+1:]
+}
+
+func inodeValue(elem Element) string {
+	if inode, _, ok := getInodeAndLinkCount(elem.Info); ok {
+		return strconv.FormatUint(inode, 10)
+	}
+	return ""
+}
+
+func linkCountValue(elem Element) string {
+	if _, links, ok := getInodeAndLinkCount(elem.Info); ok {
+		return strconv.FormatUint(links, 10)
+	}
+	return ""
 }
 
 func (cd *columnDefinition) Render(index int) string {
@@ -105,30 +159,111 @@ func (cd *columnDefinition) RenderHeader() string {
 	)
 }
 
+// Columns shown when file_panel_columns is empty, file_panel_extra_columns of them
+func defaultColumnConfigs(columnThreshold int) []common.FilePanelColumn {
+	columns := []common.FilePanelColumn{
+		{Type: common.ColumnSize},
+		{Type: common.ColumnModifyTime},
+		{Type: common.ColumnPermission},
+	}
+	return columns[:max(min(columnThreshold, len(columns)), 0)]
+}
+
+// Header, width and value alignment of the columns of each type
+func columnTypeDefaults(columnType string) (string, int, lipgloss.Position) {
+	switch columnType {
+	case common.ColumnSize:
+		return "Size", FileSizeColumnWidth, lipgloss.Right
+	case common.ColumnModifyTime:
+		return "Modify time", ModifyTimeSizeColumnWidth, lipgloss.Right
+	case common.ColumnPermission:
+		return "Permission", PermissionsColumnWidth, lipgloss.Right
+	case common.ColumnOwner:
+		return "Owner", OwnerColumnWidth, lipgloss.Left
+	case common.ColumnGroup:
+		return "Group", OwnerColumnWidth, lipgloss.Left
+	case common.ColumnCreateTime:
+		return "Create time", ModifyTimeSizeColumnWidth, lipgloss.Right
+	case common.ColumnAccessTime:
+		return "Access time", ModifyTimeSizeColumnWidth, lipgloss.Right
+	case common.ColumnExtension:
+		return "Ext", ExtensionColumnWidth, lipgloss.Left
+	case common.ColumnInode:
+		return "Inode", InodeColumnWidth, lipgloss.Right
+	case common.ColumnLinkCount:
+		return "Links", LinkCountColumnWidth, lipgloss.Right
+	case common.ColumnSymlinkTarget:
+		return "Link target", SymlinkTargetColumnWidth, lipgloss.Left
+	case common.ColumnDirSize:
+		return "Total size", FileSizeColumnWidth, lipgloss.Right
+	case common.ColumnGitStatus:
+		return "Git", GitStatusColumnWidth, lipgloss.Center
+	default:
+		return "MIME type", MimeTypeColumnWidth, lipgloss.Left
+	}
+}
+
+// Column of the given config. Width and value alignment default to the ones of
+// its type, and the header is centered unless an alignment is set
+func (m *Model) makeColumn(config common.FilePanelColumn) columnDefinition {
+	name, width, align := columnTypeDefaults(config.Type)
+	col := columnDefinition{
+		Name:        name,
+		Size:        width,
+		HeaderAlign: lipgloss.Center,
+	}
+	if config.Width > 0 {
+		col.Size = config.Width
+	}
+	if config.Align != "" {
+		align = alignPosition(config.Align)
+		col.HeaderAlign = align
+	}
+
+	switch config.Type {
+	case common.ColumnSize:
+		col.columnRender = m.valueRenderer(fileSizeValue, align)
+	case common.ColumnModifyTime:
+		col.columnRender = m.valueRenderer(modifyTimeValue, align)
+	case common.ColumnPermission:
+		col.columnRender = m.valueRenderer(permissionsValue, align)
+	case common.ColumnCreateTime:
+		col.columnRender = m.valueRenderer(createTimeValue, align)
+	case common.ColumnAccessTime:
+		col.columnRender = m.valueRenderer(accessTimeValue, align)
+	case common.ColumnExtension:
+		col.columnRender = m.valueRenderer(extensionValue, align)
+	case common.ColumnInode:
+		col.columnRender = m.valueRenderer(inodeValue, align)
+	case common.ColumnLinkCount:
+		col.columnRender = m.valueRenderer(linkCountValue, align)
+	case common.ColumnGitStatus:
+		col.columnRender = m.valueRenderer(m.gitStatusValue, align)
+	default:
+		// Owner, group, symlink target, directory size and MIME type need
+		// lookups or file reads that are too slow for rendering
+		col.backgroundType = config.Type
+		col.columnRender = m.backgroundValueRenderer(config.Type, align)
+	}
+	return col
+}
+
+func alignPosition(align string) lipgloss.Position {
+	switch align {
+	case common.ColumnAlignLeft:
+		return lipgloss.Left
+	case common.ColumnAlignCenter:
+		return lipgloss.Center
+	default:
+		return lipgloss.Right
+	}
+}
+
 func (m *Model) makeColumns(columnThreshold int, fileNameRatio int) []columnDefinition {
-	// TODO: make column set configurable
-	// Note: May use a predefined slice for efficiency. This content is static
-	extraColumns := []columnDefinition{
-		{
-			Name:         "Size",
-			columnRender: m.renderFileSize,
-			Size:         FileSizeColumnWidth,
-			HeaderAlign:  lipgloss.Center,
-		},
-		{
-			Name:         "Modify time",
-			columnRender: m.renderModifyTime,
-			Size:         ModifyTimeSizeColumnWidth,
-			HeaderAlign:  lipgloss.Center,
-		},
-		{
-			Name:         "Permission",
-			columnRender: m.renderPermissions,
-			Size:         PermissionsColumnWidth,
-			HeaderAlign:  lipgloss.Center,
-		},
+	columnConfigs := common.Config.FilePanelColumns
+	if len(columnConfigs) == 0 {
+		columnConfigs = defaultColumnConfigs(columnThreshold)
 	}
-	maxColumns := min(columnThreshold, len(extraColumns))
 	columns := []columnDefinition{
 		{
 			Name:         strings.Repeat(" ", ansi.StringWidth(emptyCursor+" ")) + "Name",
@@ -143,7 +278,8 @@ func (m *Model) makeColumns(columnThreshold int, fileNameRatio int) []columnDefi
 	// Hence, we need this check. Our constraints on Width and ratio guarantee it to be > 0 though
 	minWidthForNameColumn = min(minWidthForNameColumn, m.GetContentWidth())
 
-	for _, col := range extraColumns[0:maxColumns] {
+	for _, config := range columnConfigs {
+		col := m.makeColumn(config)
 		widthExtraColumn := ansi.StringWidth(ColumnDelimiter) + col.Size
 
 		// This condition checks that can we borrow some width from first column for additional columns?
@@ -0,0 +1,166 @@
+package filepanel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func setFilePanelColumns(t *testing.T, columns []common.FilePanelColumn) {
+	t.Helper()
+	prev := common.Config.FilePanelColumns
+	common.Config.FilePanelColumns = columns
+	t.Cleanup(func() { common.Config.FilePanelColumns = prev })
+}
+
+// New() needs the loaded hotkeys for its search bar
+func columnsTestModel(location string, width int) Model {
+	return Model{
+		Location:         location,
+		width:            width,
+		height:           MinHeight,
+		DirectoryRecords: make(map[string]directoryRecord),
+		selected:         make(map[string]int),
+		columnValues:     make(map[columnValueKey]columnValueEntry),
+		git:              &gitState{},
+	}
+}
+
+func columnNames(columns []columnDefinition) []string {
+	names := []string{}
+	// Skip the name column and the delimiters
+	for i := 2; i < len(columns); i += 2 {
+		names = append(names, columns[i].Name)
+	}
+	return names
+}
+
+func TestMakeColumns(t *testing.T) {
+	t.Run("Default columns follow the threshold", func(t *testing.T) {
+		setFilePanelColumns(t, nil)
+		m := columnsTestModel(t.TempDir(), 200)
+		assert.Empty(t, columnNames(m.makeColumns(0, 0)))
+		assert.Equal(t, []string{"Size", "Modify time"}, columnNames(m.makeColumns(2, 0)))
+		assert.Equal(t, []string{"Size", "Modify time", "Permission"}, columnNames(m.makeColumns(10, 0)))
+	})
+
+	t.Run("Configured columns", func(t *testing.T) {
+		setFilePanelColumns(t, []common.FilePanelColumn{
+			{Type: common.ColumnExtension},
+			{Type: common.ColumnOwner, Width: 15, Align: common.ColumnAlignRight},
+			{Type: common.ColumnInode},
+		})
+		m := columnsTestModel(t.TempDir(), 200)
+		columns := m.makeColumns(0, 0)
+		assert.Equal(t, []string{"Ext", "Owner", "Inode"}, columnNames(columns))
+
+		ext := columns[2]
+		assert.Equal(t, ExtensionColumnWidth, ext.Size)
+		assert.Equal(t, lipgloss.Center, ext.HeaderAlign)
+		assert.Empty(t, ext.backgroundType)
+
+		owner := columns[4]
+		assert.Equal(t, 15, owner.Size)
+		assert.Equal(t, lipgloss.Right, owner.HeaderAlign)
+		assert.Equal(t, common.ColumnOwner, owner.backgroundType)
+
+		total := 0
+		for _, col := range columns {
+			total += col.Size
+		}
+		assert.Equal(t, m.GetContentWidth(), total)
+	})
+
+	t.Run("Columns that do not fit are dropped", func(t *testing.T) {
+		setFilePanelColumns(t, []common.FilePanelColumn{
+			{Type: common.ColumnSize},
+			{Type: common.ColumnMimeType},
+		})
+		m := columnsTestModel(t.TempDir(), 40)
+		assert.Equal(t, []string{"Size"}, columnNames(m.makeColumns(0, 50)))
+	})
+}
+
+func TestExtensionValue(t *testing.T) {
+	testdata := []struct {
+		name      string
+		elem      Element
+		extension string
+	}{
+		{"Simple file", Element{Name: "main.go"}, "go"},
+		{"Double extension", Element{Name: "archive.tar.gz"}, "gz"},
+		{"No extension", Element{Name: "Makefile"}, ""},
+		{"Dotfile", Element{Name: ".bashrc"}, ""},
+		{"Dotfile with extension", Element{Name: ".config.toml"}, "toml"},
+		{"Directory", Element{Name: "dir.d", Directory: true}, ""},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.extension, extensionValue(tt.elem))
+		})
+	}
+}
+
+func TestLoadColumnValue(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir := filepath.Join(curTestDir, "dir")
+	file := filepath.Join(curTestDir, "file.txt")
+	noExt := filepath.Join(curTestDir, "noext")
+	utils.SetupDirectories(t, dir)
+	utils.SetupFilesWithData(t, []byte("0123456789"), file, filepath.Join(dir, "a"), filepath.Join(dir, "b"))
+	utils.SetupFilesWithData(t, []byte("%PDF-1.7"), noExt)
+	link := filepath.Join(curTestDir, "link")
+	require.NoError(t, os.Symlink(file, link))
+
+	elem := func(location string) Element {
+		info, err := os.Lstat(location)
+		require.NoError(t, err)
+		return Element{Name: filepath.Base(location), Location: location, Directory: info.IsDir(), Info: info}
+	}
+
+	assert.Equal(t, file, loadColumnValue(common.ColumnSymlinkTarget, elem(link)))
+	assert.Empty(t, loadColumnValue(common.ColumnSymlinkTarget, elem(file)))
+	assert.Equal(t, common.FormatFileSize(20), loadColumnValue(common.ColumnDirSize, elem(dir)))
+	assert.Equal(t, common.FormatFileSize(10), loadColumnValue(common.ColumnDirSize, elem(file)))
+	assert.Equal(t, "text/plain", loadColumnValue(common.ColumnMimeType, elem(file)))
+	assert.Equal(t, "application/pdf", loadColumnValue(common.ColumnMimeType, elem(noExt)))
+	assert.Equal(t, "inode/directory", loadColumnValue(common.ColumnMimeType, elem(dir)))
+	assert.Equal(t, "inode/symlink", loadColumnValue(common.ColumnMimeType, elem(link)))
+}
+
+func TestColumnValues(t *testing.T) {
+	curTestDir := t.TempDir()
+	dir := filepath.Join(curTestDir, "dir")
+	utils.SetupDirectories(t, dir)
+	utils.SetupFilesWithData(t, []byte("0123456789"), filepath.Join(dir, "a"), filepath.Join(dir, "b"))
+	setFilePanelColumns(t, []common.FilePanelColumn{{Type: common.ColumnDirSize}})
+
+	m := columnsTestModel(curTestDir, 0)
+	m.SetWidth(80)
+	m.SetHeight(20)
+	m.UpdateElementsIfNeeded(true, true)
+	require.Equal(t, 1, m.ElemCount())
+	assert.Contains(t, m.columns[2].Render(0), columnLoadingText)
+
+	cmd := m.GetColumnValuesCmd()
+	require.NotNil(t, cmd)
+	assert.Nil(t, m.GetColumnValuesCmd(), "Values being loaded are not loaded again")
+
+	msg, ok := cmd().(ColumnValueMsg)
+	require.True(t, ok)
+	m.ApplyColumnValue(msg)
+	assert.Contains(t, m.columns[2].Render(0), common.FormatFileSize(20))
+	assert.Nil(t, m.GetColumnValuesCmd(), "Loaded values are kept")
+
+	// Values this panel did not ask for are ignored
+	other := columnsTestModel(curTestDir, 80)
+	other.ApplyColumnValue(msg)
+	assert.Empty(t, other.columnValues)
+}
@@ -14,6 +14,13 @@ const (
 	FileSizeColumnWidth       = 15
 	ModifyTimeSizeColumnWidth = 18
 	PermissionsColumnWidth    = 12
+	OwnerColumnWidth          = 10
+	ExtensionColumnWidth      = 6
+	InodeColumnWidth          = 10
+	LinkCountColumnWidth      = 5
+	SymlinkTargetColumnWidth  = 24
+	MimeTypeColumnWidth       = 20
+	GitStatusColumnWidth      = 3
 	ColumnHeaderHeight        = 1
 
 	// Delimiter between columns in the file panel.
@@ -24,4 +31,23 @@ const (
 	nonFocussedPanelReRenderTime = 3 * time.Second
 
 	emptyCursor = " "
+
+	// Room kept at the end of the search bar for the search mode, like "pin regex"
+	searchIndicatorWidth = 10
+
+	// Between the path and the git branch in the top bar
+	gitBranchInfoGap = "  "
+	// The git branch is hidden when it leaves less than this for the path
+	minTopBarPathWidth = 10
+
+	// Shown until the value of a background column is loaded
+	columnLoadingText = "..."
+	// Values of background columns are loaded again after this
+	columnValueTTL = 30 * time.Second
+	// Loaded values are dropped past this count, to not keep every visited directory
+	maxColumnValues = 10000
+	// The recursive search stops at this count of results
+	maxSearchResults = 10000
+	// Git status is loaded again after panel refreshes, but not more often than this
+	gitStatusRefreshInterval = 2 * time.Second
 )
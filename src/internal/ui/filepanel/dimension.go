@@ -14,7 +14,7 @@ func (m *Model) SetWidth(width int) {
 		width = MinWidth
 	}
 	m.width = width
-	m.SearchBar.Width = m.width - common.InnerPadding
+	m.SearchBar.Width = m.SearchBarWidth()
 	m.columns = m.makeColumns(common.Config.FilePanelExtraColumns, common.Config.FilePanelNamePercent)
 }
 
@@ -44,7 +44,7 @@ func (m *Model) GetContentWidth() int {
 }
 
 func (m *Model) NeedRenderHeaders() bool {
-	return common.Config.FilePanelExtraColumns > 0 && len(m.columns) > 1
+	return len(m.columns) > 1
 }
 
 // PanelElementHeight calculates the number of visible elements in content area
@@ -7,7 +7,8 @@ import (
 	"strings"
 	"time"
 
-	"github.com/yorukot/superfile/src/pkg/utils"
+	"github.com/yorukot/superfile/src/pkg/archive"
+	"github.com/yorukot/superfile/src/pkg/search"
 )
 
 // TODO : Take common.Config.CaseSensitiveSort as a function parameter
@@ -15,11 +16,20 @@ import (
 // our unit_test TestReturnDirElement
 // getDirectoryElements returns the directory elements for the panel's current location
 func (m *Model) getDirectoryElements(displayDotFile bool) []Element {
-	dirEntries, err := os.ReadDir(m.Location)
+	elements, err := m.readDirectoryElements(m.Location, displayDotFile)
 	if err != nil {
 		slog.Error("Error while returning folder elements", "error", err)
 		return nil
 	}
+	return elements
+}
+
+// readDirectoryElements returns the sorted elements of the directory at location
+func (m *Model) readDirectoryElements(location string, displayDotFile bool) ([]Element, error) {
+	dirEntries, err := archive.ReadDir(location)
+	if err != nil {
+		return nil, err
+	}
 
 	dirEntries = slices.DeleteFunc(dirEntries, func(e os.DirEntry) bool {
 		// Entries not needed to be considered
@@ -29,49 +39,75 @@ func (m *Model) getDirectoryElements(displayDotFile bool) []Element {
 
 	// No files/directories to process
 	if len(dirEntries) == 0 {
-		return nil
+		return nil, nil
 	}
-	return sortFileElement(m.SortKind, m.SortReversed, dirEntries, m.Location)
+	return sortFileElement(m.SortKind, m.SortReversed, dirEntries, location), nil
 }
 
-// getDirectoryElementsBySearch returns filtered directory elements based on search string
+// getDirectoryElementsBySearch returns filtered directory elements based on search string.
+// Attribute predicates of the search string are matched against the loaded elements
 func (m *Model) getDirectoryElementsBySearch(displayDotFile bool) []Element {
-	searchString := m.SearchBar.Value()
-	items, err := os.ReadDir(m.Location)
+	query, err := search.ParseQuery(m.SearchBar.Value())
+	m.searchInvalid = err != nil
+	if err != nil {
+		slog.Debug("Invalid search query", "error", err)
+		return nil
+	}
+	elements, err := m.readDirectoryElements(m.Location, displayDotFile)
 	if err != nil {
 		slog.Error("Error while return folder element function", "error", err)
 		return nil
 	}
+	m.searchTotal = len(elements)
 
-	if len(items) == 0 {
+	elements, err = m.filterElements(elements, query)
+	m.searchInvalid = err != nil
+	if err != nil {
+		slog.Debug("Invalid search pattern", "mode", m.SearchMode, "error", err)
 		return nil
 	}
+	return elements
+}
 
-	folderElementMap := map[string]os.DirEntry{}
-	fileAndDirectories := []string{}
-
-	for _, item := range items {
-		fileInfo, err := item.Info()
+// filterElements returns the elements matching query, in their order
+func (m *Model) filterElements(elements []Element, query search.Query) ([]Element, error) {
+	if query.Pattern != "" {
+		names := make([]string, 0, len(elements))
+		for _, elem := range elements {
+			names = append(names, elem.Name)
+		}
+		matched, err := search.Filter(m.SearchMode, query.Pattern, names)
 		if err != nil {
-			continue
+			return nil, err
 		}
-		if !displayDotFile && strings.HasPrefix(fileInfo.Name(), ".") {
-			continue
+		// Fuzzy matches are ordered by score, the chosen sort option is kept instead
+		matchedNames := make(map[string]bool, len(matched))
+		for _, name := range matched {
+			matchedNames[name] = true
 		}
-
-		fileAndDirectories = append(fileAndDirectories, item.Name())
-		folderElementMap[item.Name()] = item
+		elements = slices.DeleteFunc(elements, func(elem Element) bool {
+			return !matchedNames[elem.Name]
+		})
 	}
-	// https://github.com/reinhrst/fzf-lib/blob/main/core.go#L43
-	// fzf returns matches ordered by score; we subsequently sort by the chosen sort option.
-	fzfResults := utils.FzfSearch(searchString, fileAndDirectories)
-	dirElements := make([]os.DirEntry, 0, len(fzfResults))
-	for _, item := range fzfResults {
-		resultItem := folderElementMap[item.Key]
-		dirElements = append(dirElements, resultItem)
+	if query.HasPredicates() {
+		now := time.Now()
+		elements = slices.DeleteFunc(elements, func(elem Element) bool {
+			return !query.MatchAttributes(elementAttributes(elem), now)
+		})
 	}
+	return elements, nil
+}
 
-	return sortFileElement(m.SortKind, m.SortReversed, dirElements, m.Location)
+func elementAttributes(elem Element) search.Attributes {
+	return search.Attributes{
+		Name:  elem.Name,
+		IsDir: elem.Directory,
+		Info:  elem.Info,
+		Owner: func() string {
+			owner, _ := getOwnerAndGroup(elem.Info)
+			return owner
+		},
+	}
 }
 
 // Helper to decide whether to skip updating a panel this tick.
@@ -108,8 +144,18 @@ func (m *Model) UpdateElementsIfNeeded(force bool, displayDotFile bool) {
 
 // Retrieves elements for a panel based on search bar value and sort options.
 func (m *Model) getElements(displayDotFile bool) []Element {
+	if m.results != nil {
+		return m.getSearchResultElements()
+	}
+	var elements []Element
 	if m.SearchBar.Value() != "" {
-		return m.getDirectoryElementsBySearch(displayDotFile)
+		elements = m.getDirectoryElementsBySearch(displayDotFile)
+	} else {
+		m.searchInvalid = false
+		elements = m.getDirectoryElements(displayDotFile)
+	}
+	if m.TreeView {
+		return m.getTreeElements(elements, displayDotFile)
 	}
-	return m.getDirectoryElements(displayDotFile)
+	return elements
 }
@@ -0,0 +1,111 @@
+package filepanel
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/git"
+)
+
+// GitFileStatusEnabled reports whether file_panel_columns has a git_status
+// column, which needs the state of every file
+func GitFileStatusEnabled() bool {
+	return slices.ContainsFunc(common.Config.FilePanelColumns, func(col common.FilePanelColumn) bool {
+		return col.Type == common.ColumnGitStatus
+	})
+}
+
+// Git status is loaded for the git_status column and for the branch shown in
+// the top bar
+func gitStatusEnabled() bool {
+	return common.Config.ShowGitBranch || GitFileStatusEnabled()
+}
+
+// GitStatusRequest returns the root of the repository whose status must be
+// loaded for the panel, when the status was never loaded, or when the panel
+// was refreshed since the last request. The request is then marked as loading.
+func (m *Model) GitStatusRequest() (string, bool) {
+	if !gitStatusEnabled() {
+		return "", false
+	}
+	if m.git.location != m.Location {
+		m.git.location = m.Location
+		root, _ := git.FindRoot(m.Location)
+		if root != m.git.root {
+			*m.git = gitState{location: m.Location, root: root}
+		}
+	}
+	if m.git.root == "" || m.git.loading {
+		return "", false
+	}
+	if !m.git.requestedAt.IsZero() && (!m.LastTimeGetElement.After(m.git.requestedAt) ||
+		time.Since(m.git.requestedAt) < gitStatusRefreshInterval) {
+		return "", false
+	}
+	m.git.requestedAt = time.Now()
+	m.git.loading = true
+	return m.git.root, true
+}
+
+// ApplyGitStatus stores the status of msg, if it is the one of the panel repository
+func (m *Model) ApplyGitStatus(msg GitStatusMsg) {
+	if msg.Root != m.git.root {
+		return
+	}
+	m.git.loading = false
+	// A failed status keeps the previous one
+	if msg.Status != nil {
+		m.git.status = msg.Status
+	}
+}
+
+// InvalidateGitStatus makes the status of the repository at root be loaded
+// again on the next request, after the repository was changed by superfile
+func (m *Model) InvalidateGitStatus(root string) {
+	if m.git.root == root {
+		m.git.requestedAt = time.Time{}
+	}
+}
+
+func (m *Model) gitStatusValue(elem Element) string {
+	if m.git.status == nil {
+		return ""
+	}
+	relPath, err := filepath.Rel(m.git.root, elem.Location)
+	if err != nil {
+		return ""
+	}
+	return m.git.status.State(filepath.ToSlash(relPath), elem.Directory).Marker()
+}
+
+// GitBranch returns the checked out branch of the panel repository, empty when
+// its status is not loaded
+func (m *Model) GitBranch() string {
+	if m.git.status == nil {
+		return ""
+	}
+	return m.git.status.Branch
+}
+
+// Branch of the repository with its commits ahead and behind its upstream,
+// empty outside of repositories or when show_git_branch is disabled
+func (m *Model) gitBranchInfo() string {
+	status := m.git.status
+	if status == nil || !common.Config.ShowGitBranch {
+		return ""
+	}
+	var builder strings.Builder
+	builder.WriteString(icon.GitBranch + icon.Space + status.Branch)
+	if status.Ahead > 0 {
+		fmt.Fprintf(&builder, " %s%d", icon.GitAhead, status.Ahead)
+	}
+	if status.Behind > 0 {
+		fmt.Fprintf(&builder, " %s%d", icon.GitBehind, status.Behind)
+	}
+	return builder.String()
+}
@@ -0,0 +1,86 @@
+package filepanel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/git"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestGitStatus(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "dir")
+	utils.SetupDirectories(t, filepath.Join(root, ".git"), dir)
+	utils.SetupFiles(t, filepath.Join(dir, "changed.go"), filepath.Join(dir, "clean.go"))
+	status := git.ParseStatus(root, "# branch.head main\x00# branch.ab +1 -0\x00"+
+		"1 .M N... 100644 100644 100644 abc abc dir/changed.go\x00")
+
+	m := columnsTestModel(dir, 80)
+	m.LastTimeGetElement = time.Now()
+	prevShowGitBranch := common.Config.ShowGitBranch
+	t.Cleanup(func() { common.Config.ShowGitBranch = prevShowGitBranch })
+	common.Config.ShowGitBranch = false
+	_, ok := m.GitStatusRequest()
+	assert.False(t, ok, "Status is not loaded without a git_status column or the branch")
+
+	setFilePanelColumns(t, []common.FilePanelColumn{{Type: common.ColumnGitStatus}})
+	assert.True(t, GitFileStatusEnabled())
+	requestRoot, ok := m.GitStatusRequest()
+	require.True(t, ok)
+	assert.Equal(t, root, requestRoot)
+	_, ok = m.GitStatusRequest()
+	assert.False(t, ok, "Status being loaded is not requested again")
+
+	// Copies of the panel share the status
+	panelCopy := m
+	m.ApplyGitStatus(GitStatusMsg{Root: filepath.Join(root, "other"), Status: status})
+	assert.Empty(t, panelCopy.gitBranchInfo())
+	m.ApplyGitStatus(GitStatusMsg{Root: root, Status: status})
+	assert.Empty(t, panelCopy.gitBranchInfo(), "Branch is only shown with show_git_branch")
+	common.Config.ShowGitBranch = true
+	assert.Equal(t, icon.GitBranch+icon.Space+"main "+icon.GitAhead+"1", panelCopy.gitBranchInfo())
+
+	changed, err := os.Stat(filepath.Join(dir, "changed.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "M", panelCopy.gitStatusValue(Element{Location: filepath.Join(dir, "changed.go"), Info: changed}))
+	assert.Empty(t, panelCopy.gitStatusValue(Element{Location: filepath.Join(dir, "clean.go"), Info: changed}))
+	assert.Equal(t, "M", panelCopy.gitStatusValue(Element{Location: dir, Directory: true}))
+
+	_, ok = m.GitStatusRequest()
+	assert.False(t, ok, "Status is loaded again only after a panel refresh")
+	m.git.requestedAt = time.Now().Add(-gitStatusRefreshInterval)
+	m.LastTimeGetElement = time.Now()
+	_, ok = m.GitStatusRequest()
+	assert.True(t, ok)
+
+	// Changes made by superfile reload the status right away
+	m.ApplyGitStatus(GitStatusMsg{Root: root, Status: status})
+	m.InvalidateGitStatus(filepath.Join(root, "other"))
+	_, ok = m.GitStatusRequest()
+	assert.False(t, ok)
+	m.InvalidateGitStatus(root)
+	_, ok = m.GitStatusRequest()
+	assert.True(t, ok)
+
+	// The branch is loaded without the git_status column
+	setFilePanelColumns(t, nil)
+	assert.False(t, GitFileStatusEnabled())
+	m.ApplyGitStatus(GitStatusMsg{Root: root, Status: status})
+	m.InvalidateGitStatus(root)
+	_, ok = m.GitStatusRequest()
+	assert.True(t, ok)
+
+	// Outside of the repository
+	m.Location = filepath.Dir(root)
+	_, ok = m.GitStatusRequest()
+	assert.False(t, ok)
+	assert.Empty(t, m.gitBranchInfo())
+}
@@ -2,6 +2,7 @@ package filepanel
 
 import (
 	"fmt"
+	"slices"
 )
 
 func (m *Model) scrollToCursor(cursor int) {
@@ -66,7 +67,10 @@ func (m *Model) ItemSelectDown() {
 
 // Applies targetFile cursor positioning, if configured for the panel.
 func (m *Model) applyTargetFileCursor() {
-	idx := m.FindElementIndexByName(m.TargetFile)
+	// Elements of the tree view can have the same name in different directories
+	idx := slices.IndexFunc(m.element, func(elem Element) bool {
+		return elem.Depth == 0 && elem.Name == m.TargetFile
+	})
 	if idx != -1 {
 		m.scrollToCursor(idx)
 	}
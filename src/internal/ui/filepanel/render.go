@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/charmbracelet/x/ansi"
+
 	"github.com/yorukot/superfile/src/config/icon"
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui"
@@ -35,13 +37,27 @@ func (m *Model) Render(focused bool) string {
 
 func (m *Model) renderTopBar(r *rendering.Renderer) {
 	// TODO - Add ansitruncate left in renderer and remove truncation here
-	truncatedPath := common.TruncateTextBeginning(m.Location, m.GetContentWidth()-common.InnerPadding, "...")
-	r.AddLines(common.FilePanelTopDirectoryIcon + common.FilePanelTopPathStyle.Render(truncatedPath))
+	pathWidth := m.GetContentWidth() - common.InnerPadding
+	branchInfo := m.gitBranchInfo()
+	if branchInfo != "" {
+		branchInfo = gitBranchInfoGap + branchInfo
+		// The path is more important than the branch
+		if pathWidth-ansi.StringWidth(branchInfo) < minTopBarPathWidth {
+			branchInfo = ""
+		}
+		pathWidth -= ansi.StringWidth(branchInfo)
+	}
+	truncatedPath := common.TruncateTextBeginning(m.Location, pathWidth, "...")
+	r.AddLines(common.FilePanelTopDirectoryIcon + common.FilePanelTopPathStyle.Render(truncatedPath) +
+		common.FilePanelTopDirectoryIconStyle.Render(branchInfo))
 	r.AddSection()
 }
 
 func (m *Model) renderSearchBar(r *rendering.Renderer) {
-	r.AddLines(" " + m.SearchBar.View())
+	line := " " + m.SearchBar.View()
+	indicator := m.searchIndicator()
+	padding := max(0, m.GetContentWidth()-ansi.StringWidth(line)-ansi.StringWidth(indicator))
+	r.AddLines(line + strings.Repeat(" ", padding) + indicator)
 }
 
 // TODO : Unit test this
@@ -109,6 +125,9 @@ func (m *Model) getSortInfo() (string, string) {
 func (m *Model) getPanelModeInfo(selectedCount uint) (string, string) {
 	switch m.PanelMode {
 	case BrowserMode:
+		if m.TreeView {
+			return "Tree", icon.Tree
+		}
 		return "Browser", icon.Browser
 	case SelectMode:
 		return "Select" + icon.Space + fmt.Sprintf("(%d)", selectedCount), icon.Select
@@ -122,6 +141,13 @@ func (m *Model) getCursorString() string {
 	if !m.Empty() {
 		cursor++ // Convert to 1-based
 	}
+	if m.results != nil {
+		return m.getSearchResultsCursorString(cursor)
+	}
+	// Elements filtered by the search bar are counted against all of them
+	if m.SearchBar.Value() != "" {
+		return fmt.Sprintf("%d/%d of %d", cursor, m.ElemCount(), m.searchTotal)
+	}
 	return fmt.Sprintf("%d/%d", cursor, m.ElemCount())
 }
 
@@ -144,6 +170,11 @@ func (m *Model) renderSelectBox(isSelected bool) string {
 
 // Checks whether a panel needs re-render due to being invalid or due to directory change
 func (m *Model) NeedsReRender() bool {
+	// Search results are not direct children of the location, and are
+	// added as they are found
+	if m.results != nil {
+		return false
+	}
 	if !m.EmptyOrInvalid() {
 		return filepath.Dir(m.GetFirstElement().Location) != m.Location
 	}
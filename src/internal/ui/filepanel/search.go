@@ -0,0 +1,68 @@
+package filepanel
+
+import (
+	"time"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+// SearchBarWidth is the width of the search bar input, leaving room for the
+// search mode indicator
+func (m *Model) SearchBarWidth() int {
+	return m.width - common.InnerPadding - searchIndicatorWidth
+}
+
+// CycleSearchMode switches to the next search mode, and filters again with it
+func (m *Model) CycleSearchMode() {
+	m.SearchMode = m.SearchMode.Next()
+	m.forceElementsUpdate()
+}
+
+// ToggleFilterPin pins the search bar value, so that it keeps filtering the
+// panel after moving to other directories, or unpins it
+func (m *Model) ToggleFilterPin() {
+	m.FilterPinned = !m.FilterPinned
+}
+
+// ClearSearch removes the filter of the search bar, even if it is pinned, and
+// the results of the recursive search
+func (m *Model) ClearSearch() {
+	m.SearchBar.Blur()
+	m.SearchBar.SetValue("")
+	m.FilterPinned = false
+	m.StopRecursiveSearch()
+}
+
+// Elements are loaded again on the next update, without waiting for the
+// refresh interval of large directories
+func (m *Model) forceElementsUpdate() {
+	m.LastTimeGetElement = time.Time{}
+}
+
+// Search mode shown at the end of the search bar, marked for pinned filters
+// and recursive or content searches. Invalid patterns are shown in the error style
+func (m *Model) searchIndicator() string {
+	label := m.SearchMode.String()
+	if m.results != nil {
+		// The recursive search can use another mode than the search bar
+		label = m.results.mode.String()
+		if m.results.content {
+			label = "grep " + label
+		} else if common.Config.Nerdfont {
+			label = icon.Search + icon.Space + label
+		} else {
+			label = "rec " + label
+		}
+	} else if m.FilterPinned {
+		if common.Config.Nerdfont {
+			label = icon.Pinned + icon.Space + label
+		} else {
+			label = "pin " + label
+		}
+	}
+	if m.searchInvalid && m.SearchBar.Value() != "" {
+		return common.ModalErrorStyle.Render(label)
+	}
+	return common.FilePanelTopDirectoryIconStyle.Render(label)
+}
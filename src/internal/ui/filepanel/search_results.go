@@ -0,0 +1,231 @@
+package filepanel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/pkg/search"
+)
+
+var (
+	errSearchInArchive    = errors.New("cannot search recursively inside archives")
+	errEmptyContentSearch = errors.New("content search needs a pattern")
+)
+
+// StartRecursiveSearch searches the tree under the panel location for the
+// search bar value, and shows the matches in place of the directory content.
+// The search runs in background, its results come back as SearchResultsMsg
+// from the returned command. Hidden entries are searched when displayDotFile is
+// set, and entries ignored by git unless includeIgnored is.
+func (m *Model) StartRecursiveSearch(displayDotFile bool, includeIgnored bool) (tea.Cmd, error) {
+	if m.IsInArchive() {
+		return nil, errSearchInArchive
+	}
+	query, err := search.ParseQuery(m.SearchBar.Value())
+	if err != nil {
+		return nil, err
+	}
+	// Fuzzy matching ranks a known list of names, walked names are matched as
+	// they are found instead
+	mode := m.SearchMode
+	if mode == search.ModeFuzzy {
+		mode = search.ModeExact
+	}
+	matcher, err := search.NewMatcher(mode, query.Pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	match := func(entry os.DirEntry) bool {
+		if !matcher.Match(entry.Name()) {
+			return false
+		}
+		if !query.HasPredicates() {
+			return true
+		}
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			return false
+		}
+		return query.MatchAttributes(elementAttributes(Element{
+			Name:      entry.Name(),
+			Directory: entry.IsDir(),
+			Info:      info,
+		}), now)
+	}
+	opts := search.WalkOptions{Hidden: displayDotFile, Ignored: includeIgnored, Limit: maxSearchResults}
+	return m.startSearch(mode, false, func(ctx context.Context, root string, channel chan<- []search.Result) error {
+		return search.Walk(ctx, root, match, opts, channel)
+	}), nil
+}
+
+// StartContentSearch searches the content of the files in the tree under the
+// panel location for the search bar value, and shows the matching lines in
+// place of the directory content, like StartRecursiveSearch. The value is a
+// regex in regex mode, and literal text otherwise. Binary files are skipped.
+func (m *Model) StartContentSearch(displayDotFile bool, includeIgnored bool) (tea.Cmd, error) {
+	if m.IsInArchive() {
+		return nil, errSearchInArchive
+	}
+	if m.SearchBar.Value() == "" {
+		return nil, errEmptyContentSearch
+	}
+	mode := search.ModeExact
+	if m.SearchMode == search.ModeRegex {
+		mode = search.ModeRegex
+	}
+	// Attribute filters are not parsed, as code often looks like them
+	matcher, err := search.NewMatcher(mode, m.SearchBar.Value())
+	if err != nil {
+		return nil, err
+	}
+	opts := search.WalkOptions{Hidden: displayDotFile, Ignored: includeIgnored, Limit: maxSearchResults}
+	cmd := m.startSearch(mode, true, func(ctx context.Context, root string, channel chan<- []search.Result) error {
+		return search.Grep(ctx, root, matcher.Match, opts, channel)
+	})
+	m.results.matcher = matcher
+	return cmd, nil
+}
+
+// ContentMatchIndex returns the function finding the span of the content
+// search pattern in a line, like search.Matcher.Index. It is nil when the panel
+// doesn't show the results of a content search
+func (m *Model) ContentMatchIndex() func(line string) []int {
+	if m.results == nil || m.results.matcher == nil {
+		return nil
+	}
+	return m.results.matcher.Index
+}
+
+// Shows the results of run in place of the directory content, and returns the
+// command waiting for them. run is cancelled when the results are left
+func (m *Model) startSearch(mode search.Mode, content bool,
+	run func(ctx context.Context, root string, channel chan<- []search.Result) error) tea.Cmd {
+	m.StopRecursiveSearch()
+	m.saveDirectoryRecord()
+	m.cursor = 0
+	m.renderIndex = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.results = &searchResults{mode: mode, content: content, running: true, cancel: cancel}
+	m.element = nil
+
+	root := m.Location
+	channel := make(chan []search.Result)
+	slog.Debug("Starting search", "root", root, "mode", mode, "content", content, "value", m.SearchBar.Value())
+	go func() {
+		if err := run(ctx, root, channel); err != nil && !errors.Is(err, context.Canceled) {
+			slog.Debug("Search stopped", "root", root, "error", err)
+		}
+	}()
+	return listenSearchResults(m.results, channel)
+}
+
+// StopRecursiveSearch cancels the recursive or content search of the panel, if any, and
+// shows the directory content again with the cursor where it was before
+func (m *Model) StopRecursiveSearch() {
+	if m.results == nil {
+		return
+	}
+	m.results.cancel()
+	m.results = nil
+	m.element = nil
+	m.cursor = 0
+	m.renderIndex = 0
+	if record, ok := m.DirectoryRecords[m.Location]; ok {
+		m.cursor = record.directoryCursor
+		m.renderIndex = record.directoryRender
+	}
+	m.forceElementsUpdate()
+}
+
+// ShowingSearchResults reports whether the panel shows the results of a
+// recursive or content search
+func (m *Model) ShowingSearchResults() bool {
+	return m.results != nil
+}
+
+// ApplySearchResults adds the results of msg to the panel, if they are the
+// ones of its search, and reports whether they were
+func (m *Model) ApplySearchResults(msg SearchResultsMsg) bool {
+	if m.results == nil || m.results != msg.results {
+		return false
+	}
+	if msg.done {
+		m.results.running = false
+		m.results.cancel()
+		return true
+	}
+	for _, result := range msg.batch {
+		m.results.elements = append(m.results.elements, Element{
+			Name:      result.Path,
+			Location:  filepath.Join(m.Location, result.Path),
+			Directory: result.Info.IsDir() || isSymlinkToDir(m.Location, result.Info, result.Path),
+			Info:      result.Info,
+			Line:      result.Line,
+			LineText:  result.Text,
+		})
+	}
+	m.element = m.results.elements
+	if m.ValidateCursorAndRenderIndex() != nil {
+		m.scrollToCursor(0)
+	}
+	return true
+}
+
+// NextCmd returns the command waiting for the results after the ones of msg,
+// nil once the search is over
+func (msg SearchResultsMsg) NextCmd() tea.Cmd {
+	if msg.done {
+		return nil
+	}
+	return listenSearchResults(msg.results, msg.channel)
+}
+
+func listenSearchResults(results *searchResults, channel <-chan []search.Result) tea.Cmd {
+	return func() tea.Msg {
+		batch, ok := <-channel
+		return SearchResultsMsg{
+			results: results,
+			batch:   batch,
+			done:    !ok,
+			channel: channel,
+		}
+	}
+}
+
+// Drops the results that were removed since they were found, and updates the
+// info of the others
+func (m *Model) getSearchResultElements() []Element {
+	kept := m.results.elements[:0]
+	for _, elem := range m.results.elements {
+		info, err := os.Lstat(elem.Location)
+		if err != nil {
+			continue
+		}
+		elem.Info = info
+		elem.Directory = info.IsDir() || isSymlinkToDir(m.Location, info, elem.Name)
+		kept = append(kept, elem)
+	}
+	m.results.elements = kept
+	return kept
+}
+
+// Position of the cursor among the results, with the state of the search
+func (m *Model) getSearchResultsCursorString(cursor int) string {
+	status := "found"
+	if m.results.running {
+		status = "searching"
+	} else if len(m.results.elements) >= maxSearchResults {
+		status = "limit"
+	}
+	return fmt.Sprintf("%d/%d %s", cursor, m.ElemCount(), status)
+}
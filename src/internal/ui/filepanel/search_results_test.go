@@ -0,0 +1,152 @@
+package filepanel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/search"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestRecursiveSearch(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	deep := filepath.Join(sub, "deep")
+	utils.SetupDirectories(t, deep)
+	utils.SetupFiles(t, filepath.Join(root, "main.go"), filepath.Join(root, "notes.txt"),
+		filepath.Join(sub, "util.go"), filepath.Join(deep, "deep.go"), filepath.Join(deep, ".hidden.go"))
+
+	// Runs the search to the end, like the event loop would
+	runSearch := func(m *Model, displayDotFile bool) {
+		t.Helper()
+		cmd, err := m.StartRecursiveSearch(displayDotFile, false)
+		require.NoError(t, err)
+		assert.True(t, m.ShowingSearchResults())
+		for cmd != nil {
+			msg, ok := cmd().(SearchResultsMsg)
+			require.True(t, ok)
+			require.True(t, m.ApplySearchResults(msg))
+			cmd = msg.NextCmd()
+		}
+	}
+	elementNames := func(m *Model) []string {
+		names := []string{}
+		for _, elem := range m.element {
+			names = append(names, elem.Name)
+		}
+		return names
+	}
+
+	m := columnsTestModel(root, 40)
+	m.UpdateElementsIfNeeded(true, false)
+	m.SearchMode = search.ModeGlob
+	m.SearchBar.SetValue("*.go")
+	runSearch(&m, false)
+	assert.ElementsMatch(t, []string{"main.go", filepath.Join("sub", "util.go"),
+		filepath.Join("sub", "deep", "deep.go")}, elementNames(&m))
+	idx := m.FindElementIndexByLocation(filepath.Join(deep, "deep.go"))
+	require.NotEqual(t, -1, idx, "Results have their full location")
+	assert.Equal(t, "1/3 found", m.getCursorString())
+
+	// Results stay on periodic updates, except the removed ones
+	require.NoError(t, os.Remove(filepath.Join(sub, "util.go")))
+	m.UpdateElementsIfNeeded(true, false)
+	assert.ElementsMatch(t, []string{"main.go", filepath.Join("sub", "deep", "deep.go")}, elementNames(&m))
+
+	// Fuzzy mode matches like exact mode, with the attribute filters
+	m.SearchMode = search.ModeFuzzy
+	m.SearchBar.SetValue("deep type:dir")
+	runSearch(&m, true)
+	assert.Equal(t, []string{filepath.Join("sub", "deep")}, elementNames(&m))
+	m.SearchBar.SetValue(".go")
+	runSearch(&m, true)
+	assert.ElementsMatch(t, []string{"main.go", filepath.Join("sub", "deep", "deep.go"),
+		filepath.Join("sub", "deep", ".hidden.go")}, elementNames(&m))
+
+	// Results of a stopped search are dropped
+	cmd, err := m.StartRecursiveSearch(false, false)
+	require.NoError(t, err)
+	m.StopRecursiveSearch()
+	msg, ok := cmd().(SearchResultsMsg)
+	require.True(t, ok)
+	assert.False(t, m.ApplySearchResults(msg))
+
+	// Leaving the results shows the directory content again
+	runSearch(&m, false)
+	require.NoError(t, m.ParentDirectory())
+	assert.False(t, m.ShowingSearchResults())
+	assert.Equal(t, root, m.Location)
+	assert.Empty(t, m.SearchBar.Value())
+	m.UpdateElementsIfNeeded(false, false)
+	assert.ElementsMatch(t, []string{"main.go", "notes.txt", "sub"}, elementNames(&m))
+
+	runSearch(&m, false)
+	require.NoError(t, m.UpdateCurrentFilePanelDir(sub))
+	assert.False(t, m.ShowingSearchResults())
+}
+
+func TestContentSearch(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	utils.SetupDirectories(t, sub)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc Main() {}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(sub, "util.go"), []byte("package sub\nfunc helper() {}\n"), 0o644))
+
+	m := columnsTestModel(root, 40)
+	m.UpdateElementsIfNeeded(true, false)
+	_, err := m.StartContentSearch(false, false)
+	require.ErrorIs(t, err, errEmptyContentSearch)
+	assert.Nil(t, m.ContentMatchIndex())
+
+	m.SearchBar.SetValue("func")
+	cmd, err := m.StartContentSearch(false, false)
+	require.NoError(t, err)
+	for cmd != nil {
+		msg, ok := cmd().(SearchResultsMsg)
+		require.True(t, ok)
+		require.True(t, m.ApplySearchResults(msg))
+		cmd = msg.NextCmd()
+	}
+	require.Len(t, m.element, 2)
+	idx := m.FindElementIndexByLocation(filepath.Join(sub, "util.go"))
+	require.NotEqual(t, -1, idx)
+	elem := m.element[idx]
+	assert.Equal(t, 2, elem.Line)
+	assert.Equal(t, "func helper() {}", elem.LineText)
+	assert.Equal(t, filepath.Join(sub, "util.go")+":2", elem.PreviewLocation())
+	require.NotNil(t, m.ContentMatchIndex())
+	assert.Equal(t, []int{0, 4}, m.ContentMatchIndex()(elem.LineText), "Matches are found for the preview")
+
+	// Regex mode takes the value as a regex
+	m.SearchMode = search.ModeRegex
+	m.SearchBar.SetValue("^package (main|sub)$")
+	cmd, err = m.StartContentSearch(false, false)
+	require.NoError(t, err)
+	for cmd != nil {
+		msg, ok := cmd().(SearchResultsMsg)
+		require.True(t, ok)
+		m.ApplySearchResults(msg)
+		cmd = msg.NextCmd()
+	}
+	assert.Len(t, m.element, 2)
+
+	// Selection is per file, for all of its matching lines
+	m.SearchBar.SetValue("Main|main")
+	cmd, err = m.StartContentSearch(false, false)
+	require.NoError(t, err)
+	for cmd != nil {
+		msg, ok := cmd().(SearchResultsMsg)
+		require.True(t, ok)
+		m.ApplySearchResults(msg)
+		cmd = msg.NextCmd()
+	}
+	require.Len(t, m.element, 2)
+	m.scrollToCursor(0)
+	m.SingleItemSelect()
+	assert.True(t, m.CheckSelected(m.element[1].Location))
+	assert.Equal(t, []string{filepath.Join(root, "main.go")}, m.GetSelectedLocations())
+}
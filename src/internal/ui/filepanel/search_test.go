@@ -0,0 +1,109 @@
+package filepanel
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/search"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestSearchModes(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "sub")
+	utils.SetupDirectories(t, sub)
+	utils.SetupFiles(t, filepath.Join(root, "debug.log"), filepath.Join(root, "test_main.go"),
+		filepath.Join(root, "main_test.go"), filepath.Join(sub, "app.log"), filepath.Join(sub, "app.go"))
+
+	elementNames := func(m *Model) []string {
+		names := []string{}
+		for _, elem := range m.getElements(false) {
+			names = append(names, elem.Name)
+		}
+		return names
+	}
+
+	m := columnsTestModel(root, 40)
+	m.SearchBar.SetValue("*.log")
+	assert.Empty(t, elementNames(&m), "Fuzzy mode does not treat * as a wildcard")
+	m.CycleSearchMode()
+	assert.Equal(t, search.ModeGlob, m.SearchMode)
+	assert.True(t, m.LastTimeGetElement.IsZero(), "Elements should be loaded again")
+	assert.Equal(t, []string{"debug.log"}, elementNames(&m))
+
+	m.CycleSearchMode()
+	m.SearchBar.SetValue("^test_")
+	assert.Equal(t, []string{"test_main.go"}, elementNames(&m))
+	m.SearchBar.SetValue("(")
+	assert.Empty(t, elementNames(&m))
+	assert.True(t, m.searchInvalid)
+	m.SearchBar.SetValue("")
+	assert.Len(t, elementNames(&m), 4)
+	assert.False(t, m.searchInvalid)
+
+	m.CycleSearchMode()
+	m.SearchBar.SetValue("_TEST")
+	assert.Empty(t, elementNames(&m), "Uppercase patterns are case sensitive")
+	m.SearchBar.SetValue("_test")
+	assert.Equal(t, []string{"main_test.go"}, elementNames(&m))
+
+	// Pinned filter
+	m.CycleSearchMode()
+	m.CycleSearchMode()
+	m.SearchBar.SetValue("*.log")
+	require.NoError(t, m.UpdateCurrentFilePanelDir(sub))
+	assert.Empty(t, m.SearchBar.Value(), "Filter is reset without pin")
+	require.NoError(t, m.ParentDirectory())
+
+	m.SearchBar.SetValue("*.log")
+	m.ToggleFilterPin()
+	require.NoError(t, m.UpdateCurrentFilePanelDir(sub))
+	assert.Equal(t, []string{"app.log"}, elementNames(&m))
+	assert.Contains(t, m.searchIndicator(), "glob")
+
+	m.ClearSearch()
+	assert.False(t, m.FilterPinned)
+	assert.Len(t, elementNames(&m), 2)
+}
+
+func TestSearchAttributeFilters(t *testing.T) {
+	root := t.TempDir()
+	utils.SetupDirectories(t, filepath.Join(root, "build"))
+	utils.SetupFilesWithData(t, make([]byte, 2048), filepath.Join(root, "big.go"))
+	utils.SetupFilesWithData(t, []byte("a"), filepath.Join(root, "small.go"), filepath.Join(root, "go.mod"),
+		filepath.Join(root, "notes.txt"))
+
+	m := columnsTestModel(root, 40)
+	testdata := []struct {
+		value    string
+		expected []string
+	}{
+		{"type:dir", []string{"build"}},
+		{"ext:go,mod", []string{"big.go", "go.mod", "small.go"}},
+		{"size>1K", []string{"big.go"}},
+		{"small size<1K", []string{"small.go"}},
+		{"modified<1h type:file t", []string{"notes.txt"}},
+		{"modified>1h", []string{}},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.value, func(t *testing.T) {
+			m.SearchBar.SetValue(tt.value)
+			m.element = m.getElements(false)
+			names := []string{}
+			for _, elem := range m.element {
+				names = append(names, elem.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.False(t, m.searchInvalid)
+			assert.Equal(t, fmt.Sprintf("%d/%d of 5", min(len(names), 1), len(names)), m.getCursorString())
+		})
+	}
+
+	m.SearchBar.SetValue("size>lots")
+	assert.Empty(t, m.getElements(false))
+	assert.True(t, m.searchInvalid)
+}
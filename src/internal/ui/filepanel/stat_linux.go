@@ -0,0 +1,28 @@
+package filepanel
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func getAccessTime(info os.FileInfo) (time.Time, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(stat.Atim.Unix()), true
+}
+
+// The creation time is not in the stat data of linux, and needs a statx call.
+// Some filesystems don't record it.
+func getCreateTime(path string, _ os.FileInfo) (time.Time, bool) {
+	var statx unix.Statx_t
+	err := unix.Statx(unix.AT_FDCWD, path, unix.AT_SYMLINK_NOFOLLOW, unix.STATX_BTIME, &statx)
+	if err != nil || statx.Mask&unix.STATX_BTIME == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(statx.Btime.Sec, int64(statx.Btime.Nsec)), true
+}
@@ -0,0 +1,16 @@
+//go:build !windows && !linux && !darwin && !freebsd && !netbsd
+
+package filepanel
+
+import (
+	"os"
+	"time"
+)
+
+func getAccessTime(_ os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func getCreateTime(_ string, _ os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
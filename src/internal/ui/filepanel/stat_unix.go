@@ -0,0 +1,36 @@
+//go:build !windows
+
+package filepanel
+
+import (
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+func getOwnerAndGroup(info os.FileInfo) (string, string) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", ""
+	}
+	uid := strconv.FormatUint(uint64(stat.Uid), 10)
+	gid := strconv.FormatUint(uint64(stat.Gid), 10)
+	// Ids are shown when they have no name
+	owner, group := uid, gid
+	if userData, err := user.LookupId(uid); err == nil {
+		owner = userData.Username
+	}
+	if groupData, err := user.LookupGroupId(gid); err == nil {
+		group = groupData.Name
+	}
+	return owner, group
+}
+
+func getInodeAndLinkCount(info os.FileInfo) (uint64, uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return stat.Ino, uint64(stat.Nlink), true //nolint:unconvert // Nlink is not uint64 on all platforms
+}
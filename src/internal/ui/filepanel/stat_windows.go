@@ -0,0 +1,33 @@
+//go:build windows
+
+package filepanel
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+func getOwnerAndGroup(_ os.FileInfo) (string, string) {
+	return "", ""
+}
+
+func getInodeAndLinkCount(_ os.FileInfo) (uint64, uint64, bool) {
+	return 0, 0, false
+}
+
+func getAccessTime(info os.FileInfo) (time.Time, bool) {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, data.LastAccessTime.Nanoseconds()), true
+}
+
+func getCreateTime(_ string, info os.FileInfo) (time.Time, bool) {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, data.CreationTime.Nanoseconds()), true
+}
@@ -0,0 +1,144 @@
+package filepanel
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/pkg/search"
+)
+
+// ToggleTreeView switches between the content of the location and the tree of
+// its expanded directories. The cursor stays on the focused item, or on the
+// item of the location containing it when the tree is left
+func (m *Model) ToggleTreeView() {
+	if m.results == nil && !m.EmptyOrInvalid() {
+		if relPath, err := filepath.Rel(m.Location, m.GetFocusedItem().Location); err == nil {
+			m.TargetFile, _, _ = strings.Cut(relPath, string(filepath.Separator))
+		}
+	}
+	m.TreeView = !m.TreeView
+	m.forceElementsUpdate()
+}
+
+// Whether the elements are shown as a tree. Search results are always flat
+func (m *Model) showingTree() bool {
+	return m.TreeView && m.results == nil
+}
+
+// Directories expanded in the tree of the location. They are kept in its
+// record, to be expanded again when coming back to it
+func (m *Model) expandedDirs() map[string]bool {
+	record := m.DirectoryRecords[m.Location]
+	if record.expanded == nil {
+		record.expanded = make(map[string]bool)
+		m.DirectoryRecords[m.Location] = record
+	}
+	return record.expanded
+}
+
+// ExpandFocusedDirectory expands the focused directory in tree view, and
+// reports whether it was collapsed. Its content is read on the next update
+func (m *Model) ExpandFocusedDirectory() bool {
+	if !m.showingTree() || m.EmptyOrInvalid() {
+		return false
+	}
+	elem := m.GetFocusedItem()
+	expanded := m.expandedDirs()
+	if !elem.Directory || expanded[elem.Location] {
+		return false
+	}
+	expanded[elem.Location] = true
+	m.forceElementsUpdate()
+	return true
+}
+
+// Collapses the focused directory in tree view if it is expanded, or else the
+// one containing the focused item, with the cursor moved on it. Reports whether
+// a directory was collapsed. The expanded directories inside it are kept
+func (m *Model) collapseFocusedDirectory() bool {
+	if !m.showingTree() || m.EmptyOrInvalid() {
+		return false
+	}
+	elem := m.GetFocusedItem()
+	expanded := m.expandedDirs()
+	if !expanded[elem.Location] {
+		if elem.Depth == 0 {
+			return false
+		}
+		parent := filepath.Dir(elem.Location)
+		if idx := m.FindElementIndexByLocation(parent); idx != -1 {
+			m.scrollToCursor(idx)
+		}
+		elem.Location = parent
+	}
+	delete(expanded, elem.Location)
+	m.forceElementsUpdate()
+	return true
+}
+
+// Inserts the content of the expanded directories under them, at any depth
+func (m *Model) getTreeElements(elements []Element, displayDotFile bool) []Element {
+	expanded := m.DirectoryRecords[m.Location].expanded
+	if len(expanded) == 0 {
+		return elements
+	}
+	tree := make([]Element, 0, len(elements))
+	for _, elem := range elements {
+		tree = m.appendTreeElement(tree, elem, expanded, displayDotFile)
+	}
+	return tree
+}
+
+func (m *Model) appendTreeElement(tree []Element, elem Element, expanded map[string]bool,
+	displayDotFile bool) []Element {
+	tree = append(tree, elem)
+	if !elem.Directory || !expanded[elem.Location] {
+		return tree
+	}
+	children, err := m.readDirectoryElements(elem.Location, displayDotFile)
+	if err != nil {
+		// Like directories replaced by files
+		slog.Debug("Collapsing unreadable directory of tree view", "location", elem.Location, "error", err)
+		delete(expanded, elem.Location)
+		return tree
+	}
+	children = m.filterTreeChildren(children)
+	for _, child := range children {
+		child.Depth = elem.Depth + 1
+		tree = m.appendTreeElement(tree, child, expanded, displayDotFile)
+	}
+	return tree
+}
+
+// Content of expanded directories is filtered by the search bar value, like
+// the content of the location
+func (m *Model) filterTreeChildren(children []Element) []Element {
+	if m.SearchBar.Value() == "" {
+		return children
+	}
+	query, err := search.ParseQuery(m.SearchBar.Value())
+	if err != nil {
+		return nil
+	}
+	children, err = m.filterElements(children, query)
+	if err != nil {
+		return nil
+	}
+	return children
+}
+
+// Indentation guides of the element in tree view, followed by the expansion
+// state of directories
+func (m *Model) treePrefix(elem Element) string {
+	prefix := strings.Repeat(icon.TreeGuide+" ", elem.Depth)
+	switch {
+	case !elem.Directory:
+		return prefix + "  "
+	case m.DirectoryRecords[m.Location].expanded[elem.Location]:
+		return prefix + icon.TreeExpanded + " "
+	default:
+		return prefix + icon.TreeCollapsed + " "
+	}
+}
@@ -0,0 +1,114 @@
+package filepanel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/search"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestTreeView(t *testing.T) {
+	root := t.TempDir()
+	dir1 := filepath.Join(root, "dir1")
+	nested := filepath.Join(dir1, "nested")
+	dir2 := filepath.Join(root, "dir2")
+	utils.SetupDirectories(t, nested, dir2)
+	utils.SetupFiles(t, filepath.Join(root, "file.txt"), filepath.Join(dir1, "a.txt"),
+		filepath.Join(nested, "b.txt"), filepath.Join(dir2, "file.txt"))
+
+	// Elements as their path relative to root with their depth
+	treeElements := func(m *Model) []string {
+		names := []string{}
+		for _, elem := range m.element {
+			relPath, err := filepath.Rel(root, elem.Location)
+			require.NoError(t, err)
+			names = append(names, filepath.ToSlash(relPath)+":"+string(rune('0'+elem.Depth)))
+		}
+		return names
+	}
+	focus := func(m *Model, location string) {
+		idx := m.FindElementIndexByLocation(location)
+		require.NotEqual(t, -1, idx, "%s should be shown", location)
+		m.scrollToCursor(idx)
+	}
+
+	m := columnsTestModel(root, 40)
+	m.height = 20
+	m.PanelMode = BrowserMode
+	m.UpdateElementsIfNeeded(true, false)
+	focus(&m, dir1)
+	assert.False(t, m.ExpandFocusedDirectory(), "Directories are only expanded in tree view")
+
+	m.ToggleTreeView()
+	m.UpdateElementsIfNeeded(false, false)
+	assert.Equal(t, []string{"dir1:0", "dir2:0", "file.txt:0"}, treeElements(&m))
+	require.True(t, m.ExpandFocusedDirectory())
+	assert.False(t, m.ExpandFocusedDirectory(), "Expanded directories are entered")
+	m.UpdateElementsIfNeeded(false, false)
+	focus(&m, nested)
+	require.True(t, m.ExpandFocusedDirectory())
+	focus(&m, dir2)
+	require.True(t, m.ExpandFocusedDirectory())
+	m.UpdateElementsIfNeeded(false, false)
+	assert.Equal(t, []string{"dir1:0", "dir1/nested:1", "dir1/nested/b.txt:2", "dir1/a.txt:1",
+		"dir2:0", "dir2/file.txt:1", "file.txt:0"}, treeElements(&m))
+	// Expanded directories are filtered like the location
+	m.SearchMode = search.ModeRegex
+	m.SearchBar.SetValue(`^(dir1|nested|a\.txt)$`)
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"dir1:0", "dir1/nested:1", "dir1/a.txt:1"}, treeElements(&m))
+	m.SearchBar.SetValue("")
+	m.UpdateElementsIfNeeded(true, false)
+
+	label, _ := m.getPanelModeInfo(0)
+	assert.Equal(t, "Tree", label)
+
+	// The cursor stays on the item that was focused when leaving the tree view
+	focus(&m, filepath.Join(dir2, "file.txt"))
+	m.ToggleTreeView()
+	m.UpdateElementsIfNeeded(false, false)
+	assert.Equal(t, dir2, m.GetFocusedItem().Location)
+	m.ToggleTreeView()
+	m.UpdateElementsIfNeeded(false, false)
+	assert.Equal(t, dir2, m.GetFocusedItem().Location)
+	assert.Len(t, m.element, 7, "Expanded directories are kept")
+
+	// Parent directory collapses the directory containing the focused item
+	focus(&m, filepath.Join(nested, "b.txt"))
+	require.NoError(t, m.ParentDirectory())
+	m.UpdateElementsIfNeeded(false, false)
+	assert.Equal(t, nested, m.GetFocusedItem().Location)
+	assert.Equal(t, []string{"dir1:0", "dir1/nested:1", "dir1/a.txt:1",
+		"dir2:0", "dir2/file.txt:1", "file.txt:0"}, treeElements(&m))
+	focus(&m, dir2)
+	require.NoError(t, m.ParentDirectory())
+	m.UpdateElementsIfNeeded(false, false)
+	assert.Equal(t, []string{"dir1:0", "dir1/nested:1", "dir1/a.txt:1", "dir2:0", "file.txt:0"},
+		treeElements(&m))
+
+	// Expansion is remembered in the records of the location
+	require.NoError(t, m.UpdateCurrentFilePanelDir(dir2))
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"dir2/file.txt:0"}, treeElements(&m))
+	require.NoError(t, m.ParentDirectory())
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, dir2, m.GetFocusedItem().Location)
+	assert.Len(t, m.element, 5)
+
+	require.NoError(t, os.RemoveAll(dir1))
+	m.UpdateElementsIfNeeded(true, false)
+	assert.Equal(t, []string{"dir2:0", "file.txt:0"}, treeElements(&m))
+}
+
+func TestSelectedLocationsInTree(t *testing.T) {
+	m := columnsTestModel(t.TempDir(), 40)
+	m.SetSelectedAll([]string{"/a", "/a/b", "/a/b/c", "/ab", "/d/e"})
+	assert.ElementsMatch(t, []string{"/a", "/ab", "/d/e"}, m.GetSelectedLocations(),
+		"Items in selected directories are left out")
+	assert.Equal(t, uint(5), m.SelectedCount())
+}
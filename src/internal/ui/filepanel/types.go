@@ -1,6 +1,7 @@
 package filepanel
 
 import (
+	"context"
 	"os"
 	"time"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
+	"github.com/yorukot/superfile/src/pkg/git"
+	"github.com/yorukot/superfile/src/pkg/search"
 )
 
 // Make sure to use New() to ensure that maps are initialized
@@ -30,7 +33,10 @@ type Model struct {
 	SortReversed bool
 
 	PanelMode PanelMode
-	// key is file location, value order of selection
+	// Shows the expanded directories inline, with their content under them
+	TreeView bool
+	// key is file location, value order of selection. Selection is per file,
+	// the matching lines of a file in content search results share it
 	selected           map[string]int
 	selectOrderCounter int
 	element            []Element
@@ -38,15 +44,31 @@ type Model struct {
 	Rename             textinput.Model
 	Renaming           bool
 	SearchBar          textinput.Model
+	SearchMode         search.Mode
+	// Keeps the search bar value while navigating to other directories
+	FilterPinned bool
+	// Count of elements the search bar value was matched against
+	searchTotal int
+	// Set when the search bar value is not a valid pattern for SearchMode
+	searchInvalid      bool
 	LastTimeGetElement time.Time
 	TargetFile         string             // filename to position cursor on after load
 	columns            []columnDefinition // columns for rendering
+	// Values of the columns loaded in background. Shared by the copies of the panel
+	columnValues map[columnValueKey]columnValueEntry
+	// Git status of the repository of Location. Shared by the copies of the panel
+	git *gitState
+	// Results of the recursive search shown in place of the directory content,
+	// nil when the directory content is shown
+	results *searchResults
 }
 
 // Record for directory navigation
 type directoryRecord struct {
 	directoryCursor int
 	directoryRender int
+	// Locations of the directories expanded in tree view
+	expanded map[string]bool
 }
 
 // Element within a file panel
@@ -55,6 +77,13 @@ type Element struct {
 	Location  string
 	Directory bool
 	Info      os.FileInfo
+	// Line of a content search match, 0 for other elements
+	Line int
+	// Text of the matching line
+	LineText string
+	// Count of directories between the panel location and the element in
+	// tree view, 0 for the elements directly in the location
+	Depth int
 }
 
 // Type representing the mode of the panel
@@ -75,4 +104,70 @@ type columnDefinition struct {
 	Size         int
 	HeaderAlign  lipgloss.Position
 	columnRender columnRenderer
+	// Type of the column if its values are loaded in background, empty otherwise
+	backgroundType string
+}
+
+type columnValueKey struct {
+	columnType string
+	location   string
+}
+
+type columnValueEntry struct {
+	value string
+	// Modification time of the element the value was loaded for
+	modTime time.Time
+	// Zero while the value is being loaded for the first time
+	loadedAt time.Time
+	loading  bool
+}
+
+// ColumnValueMsg carries the value of a background column, loaded by the
+// command of GetColumnValuesCmd
+type ColumnValueMsg struct {
+	key     columnValueKey
+	value   string
+	modTime time.Time
+}
+
+type gitState struct {
+	// Location the root was looked up for
+	location string
+	// Root of the repository, empty outside of repositories
+	root   string
+	status *git.Status
+	// Time of the last status request, zero if the status was never requested for root
+	requestedAt time.Time
+	loading     bool
+}
+
+// GitStatusMsg carries the status of a repository, loaded by the command of
+// filemodel's GetGitStatusCmd
+type GitStatusMsg struct {
+	Root   string
+	Status *git.Status
+}
+
+// Results of a recursive or content search under the panel location
+type searchResults struct {
+	mode search.Mode
+	// Set for content searches, whose elements are matching lines
+	content bool
+	// Pattern of content searches, nil for the others
+	matcher *search.Matcher
+	// Elements are named by their path relative to the panel location
+	elements []Element
+	// Set while the search is still sending results
+	running bool
+	cancel  context.CancelFunc
+}
+
+// SearchResultsMsg carries a batch of results of the search started by
+// StartRecursiveSearch or StartContentSearch
+type SearchResultsMsg struct {
+	results *searchResults
+	batch   []search.Result
+	// Set once the search is over, batch is empty then
+	done    bool
+	channel <-chan []search.Result
 }
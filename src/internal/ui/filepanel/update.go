@@ -3,9 +3,9 @@ package filepanel
 import (
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 )
 
@@ -32,17 +32,24 @@ func (m *Model) UpdateCurrentFilePanelDir(path string) error {
 		return nil
 	}
 
+	// Search results are left for the content of the new directory
+	m.StopRecursiveSearch()
+
 	// NOTE: This could be a configurable feature
 	// Update the cursor and render status in case we switch back to this.
-	m.DirectoryRecords[m.Location] = directoryRecord{
-		directoryCursor: m.cursor,
-		directoryRender: m.renderIndex,
-	}
+	m.saveDirectoryRecord()
 
-	if info, err := os.Stat(path); err != nil {
+	if info, err := archive.Stat(path); err != nil {
 		return fmt.Errorf("%s : no such file or directory, stats err : %w", path, err)
 	} else if !info.IsDir() {
-		return fmt.Errorf("%s is not a directory", path)
+		if !archive.DetectFormat(path).Browsable() {
+			return fmt.Errorf("%s is not a directory", path)
+		}
+		// Archives are browsed like directories. Read it now, so that
+		// unreadable archives are reported here instead of showing as empty
+		if _, err = archive.ReadDir(path); err != nil {
+			return fmt.Errorf("cannot browse archive %s : %w", path, err)
+		}
 	}
 
 	// In case of switching to parent, explicitly set focus.
@@ -69,14 +76,38 @@ func (m *Model) UpdateCurrentFilePanelDir(path string) error {
 
 	slog.Debug("updateCurrentFilePanelDir : After update", "cursor", m.cursor, "render", m.renderIndex)
 
-	// Reset the searchbar Value
+	// Reset the searchbar Value, unless the filter is pinned
 	// TODO(Refactoring) : Have a common searchBar type for sidebar and this search bar.
-	m.SearchBar.SetValue("")
+	if !m.FilterPinned {
+		m.SearchBar.SetValue("")
+	}
 
 	return nil
 }
 
+// Updates the cursor and render index of the record of the location, and
+// keeps its expanded directories
+func (m *Model) saveDirectoryRecord() {
+	record := m.DirectoryRecords[m.Location]
+	record.directoryCursor = m.cursor
+	record.directoryRender = m.renderIndex
+	m.DirectoryRecords[m.Location] = record
+}
+
+// ParentDirectory moves to the parent directory, or back to the directory
+// content from the results of a recursive search. In tree view, the focused
+// directory or the one containing the focused item is collapsed first
 func (m *Model) ParentDirectory() error {
+	if m.results != nil {
+		m.StopRecursiveSearch()
+		if !m.FilterPinned {
+			m.SearchBar.SetValue("")
+		}
+		return nil
+	}
+	if m.collapseFocusedDirectory() {
+		return nil
+	}
 	return m.UpdateCurrentFilePanelDir("..")
 }
 
@@ -86,3 +117,20 @@ func (m *Model) SelectAllItem() {
 		m.SetSelected(item.Location)
 	}
 }
+
+// SelectMatchingItems selects the items whose name matches the glob pattern,
+// and returns the count of matched items
+func (m *Model) SelectMatchingItems(pattern string) (int, error) {
+	matched := 0
+	for _, item := range m.element {
+		ok, err := filepath.Match(pattern, item.Name)
+		if err != nil {
+			return matched, err
+		}
+		if ok {
+			m.SetSelected(item.Location)
+			matched++
+		}
+	}
+	return matched, nil
+}
@@ -1,6 +1,12 @@
 package filepanel
 
-import "math"
+import (
+	"math"
+	"path/filepath"
+	"strconv"
+
+	"github.com/yorukot/superfile/src/pkg/archive"
+)
 
 func (m *Model) GetCursor() int {
 	return m.cursor
@@ -71,15 +77,28 @@ func (m *Model) CheckSelected(location string) bool {
 	return isSelected
 }
 
-// Returns an unordered list of selected locations
+// Returns an unordered list of selected locations. Items inside a selected
+// directory, like the ones of the tree view, are left out as operations on
+// the directory already include them
 func (m *Model) GetSelectedLocations() []string {
 	result := make([]string, 0, len(m.selected))
 	for k := range m.selected {
-		result = append(result, k)
+		if !m.hasSelectedParent(k) {
+			result = append(result, k)
+		}
 	}
 	return result
 }
 
+func (m *Model) hasSelectedParent(location string) bool {
+	for parent := filepath.Dir(location); parent != location; location, parent = parent, filepath.Dir(parent) {
+		if m.CheckSelected(parent) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Model) GetFirstSelectedLocation() string {
 	if len(m.selected) == 0 {
 		return ""
@@ -95,7 +114,8 @@ func (m *Model) GetFirstSelectedLocation() string {
 	return result
 }
 
-// Select the item where cursor located (only work on select mode)
+// Select the item where cursor located (only work on select mode). For a line
+// found by a content search, its file is selected, with its other lines
 func (m *Model) SingleItemSelect() {
 	if !m.EmptyOrInvalid() {
 		m.ToggleSelected(m.GetFocusedItem().Location)
@@ -118,6 +138,13 @@ func (m *Model) EmptyOrInvalid() bool {
 	return m.Empty() || m.ValidateCursorAndRenderIndex() != nil
 }
 
+// IsInArchive reports whether the panel is browsing an archive, either its
+// root or a directory inside it. Archives are browsed read-only
+func (m *Model) IsInArchive() bool {
+	_, _, ok := archive.SplitPath(m.Location)
+	return ok
+}
+
 func (m *Model) ToggleReverseSort() {
 	m.SortReversed = !m.SortReversed
 }
@@ -145,3 +172,12 @@ func (m *Model) FindElementIndexByLocation(location string) int {
 	}
 	return -1
 }
+
+// PreviewLocation identifies what the preview panel shows for the element.
+// Each line of a content search has its own preview
+func (e Element) PreviewLocation() string {
+	if e.Line == 0 {
+		return e.Location
+	}
+	return e.Location + ":" + strconv.Itoa(e.Line)
+}
@@ -0,0 +1,11 @@
+package gitcommitmodal
+
+const (
+	commitHeadlineText = "Commit staged changes"
+
+	// Including borders
+	modalWidth  = 60
+	modalHeight = 9
+
+	emptyMessageError = "Commit message cannot be empty"
+)
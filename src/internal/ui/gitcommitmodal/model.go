@@ -0,0 +1,73 @@
+package gitcommitmodal
+
+import (
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/inputmodal"
+)
+
+func New() Model {
+	return Model{
+		input: inputmodal.New(inputmodal.Config{
+			Headline:    icon.GitBranch + icon.Space + commitHeadlineText,
+			Placeholder: "Commit message",
+			ConfirmText: "Commit",
+			Width:       modalWidth,
+			Height:      modalHeight,
+			Validate:    validateMessage,
+		}),
+	}
+}
+
+func validateMessage(message string) (string, error) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", errors.New(emptyMessageError)
+	}
+	return message, nil
+}
+
+// Open the modal to commit to the repository at root. branch is only displayed
+func (m *Model) Open(root string, branch string) {
+	m.root = root
+	location := root
+	if branch != "" {
+		location += " (" + branch + ")"
+	}
+	m.input.Open(location, "")
+}
+
+func (m *Model) Close() {
+	m.root = ""
+	m.input.Close()
+}
+
+func (m *Model) IsOpen() bool {
+	return m.input.IsOpen()
+}
+
+func (m *Model) GetWidth() int {
+	return m.input.GetWidth()
+}
+
+func (m *Model) GetHeight() int {
+	return m.input.GetHeight()
+}
+
+func (m *Model) HandleUpdate(msg tea.Msg) (common.ModelAction, tea.Cmd) {
+	message, confirmed, cmd := m.input.HandleUpdate(msg)
+	if !confirmed {
+		return common.NoAction{}, cmd
+	}
+	action := common.GitCommitAction{
+		Root:    m.root,
+		Message: message,
+	}
+	m.Close()
+	return action, cmd
+}
@@ -0,0 +1,44 @@
+package gitcommitmodal
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestMain(m *testing.M) {
+	common.RunTestsWithConfig(m)
+}
+
+func TestGitCommitModal(t *testing.T) {
+	t.Run("Confirm with message", func(t *testing.T) {
+		m := New()
+		m.Open("/tmp/repo", "main")
+		assert.Contains(t, m.Render(), "/tmp/repo (main)")
+		m.HandleUpdate(utils.TeaRuneKeyMsg(" Fix the parser "))
+		action, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, common.GitCommitAction{Root: "/tmp/repo", Message: "Fix the parser"}, action)
+		assert.False(t, m.IsOpen())
+	})
+
+	t.Run("Empty message is rejected", func(t *testing.T) {
+		m := New()
+		m.Open("/tmp/repo", "main")
+		m.HandleUpdate(utils.TeaRuneKeyMsg("  "))
+		action, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, common.NoAction{}, action)
+		assert.Contains(t, m.Render(), emptyMessageError)
+		assert.True(t, m.IsOpen())
+	})
+
+	t.Run("Cancel closes the modal", func(t *testing.T) {
+		m := New()
+		m.Open("/tmp/repo", "main")
+		m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.False(t, m.IsOpen())
+	})
+}
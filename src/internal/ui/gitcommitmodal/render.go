@@ -0,0 +1,5 @@
+package gitcommitmodal
+
+func (m *Model) Render() string {
+	return m.input.Render()
+}
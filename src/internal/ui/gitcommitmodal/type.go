@@ -0,0 +1,14 @@
+package gitcommitmodal
+
+import (
+	"github.com/yorukot/superfile/src/internal/ui/inputmodal"
+)
+
+// Modal asking for the message of a commit of the staged changes.
+// No need to name it as GitCommitModel. It will be imported as gitcommitmodal.Model
+type Model struct {
+	input inputmodal.Model
+
+	// Repository that is committed to
+	root string
+}
@@ -1,7 +1,10 @@
 package helpmenu
 
 import (
+	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/yorukot/superfile/src/internal/common"
@@ -64,6 +67,16 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Open zoxide navigation",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.RecordMacro,
+			description:    "Record a macro into a register, or stop recording",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ReplayMacro,
+			description:    "Replay the macro of a register, optionally preceded by a count",
+			hotkeyWorkType: globalType,
+		},
 		{
 			subTitle: "Panel navigation",
 		},
@@ -102,6 +115,31 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Toggle footer",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ClearFinishedProcesses,
+			description:    "Clear finished processes (processbar focused)",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.OpenProcessHistory,
+			description:    "Open process history",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.RetryFailedItems,
+			description:    "Retry the failed items (process details)",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.CopyErrorList,
+			description:    "Copy the error list (process details)",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.CancelProcess,
+			description:    "Cancel the selected process, or kill its command (processbar focused)",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.NextFilePanel,
 			description:    "Focus on the next file panel",
@@ -180,11 +218,41 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Toggle active search bar",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleSearchMode,
+			description:    "Cycle search mode (fuzzy, glob, regex, exact) in the search bar",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.TogglePinFilter,
+			description:    "Pin the search bar filter to keep it in other directories",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.RecursiveSearch,
+			description:    "Search the search bar value in all subfolders",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.ContentSearch,
+			description:    "Search the search bar value in the content of all files",
+			hotkeyWorkType: globalType,
+		},
+		{
+			hotkey:         common.Hotkeys.JumpToContainingFolder,
+			description:    "Jump to the folder containing a search result",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.ChangePanelMode,
 			description:    "Change between selection mode or normal mode",
 			hotkeyWorkType: globalType,
 		},
+		{
+			hotkey:         common.Hotkeys.ToggleTreeView,
+			description:    "Toggle tree view, expanding folders inline",
+			hotkeyWorkType: globalType,
+		},
 		{
 			hotkey:         common.Hotkeys.PinnedDirectory,
 			description:    "Pin or Unpin folder to sidebar (can be auto saved)",
@@ -245,7 +313,17 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 		},
 		{
 			hotkey:         common.Hotkeys.CompressFile,
-			description:    "Zip file or folder to .zip file",
+			description:    "Compress files (choose name, format and level)",
+			hotkeyWorkType: normalType,
+		},
+		{
+			hotkey:         common.Hotkeys.AddToArchive,
+			description:    "Add clipboard or selected items to the focused archive",
+			hotkeyWorkType: normalType,
+		},
+		{
+			hotkey:         common.Hotkeys.TestArchive,
+			description:    "Test integrity of the focused archive",
 			hotkeyWorkType: normalType,
 		},
 		{
@@ -258,8 +336,62 @@ func getData() []hotkeydata { //nolint: funlen // This should be self contained
 			description:    "Open current directory with default editor",
 			hotkeyWorkType: normalType,
 		},
+		{
+			subTitle: "Git",
+		},
+		{
+			hotkey:         common.Hotkeys.GitStage,
+			description:    "Stage the changes of selected items",
+			hotkeyWorkType: normalType,
+		},
+		{
+			hotkey:         common.Hotkeys.GitUnstage,
+			description:    "Unstage the changes of selected items",
+			hotkeyWorkType: normalType,
+		},
+		{
+			hotkey:         common.Hotkeys.GitDiscard,
+			description:    "Discard the unstaged changes of selected items",
+			hotkeyWorkType: normalType,
+		},
+		{
+			hotkey:         common.Hotkeys.GitDiff,
+			description:    "Show the diff of selected items in the preview panel",
+			hotkeyWorkType: normalType,
+		},
+		{
+			hotkey:         common.Hotkeys.GitCommit,
+			description:    "Commit the staged changes",
+			hotkeyWorkType: normalType,
+		},
 	}
 
+	return append(data, getCustomCommandsData()...)
+}
+
+// Commands of the [commands] table. Those without hotkey are only run from the
+// SPF prompt
+func getCustomCommandsData() []hotkeydata {
+	if len(common.Config.Commands) == 0 {
+		return nil
+	}
+	data := []hotkeydata{
+		{
+			subTitle: "User-defined commands",
+		},
+	}
+	for _, name := range slices.Sorted(maps.Keys(common.Config.Commands)) {
+		command := common.Config.Commands[name]
+		hotkey := []string{}
+		if command.Hotkey != "" {
+			hotkey = append(hotkey, command.Hotkey)
+		}
+		data = append(data, hotkeydata{
+			hotkey:         hotkey,
+			description:    fmt.Sprintf("%s ('%s' in SPF prompt)", command.GetDescription(), name),
+			hotkeyWorkType: normalType,
+		})
+	}
 	return data
 }
 
@@ -0,0 +1,13 @@
+package historymodal
+
+const (
+	historyHeadlineText = "Process history"
+	noHistoryText       = "No finished processes yet"
+
+	// Each entry takes a line for what was done, and one for its details
+	linesPerEntry = 2
+
+	// Largest dimensions, including borders
+	maxWidth  = 90
+	maxHeight = 30
+)
@@ -0,0 +1,94 @@
+package historymodal
+
+import (
+	"slices"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+func New() Model {
+	return Model{
+		width:  maxWidth,
+		height: maxHeight,
+	}
+}
+
+// Open the modal with entries as read from the history file, oldest first.
+// err is shown instead of the entries if it isn't nil
+func (m *Model) Open(entries []processbar.HistoryEntry, err error) {
+	m.open = true
+	m.entries = slices.Clone(entries)
+	slices.Reverse(m.entries)
+	m.errorMsg = ""
+	if err != nil {
+		m.errorMsg = err.Error()
+	}
+	m.cursor = 0
+	m.renderIndex = 0
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.entries = nil
+	m.errorMsg = ""
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// SetMaxDimensions fits the modal into a screen of the given size
+func (m *Model) SetMaxDimensions(fullWidth int, fullHeight int) {
+	m.width = min(maxWidth, fullWidth-common.BorderPadding)
+	m.height = min(maxHeight, fullHeight-common.BorderPadding)
+	m.fixRenderIndex()
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetHeight() int {
+	return m.height
+}
+
+// HandleKey handles moving through the entries and closing the modal
+func (m *Model) HandleKey(msg string) {
+	switch {
+	case slices.Contains(common.Hotkeys.ListUp, msg):
+		m.ListUp()
+	case slices.Contains(common.Hotkeys.ListDown, msg):
+		m.ListDown()
+	case slices.Contains(common.Hotkeys.Quit, msg),
+		slices.Contains(common.Hotkeys.CancelTyping, msg),
+		slices.Contains(common.Hotkeys.OpenProcessHistory, msg):
+		m.Close()
+	}
+}
+
+func (m *Model) ListUp() {
+	if len(m.entries) == 0 {
+		return
+	}
+	m.cursor = (m.cursor - 1 + len(m.entries)) % len(m.entries)
+	m.fixRenderIndex()
+}
+
+func (m *Model) ListDown() {
+	if len(m.entries) == 0 {
+		return
+	}
+	m.cursor = (m.cursor + 1) % len(m.entries)
+	m.fixRenderIndex()
+}
+
+func (m *Model) cntRenderableEntries() int {
+	return max(1, (m.height-common.BorderPadding)/linesPerEntry)
+}
+
+// fixRenderIndex keeps the cursor within the rendered entries
+func (m *Model) fixRenderIndex() {
+	m.renderIndex = min(m.renderIndex, m.cursor)
+	m.renderIndex = max(m.renderIndex, m.cursor-m.cntRenderableEntries()+1)
+}
@@ -0,0 +1,88 @@
+package historymodal
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+func TestMain(m *testing.M) {
+	common.RunTestsWithConfig(m)
+}
+
+func testEntries(count int) []processbar.HistoryEntry {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	entries := make([]processbar.HistoryEntry, 0, count)
+	for i := range count {
+		entries = append(entries, processbar.HistoryEntry{
+			Operation: "copy",
+			Name:      fmt.Sprintf("file%d.txt", i),
+			State:     processbar.Successful.String(),
+			Files:     1,
+			StartTime: start.Add(time.Duration(i) * time.Minute),
+			EndTime:   start.Add(time.Duration(i)*time.Minute + time.Second),
+		})
+	}
+	return entries
+}
+
+func TestHistoryModal(t *testing.T) {
+	t.Run("Most recent entry first", func(t *testing.T) {
+		m := New()
+		entries := testEntries(3)
+		m.Open(entries, nil)
+		require.True(t, m.IsOpen())
+		assert.Equal(t, "file2.txt", m.entries[0].Name)
+		assert.Equal(t, "file0.txt", entries[0].Name, "Entries of the caller should be left untouched")
+		assert.Contains(t, m.Render(), "file2.txt")
+	})
+
+	t.Run("Cursor wraps around", func(t *testing.T) {
+		m := New()
+		m.Open(testEntries(3), nil)
+		m.ListUp()
+		assert.Equal(t, 2, m.cursor)
+		m.ListDown()
+		assert.Equal(t, 0, m.cursor)
+	})
+
+	t.Run("Scrolling keeps the cursor rendered", func(t *testing.T) {
+		m := New()
+		m.SetMaxDimensions(80, 8)
+		m.Open(testEntries(10), nil)
+		renderable := m.cntRenderableEntries()
+		for range renderable + 1 {
+			m.ListDown()
+		}
+		assert.Equal(t, renderable+1, m.cursor)
+		assert.Equal(t, 2, m.renderIndex)
+		assert.Contains(t, m.Render(), fmt.Sprintf("file%d.txt", 10-1-m.cursor))
+		assert.NotContains(t, m.Render(), "file9.txt")
+	})
+
+	t.Run("Empty and error", func(t *testing.T) {
+		m := New()
+		m.Open(nil, nil)
+		assert.Contains(t, m.Render(), noHistoryText)
+		m.ListDown()
+		assert.Equal(t, 0, m.cursor)
+
+		m.Open(nil, errors.New("cannot read history"))
+		assert.Contains(t, m.Render(), "cannot read history")
+	})
+
+	t.Run("Close with key", func(t *testing.T) {
+		m := New()
+		m.Open(testEntries(1), nil)
+		m.HandleKey(common.Hotkeys.CancelTyping[0])
+		assert.False(t, m.IsOpen())
+		assert.Nil(t, m.entries)
+	})
+}
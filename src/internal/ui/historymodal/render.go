@@ -0,0 +1,43 @@
+package historymodal
+
+import (
+	"fmt"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+)
+
+func (m *Model) Render() string {
+	r := ui.ProcessHistoryRenderer(m.height, m.width)
+	r.SetBorderTitle(historyHeadlineText)
+
+	switch {
+	case m.errorMsg != "":
+		r.AddLines(common.ModalErrorStyle.Render(" " + m.errorMsg))
+		return r.Render()
+	case len(m.entries) == 0:
+		r.AddLines(" " + noHistoryText)
+		return r.Render()
+	}
+
+	r.SetBorderInfoItems(fmt.Sprintf("%d/%d", m.cursor+1, len(m.entries)))
+	// Room left by the border, and the cursor and state icon
+	textWidth := m.width - common.BorderPadding - common.InnerPadding
+	end := min(len(m.entries), m.renderIndex+m.cntRenderableEntries())
+	for i := m.renderIndex; i < end; i++ {
+		entry := m.entries[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = common.FilePanelCursorStyle.Render(icon.Cursor + " ")
+		}
+		title := fmt.Sprintf("%s  %s %s", common.FormatTime(entry.EndTime.Local()), entry.Operation, entry.Name)
+		details := entry.Details()
+		if entry.Error != "" {
+			details += " : " + entry.Error
+		}
+		r.AddLines(cursor+entry.StateIcon()+" "+common.ModalStyle.Render(common.TruncateText(title, textWidth, "...")),
+			"    "+common.ModalStyle.Render(common.TruncateText(details, textWidth, "...")))
+	}
+	return r.Render()
+}
@@ -0,0 +1,22 @@
+package historymodal
+
+import (
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+// Read-only modal listing the finished processes saved in the history file.
+// No need to name it as HistoryModel. It will be imported as historymodal.Model
+type Model struct {
+	open bool
+	// Most recent first
+	entries []processbar.HistoryEntry
+	// Set if the history couldn't be read
+	errorMsg string
+
+	cursor      int
+	renderIndex int
+
+	// Including borders
+	width  int
+	height int
+}
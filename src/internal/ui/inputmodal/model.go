@@ -0,0 +1,102 @@
+package inputmodal
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func New(cfg Config) Model {
+	m := Model{
+		headline:    cfg.Headline,
+		confirmText: cfg.ConfirmText,
+		validate:    cfg.Validate,
+		input:       common.GeneratePromptTextInput(),
+		width:       cfg.Width,
+		height:      cfg.Height,
+	}
+	m.input.Placeholder = cfg.Placeholder
+	if cfg.Masked {
+		m.input.EchoMode = textinput.EchoPassword
+		m.input.EchoCharacter = '*'
+	}
+	m.input.Width = cfg.Width - common.InnerPadding
+	return m
+}
+
+// Open the modal with an empty input. subtitle is displayed above the input,
+// and errorMsg below it until the input is edited
+func (m *Model) Open(subtitle string, errorMsg string) {
+	m.open = true
+	m.subtitle = subtitle
+	m.errorMsg = errorMsg
+	m.input.SetValue("")
+	_ = m.input.Focus()
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.subtitle = ""
+	m.errorMsg = ""
+	m.input.Blur()
+	m.input.SetValue("")
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetHeight() int {
+	return m.height
+}
+
+// HandleUpdate updates the input with msg. confirmed is set when a valid value
+// is confirmed, and value is then the one returned by the validation. The
+// modal is only closed when cancelled, it is up to the caller to close it
+// once confirmed
+func (m *Model) HandleUpdate(msg tea.Msg) (string, bool, tea.Cmd) {
+	var cmd tea.Cmd
+	if !m.IsOpen() {
+		slog.Error("HandleUpdate called on closed input modal", "headline", m.headline)
+		return "", false, cmd
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// Non keypress updates like Cursor Blink
+		m.input, cmd = m.input.Update(msg)
+		return "", false, cmd
+	}
+
+	key := keyMsg.String()
+	switch {
+	case slices.Contains(common.Hotkeys.ConfirmTyping, key):
+		value, err := m.validateInput()
+		if err != nil {
+			m.errorMsg = err.Error()
+			return "", false, cmd
+		}
+		return value, true, cmd
+	case slices.Contains(common.Hotkeys.CancelTyping, key):
+		m.Close()
+	default:
+		m.errorMsg = ""
+		m.input, cmd = m.input.Update(msg)
+	}
+	return "", false, cmd
+}
+
+func (m *Model) validateInput() (string, error) {
+	if m.validate == nil {
+		return m.input.Value(), nil
+	}
+	return m.validate(m.input.Value())
+}
@@ -0,0 +1,80 @@
+package inputmodal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestMain(m *testing.M) {
+	common.RunTestsWithConfig(m)
+}
+
+func newTestModal(masked bool) Model {
+	return New(Config{
+		Headline:    "Name",
+		Placeholder: "New name",
+		ConfirmText: "Rename",
+		Width:       40,
+		Height:      8,
+		Masked:      masked,
+		Validate: func(value string) (string, error) {
+			if strings.TrimSpace(value) == "" {
+				return "", errors.New("name cannot be empty")
+			}
+			return strings.ToUpper(value), nil
+		},
+	})
+}
+
+func TestInputModal(t *testing.T) {
+	t.Run("Confirm returns the validated value", func(t *testing.T) {
+		m := newTestModal(false)
+		m.Open("/tmp/file.txt", "")
+		assert.Contains(t, m.Render(), "/tmp/file.txt")
+		assert.Contains(t, m.Render(), "Rename")
+		m.HandleUpdate(utils.TeaRuneKeyMsg("abc"))
+		value, confirmed, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.True(t, confirmed)
+		assert.Equal(t, "ABC", value)
+		assert.True(t, m.IsOpen(), "Caller closes the modal once confirmed")
+	})
+
+	t.Run("Invalid value shows the error until edited", func(t *testing.T) {
+		m := newTestModal(false)
+		m.Open("", "")
+		_, confirmed, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.False(t, confirmed)
+		assert.Contains(t, m.Render(), "name cannot be empty")
+		m.HandleUpdate(utils.TeaRuneKeyMsg("a"))
+		assert.NotContains(t, m.Render(), "name cannot be empty")
+	})
+
+	t.Run("Masked input", func(t *testing.T) {
+		m := newTestModal(true)
+		m.Open("", "")
+		m.HandleUpdate(utils.TeaRuneKeyMsg("secret"))
+		assert.NotContains(t, m.Render(), "secret")
+	})
+
+	t.Run("Extra lines", func(t *testing.T) {
+		m := newTestModal(false)
+		m.Open("", "")
+		assert.Contains(t, m.Render("[x] option"), "[x] option")
+	})
+
+	t.Run("Cancel closes the modal", func(t *testing.T) {
+		m := newTestModal(false)
+		m.Open("/tmp/file.txt", "previous error")
+		m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.False(t, m.IsOpen())
+		_, confirmed, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.False(t, confirmed, "Closed modal ignores updates")
+	})
+}
@@ -0,0 +1,25 @@
+package inputmodal
+
+import (
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+)
+
+// Render the modal. extraLines are added below the input, like options of
+// the modal
+func (m *Model) Render(extraLines ...string) string {
+	r := ui.InputModalRenderer(m.height, m.width)
+	r.SetBorderTitle(m.headline)
+
+	r.AddLines(" " + common.TruncateTextBeginning(m.subtitle, m.width-common.InnerPadding, "..."))
+	r.AddSection()
+	r.AddLines(m.input.View())
+	r.AddLines(extraLines...)
+	if m.errorMsg != "" {
+		r.AddLines(common.ModalErrorStyle.Render(" " + m.errorMsg))
+	}
+	r.AddLines("", " "+common.ModalConfirm.Render(" ("+common.Hotkeys.ConfirmTyping[0]+") "+m.confirmText+" ")+
+		common.ModalInputSpacingText+
+		common.ModalCancel.Render(" ("+common.Hotkeys.CancelTyping[0]+") Cancel "))
+	return r.Render()
+}
@@ -0,0 +1,41 @@
+package inputmodal
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// Config of an input modal
+type Config struct {
+	Headline    string
+	Placeholder string
+	// Text of the confirm button
+	ConfirmText string
+	// Including borders
+	Width  int
+	Height int
+	// Mask the value, for passwords
+	Masked bool
+	// Validate returns the value to use, or an error shown in the modal.
+	// Every value is accepted when it is nil
+	Validate func(value string) (string, error)
+}
+
+// Modal asking for a single line of text, validated on confirmation. It is
+// the base of the modals asking for a value, like a password or a commit message.
+// No need to name it as InputModel. It will be imported as inputmodal.Model
+type Model struct {
+	// Configuration
+	headline    string
+	confirmText string
+	validate    func(value string) (string, error)
+
+	// State
+	open  bool
+	input textinput.Model
+	// Displayed above the input, like what the value is asked for
+	subtitle string
+	errorMsg string
+
+	width  int
+	height int
+}
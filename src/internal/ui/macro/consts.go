@@ -0,0 +1,12 @@
+package macro
+
+const (
+	// Prevents a typo in the replay count from freezing superfile
+	MaxReplayCount = 1000
+	// A macro can replay other macros, including itself. This stops the
+	// recursion before it floods the event loop
+	MaxReplayDepth = 10
+
+	// The recording indicator is drawn on the bottom border, past its corner
+	IndicatorOffset = 2
+)
@@ -0,0 +1,161 @@
+package macro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func New() Model {
+	return Model{
+		registers: make(map[string][]tea.KeyMsg),
+	}
+}
+
+// IsValidRegister reports if register can name a macro. Registers are single
+// letters, as digits are used for the replay count
+func IsValidRegister(register string) bool {
+	if len(register) != 1 {
+		return false
+	}
+	c := register[0]
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// Load reads the registers saved at path, and saves the registers there from now on
+func (m *Model) Load(path string) error {
+	m.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	saved := make(map[string][]string)
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return fmt.Errorf("error parsing macros file: %w", err)
+	}
+	for register, keys := range saved {
+		if !IsValidRegister(register) {
+			continue
+		}
+		msgs := make([]tea.KeyMsg, 0, len(keys))
+		for _, key := range keys {
+			msgs = append(msgs, utils.TeaKeyMsgFromString(key))
+		}
+		m.registers[register] = msgs
+	}
+	return nil
+}
+
+func (m *Model) save() error {
+	if m.path == "" {
+		return nil
+	}
+	saved := make(map[string][]string, len(m.registers))
+	for register, msgs := range m.registers {
+		keys := make([]string, 0, len(msgs))
+		for _, msg := range msgs {
+			keys = append(keys, msg.String())
+		}
+		saved[register] = keys
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling macros: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), utils.UserDirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, data, utils.ConfigFilePerm)
+}
+
+// Registers returns the names of the registers holding a macro, sorted
+func (m *Model) Registers() []string {
+	return slices.Sorted(maps.Keys(m.registers))
+}
+
+func (m *Model) Keys(register string) ([]tea.KeyMsg, bool) {
+	keys, ok := m.registers[register]
+	return keys, ok
+}
+
+func (m *Model) IsRecording() bool {
+	return m.recordingRegister != ""
+}
+
+func (m *Model) RecordingRegister() string {
+	return m.recordingRegister
+}
+
+func (m *Model) StartRecording(register string) {
+	m.recordingRegister = register
+	m.recordedKeys = nil
+}
+
+// Record appends msg to the macro being recorded, if any
+func (m *Model) Record(msg tea.KeyMsg) {
+	if m.IsRecording() {
+		m.recordedKeys = append(m.recordedKeys, msg)
+	}
+}
+
+// StopRecording saves the recorded keys in their register. The last recorded
+// key is the one that stopped the recording, so it is left out.
+func (m *Model) StopRecording() error {
+	if !m.IsRecording() {
+		return nil
+	}
+	keys := m.recordedKeys
+	if len(keys) > 0 {
+		keys = keys[:len(keys)-1]
+	}
+	m.registers[m.recordingRegister] = keys
+	m.recordingRegister = ""
+	m.recordedKeys = nil
+	return m.save()
+}
+
+func (m *Model) AwaitRecordRegister() {
+	m.registerInput = recordRegisterInput
+	m.replayCount = 0
+}
+
+func (m *Model) AwaitReplayRegister() {
+	m.registerInput = replayRegisterInput
+	m.replayCount = 0
+}
+
+func (m *Model) IsAwaitingRegister() bool {
+	return m.registerInput != noRegisterInput
+}
+
+// HandleRegisterKey handles a key pressed after the record or replay key.
+// It returns true once a register is picked. Digits after the replay key
+// make the replay count, and any other key cancels.
+func (m *Model) HandleRegisterKey(key string) (RegisterInput, bool) {
+	if m.registerInput == replayRegisterInput && len(key) == 1 && '0' <= key[0] && key[0] <= '9' {
+		digit, _ := strconv.Atoi(key)
+		m.replayCount = min(m.replayCount*10+digit, MaxReplayCount)
+		return RegisterInput{}, false
+	}
+	replay := m.registerInput == replayRegisterInput
+	count := max(m.replayCount, 1)
+	m.registerInput = noRegisterInput
+	m.replayCount = 0
+	if !IsValidRegister(key) {
+		return RegisterInput{}, false
+	}
+	return RegisterInput{Register: key, Replay: replay, Count: count}, true
+}
@@ -0,0 +1,150 @@
+package macro
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestIsValidRegister(t *testing.T) {
+	for _, register := range []string{"a", "z", "A", "Q"} {
+		assert.True(t, IsValidRegister(register), register)
+	}
+	for _, register := range []string{"", "1", "ab", "@", "é", "enter"} {
+		assert.False(t, IsValidRegister(register), register)
+	}
+}
+
+func TestHandleRegisterKey(t *testing.T) {
+	t.Run("Record register", func(t *testing.T) {
+		m := New()
+		m.AwaitRecordRegister()
+		assert.True(t, m.IsAwaitingRegister())
+		input, ok := m.HandleRegisterKey("a")
+		assert.True(t, ok)
+		assert.Equal(t, RegisterInput{Register: "a", Replay: false, Count: 1}, input)
+		assert.False(t, m.IsAwaitingRegister())
+	})
+	t.Run("Digits are not registers", func(t *testing.T) {
+		m := New()
+		m.AwaitRecordRegister()
+		_, ok := m.HandleRegisterKey("3")
+		assert.False(t, ok)
+		assert.False(t, m.IsAwaitingRegister())
+	})
+	t.Run("Replay with count", func(t *testing.T) {
+		m := New()
+		m.AwaitReplayRegister()
+		for _, key := range []string{"1", "2"} {
+			_, ok := m.HandleRegisterKey(key)
+			assert.False(t, ok)
+			assert.True(t, m.IsAwaitingRegister())
+		}
+		input, ok := m.HandleRegisterKey("b")
+		assert.True(t, ok)
+		assert.Equal(t, RegisterInput{Register: "b", Replay: true, Count: 12}, input)
+	})
+	t.Run("Replay count is capped", func(t *testing.T) {
+		m := New()
+		m.AwaitReplayRegister()
+		for range 6 {
+			m.HandleRegisterKey("9")
+		}
+		input, ok := m.HandleRegisterKey("b")
+		assert.True(t, ok)
+		assert.Equal(t, MaxReplayCount, input.Count)
+	})
+	t.Run("Other keys cancel", func(t *testing.T) {
+		m := New()
+		m.AwaitReplayRegister()
+		m.HandleRegisterKey("4")
+		_, ok := m.HandleRegisterKey("esc")
+		assert.False(t, ok)
+		assert.False(t, m.IsAwaitingRegister())
+
+		// The count does not stay for the next replay
+		m.AwaitReplayRegister()
+		input, ok := m.HandleRegisterKey("a")
+		assert.True(t, ok)
+		assert.Equal(t, 1, input.Count)
+	})
+}
+
+func TestRecording(t *testing.T) {
+	keys := []tea.KeyMsg{utils.TeaRuneKeyMsg("j"), {Type: tea.KeyEnter}, {Type: tea.KeyCtrlX}}
+
+	m := New()
+	m.Record(utils.TeaRuneKeyMsg("k"))
+	assert.False(t, m.IsRecording())
+
+	m.StartRecording("a")
+	assert.True(t, m.IsRecording())
+	assert.Equal(t, "a", m.RecordingRegister())
+	for _, key := range keys {
+		m.Record(key)
+	}
+	// Key stopping the recording
+	m.Record(utils.TeaRuneKeyMsg("M"))
+	require.NoError(t, m.StopRecording())
+	assert.False(t, m.IsRecording())
+
+	recorded, ok := m.Keys("a")
+	assert.True(t, ok)
+	assert.Equal(t, keys, recorded)
+	_, ok = m.Keys("b")
+	assert.False(t, ok)
+
+	// Recording again replaces the macro
+	m.StartRecording("a")
+	m.Record(utils.TeaRuneKeyMsg("M"))
+	require.NoError(t, m.StopRecording())
+	recorded, ok = m.Keys("a")
+	assert.True(t, ok)
+	assert.Empty(t, recorded)
+}
+
+func TestLoadAndSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "macros.json")
+
+	m := New()
+	require.NoError(t, m.Load(path), "Missing file is not an error")
+	assert.Empty(t, m.Registers())
+
+	keys := []tea.KeyMsg{
+		utils.TeaRuneKeyMsg("j"),
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true},
+		{Type: tea.KeyRunes, Runes: []rune("pasted text"), Paste: true},
+	}
+	m.StartRecording("b")
+	for _, key := range keys {
+		m.Record(key)
+	}
+	m.Record(utils.TeaRuneKeyMsg("M"))
+	require.NoError(t, m.StopRecording())
+
+	loaded := New()
+	require.NoError(t, loaded.Load(path))
+	assert.Equal(t, []string{"b"}, loaded.Registers())
+	recorded, ok := loaded.Keys("b")
+	assert.True(t, ok)
+	assert.Equal(t, keys, recorded)
+
+	t.Run("Invalid registers are ignored", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"a": ["j"], "1": ["k"]}`), 0o644))
+		m := New()
+		require.NoError(t, m.Load(path))
+		assert.Equal(t, []string{"a"}, m.Registers())
+	})
+	t.Run("Invalid file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`["j"]`), 0o644))
+		m := New()
+		require.Error(t, m.Load(path))
+	})
+}
@@ -0,0 +1,8 @@
+package macro
+
+import "github.com/yorukot/superfile/src/internal/common"
+
+// RenderIndicator shows the register being recorded, like vim's "recording @a"
+func (m *Model) RenderIndicator() string {
+	return common.ProcessErrorStyle.Render(" recording @" + m.recordingRegister + " ")
+}
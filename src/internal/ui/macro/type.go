@@ -0,0 +1,38 @@
+package macro
+
+import tea "github.com/charmbracelet/bubbletea"
+
+type registerInputType int
+
+const (
+	noRegisterInput registerInputType = iota
+	recordRegisterInput
+	replayRegisterInput
+)
+
+// Model keeps the recorded key sequences in named registers, and the state of
+// an ongoing recording. Keys are given back to the caller for replays.
+// No need to name it as MacroModel. It will be imported as macro.Model
+type Model struct {
+	registers map[string][]tea.KeyMsg
+	// File where the registers are saved, not saved if empty
+	path string
+
+	// Register being recorded, empty if not recording
+	recordingRegister string
+	recordedKeys      []tea.KeyMsg
+
+	// Set after the record or replay key, until the register key is pressed
+	registerInput registerInputType
+	// Digits typed after the replay key, before the register
+	replayCount int
+}
+
+// RegisterInput is the request completed by pressing a register key after
+// the record or replay key
+type RegisterInput struct {
+	Register string
+	Replay   bool
+	// Number of replays, always positive for replays
+	Count int
+}
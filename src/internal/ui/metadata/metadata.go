@@ -9,6 +9,7 @@ import (
 
 	"github.com/barasher/go-exiftool"
 
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	"github.com/yorukot/superfile/src/internal/common"
@@ -102,6 +103,9 @@ func getMetaDataUnsorted(filePath string, metadataFocused bool, et *exiftool.Exi
 
 	fileInfo, err := os.Lstat(filePath)
 	if err != nil {
+		if archive.InArchive(filePath) {
+			return getArchiveEntryMetadata(filePath)
+		}
 		res.infoMsg = fileStatErrorMsg
 		return res
 	}
@@ -112,7 +116,7 @@ func getMetaDataUnsorted(filePath string, metadataFocused bool, et *exiftool.Exi
 	// Note : we prioritize these while sorting Metadata
 	name := [2]string{keyName, fileInfo.Name()}
 	size := [2]string{keySize, common.FormatFileSize(fileInfo.Size())}
-	modifyDate := [2]string{keyDataModified, fileInfo.ModTime().String()}
+	modifyDate := [2]string{keyDataModified, common.FormatTime(fileInfo.ModTime())}
 	permissions := [2]string{keyPermissions, fileInfo.Mode().String()}
 	ownerVal, groupVal := getOwnerAndGroup(fileInfo)
 	owner := [2]string{keyOwner, ownerVal}
@@ -149,6 +153,25 @@ func getMetaDataUnsorted(filePath string, metadataFocused bool, et *exiftool.Exi
 	return res
 }
 
+// Entries inside archives only have the information stored in the archive
+func getArchiveEntryMetadata(filePath string) Metadata {
+	res := Metadata{
+		filepath: filePath,
+	}
+	fileInfo, err := archive.Lstat(filePath)
+	if err != nil {
+		res.infoMsg = fileStatErrorMsg
+		return res
+	}
+	res.data = append(res.data,
+		[2]string{keyName, fileInfo.Name()},
+		[2]string{keySize, common.FormatFileSize(fileInfo.Size())},
+		[2]string{keyDataModified, common.FormatTime(fileInfo.ModTime())},
+		[2]string{keyPermissions, fileInfo.Mode().String()},
+	)
+	return res
+}
+
 func updateExiftoolMetadata(filePath string, et *exiftool.Exiftool, res *Metadata) {
 	if !common.Config.Metadata || et == nil {
 		return
@@ -0,0 +1,13 @@
+package outputmodal
+
+const (
+	outputHeadlineText = "Command output"
+	noOutputText       = "No output yet"
+	// Lines for the command, and its state
+	headerLines = 2
+	// Line for the hints, or the result of the last action
+	footerLines = 1
+	// Largest dimensions, including borders
+	maxWidth  = 120
+	maxHeight = 40
+)
@@ -0,0 +1,121 @@
+package outputmodal
+
+import (
+	"slices"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+func New() Model {
+	return Model{
+		width:  maxWidth,
+		height: maxHeight,
+	}
+}
+
+// Open the modal on the process p, which must have an output. The last
+// lines are shown first
+func (m *Model) Open(p processbar.Process) {
+	m.open = true
+	m.process = p
+	m.notice = ""
+	m.renderIndex = 0
+	m.follow = true
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.process = processbar.Process{}
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// ProcessID returns the ID of the process being shown
+func (m *Model) ProcessID() string {
+	return m.process.ID
+}
+
+// SetProcess updates the process being shown, as the command can still be running
+func (m *Model) SetProcess(p processbar.Process) {
+	m.process = p
+}
+
+// SetMaxDimensions fits the modal into a screen of the given size
+func (m *Model) SetMaxDimensions(fullWidth int, fullHeight int) {
+	m.width = min(maxWidth, fullWidth-common.BorderPadding)
+	m.height = min(maxHeight, fullHeight-common.BorderPadding)
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetHeight() int {
+	return m.height
+}
+
+// HandleKey handles scrolling through the output, killing the command and
+// closing the modal
+func (m *Model) HandleKey(msg string) {
+	switch {
+	case slices.Contains(common.Hotkeys.ListUp, msg):
+		m.scroll(-1)
+	case slices.Contains(common.Hotkeys.ListDown, msg):
+		m.scroll(1)
+	case slices.Contains(common.Hotkeys.PageUp, msg):
+		m.scroll(-m.cntRenderableLines())
+	case slices.Contains(common.Hotkeys.PageDown, msg):
+		m.scroll(m.cntRenderableLines())
+	case slices.Contains(common.Hotkeys.CancelProcess, msg):
+		m.kill()
+	case slices.Contains(common.Hotkeys.Quit, msg),
+		slices.Contains(common.Hotkeys.CancelTyping, msg),
+		slices.Contains(common.Hotkeys.Confirm, msg):
+		m.Close()
+	}
+}
+
+func (m *Model) kill() {
+	if m.process.Finished() {
+		m.notice = "The command is not running"
+		return
+	}
+	m.process.RequestCancel()
+	m.notice = "Killing the command"
+}
+
+// Scroll by delta lines. Reaching the bottom follows the output again
+func (m *Model) scroll(delta int) {
+	m.renderIndex = m.clampedRenderIndex(m.firstRenderedLine() + delta)
+	m.follow = m.renderIndex == m.lastRenderIndex()
+}
+
+func (m *Model) cntLines() int {
+	if m.process.Output == nil {
+		return 0
+	}
+	return m.process.Output.Len()
+}
+
+func (m *Model) cntRenderableLines() int {
+	return max(1, m.height-common.BorderPadding-headerLines-footerLines)
+}
+
+// Render index showing the last lines
+func (m *Model) lastRenderIndex() int {
+	return max(0, m.cntLines()-m.cntRenderableLines())
+}
+
+func (m *Model) clampedRenderIndex(idx int) int {
+	return max(0, min(idx, m.lastRenderIndex()))
+}
+
+func (m *Model) firstRenderedLine() int {
+	if m.follow {
+		return m.lastRenderIndex()
+	}
+	return m.clampedRenderIndex(m.renderIndex)
+}
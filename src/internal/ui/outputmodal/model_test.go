@@ -0,0 +1,82 @@
+package outputmodal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+func TestMain(m *testing.M) {
+	common.RunTestsWithConfig(m)
+}
+
+func commandProcess(lines int) processbar.Process {
+	p := processbar.NewProcess("id1", "make build", processbar.OpShellCommand, 1)
+	for i := range lines {
+		_, _ = fmt.Fprintf(p.Output, "line %d\n", i)
+	}
+	return p
+}
+
+func TestOutputModal(t *testing.T) {
+	t.Run("Last lines are shown, and followed", func(t *testing.T) {
+		m := New()
+		m.SetMaxDimensions(80, 12)
+		p := commandProcess(20)
+		m.Open(p)
+		require.True(t, m.IsOpen())
+		res := ansi.Strip(m.Render())
+		assert.Contains(t, res, "make build")
+		assert.Contains(t, res, "line 19")
+		assert.NotContains(t, res, "line 0\n")
+
+		_, _ = fmt.Fprintln(p.Output, "line 20")
+		assert.Contains(t, ansi.Strip(m.Render()), "line 20", "New output should be shown")
+	})
+
+	t.Run("Scrolling up stops following", func(t *testing.T) {
+		m := New()
+		m.SetMaxDimensions(80, 12)
+		p := commandProcess(20)
+		m.Open(p)
+		m.HandleKey(common.Hotkeys.PageUp[0])
+		m.HandleKey(common.Hotkeys.PageUp[0])
+		m.HandleKey(common.Hotkeys.PageUp[0])
+		assert.False(t, m.follow)
+		assert.Equal(t, 0, m.firstRenderedLine())
+		assert.Contains(t, ansi.Strip(m.Render()), "line 0")
+
+		_, _ = fmt.Fprintln(p.Output, "line 20")
+		assert.Equal(t, 0, m.firstRenderedLine(), "View should stay while scrolled up")
+
+		m.HandleKey(common.Hotkeys.ListDown[0])
+		assert.False(t, m.follow)
+		for range m.cntLines() / m.cntRenderableLines() {
+			m.HandleKey(common.Hotkeys.PageDown[0])
+		}
+		assert.True(t, m.follow, "Reaching the bottom should follow the output again")
+	})
+
+	t.Run("Kill and close", func(t *testing.T) {
+		m := New()
+		p := commandProcess(0)
+		m.Open(p)
+		assert.Contains(t, ansi.Strip(m.Render()), noOutputText)
+		m.HandleKey(common.Hotkeys.CancelProcess[0])
+		assert.True(t, p.CancelRequested(), "Killing from the modal should cancel the process")
+
+		p.State = processbar.Failed
+		p.ErrorMsg = "exited with status 2"
+		m.SetProcess(p)
+		assert.Contains(t, ansi.Strip(m.Render()), "exited with status 2")
+
+		m.HandleKey(common.Hotkeys.CancelTyping[0])
+		assert.False(t, m.IsOpen())
+	})
+}
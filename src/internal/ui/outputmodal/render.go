@@ -0,0 +1,67 @@
+package outputmodal
+
+import (
+	"fmt"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+func (m *Model) Render() string {
+	r := ui.CommandOutputRenderer(m.height, m.width)
+	r.SetBorderTitle(outputHeadlineText)
+	// Room left by the border, and the padding
+	textWidth := m.width - common.BorderPadding - common.InnerPadding
+	p := m.process
+
+	r.AddLines(" "+p.State.Icon()+" "+common.ModalStyle.Render(common.TruncateText(p.Name, textWidth, "...")),
+		"   "+common.ModalStyle.Render(common.TruncateText(m.statusText(), textWidth, "...")))
+
+	start := m.firstRenderedLine()
+	var lines []string
+	if p.Output != nil {
+		lines = p.Output.Lines(start, start+m.cntRenderableLines())
+	}
+	if len(lines) == 0 {
+		r.AddLines(" " + common.ModalTitleStyle.Render(noOutputText))
+	} else {
+		r.SetBorderInfoItems(fmt.Sprintf("%d-%d/%d", start+1, start+len(lines), m.cntLines()))
+	}
+	for _, line := range lines {
+		line = common.MakePrintableWithEscCheck(line, false)
+		r.AddLines(" " + common.TruncateText(line, textWidth, "..."))
+	}
+	// Keep the footer at the bottom
+	for range m.cntRenderableLines() - max(1, len(lines)) {
+		r.AddLines("")
+	}
+	r.AddLines(" " + m.footer())
+	return r.Render()
+}
+
+// State of the command, with its exit code and duration
+func (m *Model) statusText() string {
+	p := m.process
+	duration := processbar.FormatDuration(p.Duration())
+	switch p.State {
+	case processbar.InOperation:
+		return "Running for " + duration
+	case processbar.Successful:
+		return "Exited with status 0 after " + duration
+	default:
+		return fmt.Sprintf("%s after %s : %s", p.State, duration, p.ErrorMsg)
+	}
+}
+
+func (m *Model) footer() string {
+	switch {
+	case m.notice != "":
+		return common.ModalStyle.Render(m.notice)
+	case m.process.Finished():
+		return ""
+	default:
+		return common.ModalTitleStyle.Render(common.GetHelpMenuHotkeyString(common.Hotkeys.CancelProcess) +
+			" kill the command")
+	}
+}
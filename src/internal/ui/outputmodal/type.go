@@ -0,0 +1,23 @@
+package outputmodal
+
+import (
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+// Modal streaming the output of a shell command run in background. It can be
+// opened again from the process bar, while the command runs and after it ends.
+// No need to name it as OutputModel. It will be imported as outputmodal.Model
+type Model struct {
+	open    bool
+	process processbar.Process
+	// Result of the last action, shown instead of the hints
+	notice string
+
+	// First rendered line of output
+	renderIndex int
+	// Keep the last lines rendered as output comes. Set while scrolled to the bottom
+	follow bool
+	// Including borders
+	width  int
+	height int
+}
@@ -0,0 +1,14 @@
+package passwordmodal
+
+const (
+	passwordHeadlineText = "Password required"
+
+	// Including borders
+	modalWidth  = 50
+	modalHeight = 10
+
+	rememberToggleKey = "tab"
+
+	emptyPasswordError = "Password cannot be empty"
+	wrongPasswordError = "Wrong password, try again"
+)
@@ -0,0 +1,78 @@
+package passwordmodal
+
+import (
+	"errors"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/inputmodal"
+)
+
+func New() Model {
+	return Model{
+		input: inputmodal.New(inputmodal.Config{
+			Headline:    icon.Warn + icon.Space + passwordHeadlineText,
+			ConfirmText: "Extract",
+			Width:       modalWidth,
+			Height:      modalHeight,
+			Masked:      true,
+			Validate:    validatePassword,
+		}),
+	}
+}
+
+// Passwords are used as they are, spaces can be part of them
+func validatePassword(password string) (string, error) {
+	if password == "" {
+		return "", errors.New(emptyPasswordError)
+	}
+	return password, nil
+}
+
+// Open the modal asking for the password of archivePath. wrongPassword is
+// set when retrying after a failed attempt
+func (m *Model) Open(archivePath string, wrongPassword bool) {
+	m.archivePath = archivePath
+	errorMsg := ""
+	if wrongPassword {
+		errorMsg = wrongPasswordError
+	}
+	m.input.Open(filepath.Base(archivePath), errorMsg)
+}
+
+func (m *Model) Close() {
+	m.archivePath = ""
+	m.input.Close()
+}
+
+func (m *Model) IsOpen() bool {
+	return m.input.IsOpen()
+}
+
+func (m *Model) GetWidth() int {
+	return m.input.GetWidth()
+}
+
+func (m *Model) GetHeight() int {
+	return m.input.GetHeight()
+}
+
+func (m *Model) HandleUpdate(msg tea.Msg) (common.ModelAction, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.IsOpen() && keyMsg.String() == rememberToggleKey {
+		// Remembered across openings, like the format in compress modal
+		m.remember = !m.remember
+		return common.NoAction{}, nil
+	}
+	password, confirmed, cmd := m.input.HandleUpdate(msg)
+	if !confirmed {
+		return common.NoAction{}, cmd
+	}
+	return common.ExtractArchiveAction{
+		ArchivePath:      m.archivePath,
+		Password:         password,
+		RememberPassword: m.remember,
+	}, cmd
+}
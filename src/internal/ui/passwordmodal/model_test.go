@@ -0,0 +1,59 @@
+package passwordmodal
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestMain(m *testing.M) {
+	common.RunTestsWithConfig(m)
+}
+
+func TestPasswordModal(t *testing.T) {
+	t.Run("Confirm with password", func(t *testing.T) {
+		m := New()
+		m.Open("/tmp/secret.zip", false)
+		m.HandleUpdate(utils.TeaRuneKeyMsg("pass word"))
+		assert.NotContains(t, m.Render(), "pass word", "Password should be masked")
+		action, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, common.ExtractArchiveAction{
+			ArchivePath: "/tmp/secret.zip",
+			Password:    "pass word",
+		}, action)
+		assert.NotContains(t, action.String(), "pass word")
+	})
+
+	t.Run("Remember toggle", func(t *testing.T) {
+		m := New()
+		m.Open("/tmp/secret.zip", false)
+		m.HandleUpdate(tea.KeyMsg{Type: tea.KeyTab})
+		m.HandleUpdate(utils.TeaRuneKeyMsg("pass"))
+		action, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		require.IsType(t, common.ExtractArchiveAction{}, action)
+		extractAction, _ := action.(common.ExtractArchiveAction)
+		assert.True(t, extractAction.RememberPassword)
+	})
+
+	t.Run("Empty password is rejected", func(t *testing.T) {
+		m := New()
+		m.Open("/tmp/secret.zip", true)
+		assert.Contains(t, m.Render(), wrongPasswordError)
+		action, _ := m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEnter})
+		assert.Equal(t, common.NoAction{}, action)
+		assert.Contains(t, m.Render(), emptyPasswordError)
+		assert.True(t, m.IsOpen())
+	})
+
+	t.Run("Cancel closes the modal", func(t *testing.T) {
+		m := New()
+		m.Open("/tmp/secret.zip", false)
+		m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEsc})
+		assert.False(t, m.IsOpen())
+	})
+}
@@ -0,0 +1,14 @@
+package passwordmodal
+
+import (
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func (m *Model) Render() string {
+	checkbox := "[ ]"
+	if m.remember {
+		checkbox = "[x]"
+	}
+	return m.input.Render(
+		common.ModalStyle.Render(" " + checkbox + " Remember for this session (" + rememberToggleKey + ")"))
+}
@@ -0,0 +1,15 @@
+package passwordmodal
+
+import (
+	"github.com/yorukot/superfile/src/internal/ui/inputmodal"
+)
+
+// Modal asking for the password of an encrypted archive, with masked input.
+// No need to name it as PasswordModel. It will be imported as passwordmodal.Model
+type Model struct {
+	input    inputmodal.Model
+	remember bool
+
+	// Archive that the password is asked for
+	archivePath string
+}
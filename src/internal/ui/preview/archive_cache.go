@@ -0,0 +1,114 @@
+package preview
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yorukot/superfile/src/pkg/archive"
+)
+
+// Total size of the archive entries kept extracted for preview. The oldest
+// ones are removed first when it is exceeded
+const maxArchiveEntryCacheSize = 4 * maxArchiveEntryPreviewSize
+
+// An entry is extracted again when its archive is modified
+type archiveEntryKey struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+type archiveCacheEntry struct {
+	key           archiveEntryKey
+	extractedPath string
+	size          int64
+}
+
+// Entries of archives extracted for preview, so that rendering the same entry
+// again does not extract it every time
+type archiveEntryCache struct {
+	mu sync.Mutex
+	// Created on first use
+	tempDirectory string
+	// Oldest first
+	entries   []archiveCacheEntry
+	totalSize int64
+	// Used to give a unique directory to every extracted entry
+	counter int
+}
+
+// Returns the path of the entry extracted on disk, extracting it if needed.
+// size is the size of the entry
+func (c *archiveEntryCache) get(itemPath string, size int64) (string, error) {
+	archivePath, _, _ := archive.SplitPath(itemPath)
+	archiveInfo, err := os.Stat(archivePath)
+	if err != nil {
+		return "", err
+	}
+	key := archiveEntryKey{path: itemPath, modTime: archiveInfo.ModTime(), size: archiveInfo.Size()}
+
+	// Held while extracting, so the same entry is not extracted twice
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx := slices.IndexFunc(c.entries, func(e archiveCacheEntry) bool { return e.key == key })
+	if idx != -1 {
+		if _, err = os.Stat(c.entries[idx].extractedPath); err == nil {
+			return c.entries[idx].extractedPath, nil
+		}
+		c.remove(idx)
+	}
+
+	if c.tempDirectory == "" {
+		if c.tempDirectory, err = os.MkdirTemp("", "superfile-archive-preview-*"); err != nil {
+			return "", err
+		}
+	}
+	c.counter++
+	entryDir := filepath.Join(c.tempDirectory, strconv.Itoa(c.counter))
+	// Keep the name, as the preview is chosen based on the extension
+	extractedPath := filepath.Join(entryDir, filepath.Base(itemPath))
+	if err = archive.Extract(itemPath, extractedPath, nil); err != nil {
+		if removeErr := os.RemoveAll(entryDir); removeErr != nil {
+			slog.Error("Error removing partially extracted archive entry", "error", removeErr)
+		}
+		return "", err
+	}
+
+	for idx = 0; idx < len(c.entries); idx++ {
+		// Older versions of the entry are not used anymore
+		if c.entries[idx].key.path == itemPath {
+			c.remove(idx)
+			idx--
+		}
+	}
+	for len(c.entries) > 0 && c.totalSize+size > maxArchiveEntryCacheSize {
+		c.remove(0)
+	}
+	c.entries = append(c.entries, archiveCacheEntry{key: key, extractedPath: extractedPath, size: size})
+	c.totalSize += size
+	return extractedPath, nil
+}
+
+func (c *archiveEntryCache) remove(idx int) {
+	if err := os.RemoveAll(filepath.Dir(c.entries[idx].extractedPath)); err != nil {
+		slog.Error("Error removing archive entry extracted for preview", "error", err)
+	}
+	c.totalSize -= c.entries[idx].size
+	c.entries = slices.Delete(c.entries, idx, idx+1)
+}
+
+func (c *archiveEntryCache) cleanUp() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+	c.totalSize = 0
+	if c.tempDirectory == "" {
+		return nil
+	}
+	return os.RemoveAll(c.tempDirectory)
+}
@@ -22,6 +22,7 @@ type Model struct {
 	imagePreviewer     *filepreview.ImagePreviewer
 	batCmd             string
 	thumbnailGenerator *filepreview.ThumbnailGenerator
+	archiveCache       *archiveEntryCache
 }
 
 func New() Model {
@@ -42,6 +43,7 @@ func New() Model {
 		// be done via an Init() function
 		imagePreviewer:     filepreview.NewImagePreviewer(),
 		thumbnailGenerator: generator,
+		archiveCache:       &archiveEntryCache{},
 		// TODO:  This is an IO operation, move to async ?
 		batCmd: checkBatCmd(),
 	}
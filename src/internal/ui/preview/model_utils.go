@@ -59,6 +59,11 @@ func (m *Model) CleanUp() {
 			slog.Error("Error While cleaning up TempDirectory", "error", err)
 		}
 	}
+	if m.archiveCache != nil {
+		if err := m.archiveCache.cleanUp(); err != nil {
+			slog.Error("Error while cleaning up archive preview directory", "error", err)
+		}
+	}
 }
 
 func (m *Model) IsOpen() bool {
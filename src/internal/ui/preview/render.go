@@ -1,7 +1,9 @@
 package preview
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
 	"image"
 	"io/fs"
 	"log/slog"
@@ -9,12 +11,16 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/yorukot/ansichroma"
 
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/pkg/archive"
 	"github.com/yorukot/superfile/src/pkg/utils"
 
 	"github.com/yorukot/superfile/src/internal/common"
@@ -22,14 +28,54 @@ import (
 	"github.com/yorukot/superfile/src/internal/ui/rendering"
 )
 
+// Entries inside archives are extracted to a temporary directory for preview,
+// and kept there while the archive is unchanged. Bigger entries are skipped, as extracting them would take too long
+const maxArchiveEntryPreviewSize = 64 * 1024 * 1024
+
+// Summary line and the section divider above archive entries
+const archiveSummaryHeight = 2
+
+// Files with longer lines are not previewed further around matches
+const maxMatchPreviewLineSize = 1024 * 1024
+
+// Cursor marker and spaces around the line number, before the lines around matches
+const matchGutterPadding = 3
+
 func renderDirectoryPreview(r *rendering.Renderer, itemPath string, previewHeight int) string {
-	files, err := os.ReadDir(itemPath)
+	files, err := archive.ReadDir(itemPath)
 	if err != nil {
 		slog.Error("Error render directory preview", "error", err)
 		r.AddLines(common.FilePreviewDirectoryUnreadableText)
 		return r.Render()
 	}
+	return renderDirEntries(r, files, previewHeight)
+}
+
+// Archive's top level entries are listed like a directory, below a summary
+// of the whole archive
+func renderArchivePreview(r *rendering.Renderer, itemPath string, previewHeight int) string {
+	summary, err := archive.GetSummary(itemPath)
+	if err != nil {
+		slog.Error("Error render archive preview", "error", err)
+		return r.AddLines(common.FilePreviewArchiveUnreadableText).Render()
+	}
+	files, err := archive.ReadDir(itemPath)
+	if err != nil {
+		slog.Error("Error render archive preview", "error", err)
+		return r.AddLines(common.FilePreviewArchiveUnreadableText).Render()
+	}
 
+	info := icon.CompressFile + icon.Space + fmt.Sprintf("%d entries, %s", summary.EntryCount,
+		common.FormatFileSize(summary.UncompressedSize))
+	if ratio, ok := summary.Ratio(); ok {
+		info += fmt.Sprintf(", %.1f%% ratio", ratio*100) //nolint:mnd // Percentage
+	}
+	r.AddLines(common.FilePanelStyle.Render(info))
+	r.AddSection()
+	return renderDirEntries(r, files, previewHeight-archiveSummaryHeight)
+}
+
+func renderDirEntries(r *rendering.Renderer, files []os.DirEntry, previewHeight int) string {
 	if len(files) == 0 {
 		r.AddLines(common.FilePreviewEmptyText)
 		return r.Render()
@@ -160,7 +206,140 @@ func (m *Model) RenderTextWithDimension(text string, height int, width int) stri
 		Render() + clearCmd
 }
 
+// RenderDiff renders the output of `git diff` in place of the preview of an item
+func (m *Model) RenderDiff(diff string, previewWidth int, previewHeight int) string {
+	r := ui.FilePreviewPanelRenderer(previewHeight, previewWidth)
+	clearCmd := m.imagePreviewer.ClearKittyImages()
+	if strings.TrimSpace(diff) == "" {
+		return r.AddLines(common.FilePreviewNoGitChangesText).Render() + clearCmd
+	}
+
+	contentHeight := previewHeight
+	if common.Config.EnableFilePreviewBorder {
+		contentHeight = previewHeight - common.BorderPadding
+	}
+	// Lines that would not be displayed are not highlighted
+	lines := strings.Split(strings.ReplaceAll(diff, "\t", "    "), "\n")
+	diff = strings.Join(lines[:max(0, min(len(lines), contentHeight))], "\n")
+	background := ""
+	if !common.Config.TransparentBackground {
+		background = common.Theme.FilePanelBG
+	}
+	highlighted, err := ansichroma.HightlightString(diff, "diff", common.Theme.CodeSyntaxHighlightTheme, background)
+	if err != nil {
+		slog.Error("Error render diff highlight", "error", err)
+		highlighted = diff
+	}
+	return r.AddLines(highlighted).Render() + clearCmd
+}
+
+// RenderMatch renders the lines around a line of a file found by a content
+// search, with their numbers, in place of the preview of the file. The
+// matching line is marked with the cursor, and the span of the match found in
+// it by matchIndex is highlighted. matchIndex can be nil
+func (m *Model) RenderMatch(itemPath string, line int, matchIndex func(line string) []int,
+	previewWidth int, previewHeight int,
+) string {
+	r := ui.FilePreviewPanelRenderer(previewHeight, previewWidth)
+	clearCmd := m.imagePreviewer.ClearKittyImages()
+
+	contentWidth := previewWidth
+	contentHeight := previewHeight
+	if common.Config.EnableFilePreviewBorder {
+		contentWidth = previewWidth - common.BorderPadding
+		contentHeight = previewHeight - common.BorderPadding
+	}
+	// The matching line is in the middle, unless it is near the start
+	first := max(1, line-contentHeight/2)
+	numberWidth := len(strconv.Itoa(first + contentHeight - 1))
+	textWidth := max(0, contentWidth-numberWidth-matchGutterPadding)
+	rawLines, err := readLines(itemPath, first, contentHeight)
+	if err != nil {
+		slog.Error("Error reading lines of match preview", "error", err)
+		return r.AddLines(common.FilePreviewError).Render() + clearCmd
+	}
+	if len(rawLines) == 0 {
+		return r.AddLines(common.FilePreviewEmptyText).Render() + clearCmd
+	}
+	lines := make([]string, len(rawLines))
+	for i, raw := range rawLines {
+		lines[i] = ansi.Truncate(matchPreviewText(raw), textWidth, "")
+	}
+
+	if format := lexers.Match(filepath.Base(itemPath)); format != nil {
+		background := ""
+		if !common.Config.TransparentBackground {
+			background = common.Theme.FilePanelBG
+		}
+		highlighted, err := ansichroma.HightlightString(strings.Join(lines, "\n"), format.Config().Name,
+			common.Theme.CodeSyntaxHighlightTheme, background)
+		if err != nil {
+			slog.Error("Error render match highlight", "error", err)
+		} else if highlightedLines := strings.Split(highlighted, "\n"); len(highlightedLines) >= len(lines) {
+			lines = highlightedLines[:len(lines)]
+		}
+	}
+
+	for i, text := range lines {
+		number := first + i
+		marker := " "
+		if number == line {
+			marker = icon.Cursor
+			text = highlightMatch(text, rawLines[i], matchIndex)
+		}
+		r.AddLines(common.FilePanelCursorStyle.Render(marker+" ") +
+			common.FilePanelStyle.Render(fmt.Sprintf("%*d ", numberWidth, number)) + text)
+	}
+	return r.Render() + clearCmd
+}
+
+// Highlights the span of the match in text, the rendering of raw
+func highlightMatch(text string, raw string, matchIndex func(line string) []int) string {
+	if matchIndex == nil {
+		return text
+	}
+	span := matchIndex(raw)
+	if span == nil {
+		return text
+	}
+	// Offsets in raw are converted to cells of text
+	start := ansi.StringWidth(matchPreviewText(raw[:span[0]]))
+	end := min(ansi.StringWidth(matchPreviewText(raw[:span[1]])), ansi.StringWidth(text))
+	if start >= end {
+		return text
+	}
+	return lipgloss.StyleRanges(text, lipgloss.NewRange(start, end, common.FilePanelCursorStyle.Reverse(true)))
+}
+
+// Text of a line of the file shown around matches, with tabs expanded
+func matchPreviewText(line string) string {
+	return common.MakePrintable(strings.ReplaceAll(line, "\t", "    "))
+}
+
+// Returns count lines of the file at path starting at line first
+func readLines(path string, first int, count int) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxMatchPreviewLineSize)
+	for number := 1; scanner.Scan() && len(lines) < count; number++ {
+		if number < first {
+			continue
+		}
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
 func (m *Model) RenderWithPath(itemPath string, previewWidth int, previewHeight int, fullModelWidth int) string {
+	if archive.InArchive(itemPath) {
+		return m.renderArchiveEntryPreview(itemPath, previewWidth, previewHeight, fullModelWidth)
+	}
 	r := ui.FilePreviewPanelRenderer(previewHeight, previewWidth)
 	clearCmd := m.imagePreviewer.ClearKittyImages()
 
@@ -195,6 +374,10 @@ func (m *Model) RenderWithPath(itemPath string, previewWidth int, previewHeight
 		return renderDirectoryPreview(r, itemPath, contentHeight) + clearCmd
 	}
 
+	if archive.DetectFormat(itemPath).Browsable() {
+		return renderArchivePreview(r, itemPath, contentHeight) + clearCmd
+	}
+
 	if m.thumbnailGenerator != nil && m.thumbnailGenerator.SupportsExt(ext) {
 		thumbnailPath, err := m.thumbnailGenerator.GetThumbnailOrGenerate(itemPath)
 		if err != nil {
@@ -217,3 +400,36 @@ func (m *Model) RenderWithPath(itemPath string, previewWidth int, previewHeight
 
 	return m.renderTextPreview(r, itemPath, contentWidth, contentHeight) + clearCmd
 }
+
+func (m *Model) renderArchiveEntryPreview(itemPath string, previewWidth int, previewHeight int,
+	fullModelWidth int,
+) string {
+	r := ui.FilePreviewPanelRenderer(previewHeight, previewWidth)
+	clearCmd := m.imagePreviewer.ClearKittyImages()
+
+	fileInfo, err := archive.Lstat(itemPath)
+	if err != nil {
+		slog.Error("Error get archive entry info", "error", err)
+		return r.AddLines(common.FilePreviewNoFileInfoText).Render() + clearCmd
+	}
+	if fileInfo.IsDir() {
+		contentHeight := previewHeight
+		if common.Config.EnableFilePreviewBorder {
+			contentHeight = previewHeight - common.BorderPadding
+		}
+		return renderDirectoryPreview(r, itemPath, contentHeight) + clearCmd
+	}
+	if !fileInfo.Mode().IsRegular() {
+		return r.AddLines(common.FilePreviewUnsupportedFileMode).Render() + clearCmd
+	}
+	if fileInfo.Size() > maxArchiveEntryPreviewSize {
+		return r.AddLines(common.FilePreviewArchiveEntryTooLargeText).Render() + clearCmd
+	}
+
+	extractedPath, err := m.archiveCache.get(itemPath, fileInfo.Size())
+	if err != nil {
+		slog.Error("Error extracting archive entry for preview", "error", err)
+		return r.AddLines(common.FilePreviewError).Render() + clearCmd
+	}
+	return m.RenderWithPath(extractedPath, previewWidth, previewHeight, fullModelWidth)
+}
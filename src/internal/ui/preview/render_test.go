@@ -4,12 +4,18 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/pkg/utils"
 )
 
@@ -157,3 +163,90 @@ func TestFilePreviewRenderWithDimensions(t *testing.T) {
 		})
 	}
 }
+
+func TestArchivePreview(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "test.zip")
+	utils.SetupZip(t, archivePath, map[string]string{"dir/inner.txt": "content", "top.txt": "content"})
+
+	m := New()
+	defer m.CleanUp()
+	res := ansi.Strip(m.RenderWithPath(archivePath, 40, 6, 40))
+	lines := strings.Split(res, "\n")
+	require.Len(t, lines, 6)
+	assert.Contains(t, lines[0], "3 entries, 14.00 B")
+	assert.Contains(t, lines[0], "ratio")
+	assert.Contains(t, lines[2], "dir")
+	assert.Contains(t, lines[3], "top.txt")
+
+	res = ansi.Strip(m.RenderWithPath(filepath.Join(archivePath, "top.txt"), 10, 1, 10))
+	assert.Equal(t, "content   ", res, "Files inside archives should be previewed")
+
+	entryPath := filepath.Join(archivePath, "top.txt")
+	extractedPath, err := m.archiveCache.get(entryPath, int64(len("content")))
+	require.NoError(t, err)
+	require.Len(t, m.archiveCache.entries, 1, "Entries should be extracted once")
+
+	utils.SetupZip(t, archivePath, map[string]string{"dir/inner.txt": "changed content", "top.txt": "changed content"})
+	require.NoError(t, os.Chtimes(archivePath, time.Now(), time.Now().Add(time.Minute)))
+	res = ansi.Strip(m.RenderWithPath(entryPath, 10, 1, 10))
+	assert.Equal(t, "changed co", res, "Entries should be extracted again when the archive changes")
+	require.Len(t, m.archiveCache.entries, 1)
+	assert.NoFileExists(t, extractedPath, "Outdated entries should be removed")
+}
+
+func TestDiffPreview(t *testing.T) {
+	m := New()
+	diff := "diff --git a/a.txt b/a.txt\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -1 +1 @@\n" +
+		"-first\n" +
+		"+second\n"
+	lines := strings.Split(ansi.Strip(m.RenderDiff(diff, 20, 4)), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "diff --git a/a.txt b", lines[0])
+	assert.Equal(t, "@@ -1 +1 @@         ", lines[3])
+}
+
+func TestMatchPreview(t *testing.T) {
+	m := New()
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	content := ""
+	for i := 1; i <= 20; i++ {
+		content += "line " + strconv.Itoa(i) + "\n"
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	lines := strings.Split(ansi.Strip(m.RenderMatch(path, 10, nil, 20, 4)), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "   8 line 8         ", lines[0], "The matching line is in the middle")
+	assert.Equal(t, icon.Cursor+" 10 line 10        ", lines[2])
+
+	lines = strings.Split(ansi.Strip(m.RenderMatch(path, 1, nil, 20, 4)), "\n")
+	assert.Equal(t, icon.Cursor+" 1 line 1          ", lines[0])
+}
+
+func TestHighlightMatch(t *testing.T) {
+	prevProfile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prevProfile) })
+	highlighted := func(s string) string {
+		return common.FilePanelCursorStyle.Reverse(true).Render(s)
+	}
+	matchIndex := func(line string) []int {
+		if start := strings.Index(line, "bar"); start != -1 {
+			return []int{start, start + len("bar")}
+		}
+		return nil
+	}
+
+	raw := "\tfoo bar baz"
+	text := matchPreviewText(raw)
+	assert.Equal(t, "    foo "+highlighted("bar")+" baz", highlightMatch(text, raw, matchIndex),
+		"Offsets should account for expanded tabs")
+	assert.Equal(t, "    foo "+highlighted("ba"), highlightMatch(ansi.Truncate(text, 10, ""), raw, matchIndex),
+		"Cut matches are highlighted up to the end of the line")
+	assert.Equal(t, "    foo ", highlightMatch(ansi.Truncate(text, 8, ""), raw, matchIndex))
+	assert.Equal(t, "none", highlightMatch("none", "none", matchIndex))
+	assert.Equal(t, text, highlightMatch(text, raw, nil))
+}
@@ -1,5 +1,7 @@
 package processbar
 
+import "time"
+
 const (
 	// Min width and height for borders
 	minHeight = 2
@@ -21,4 +23,19 @@ const (
 
 	// linesPerProcess is the number of lines needed to render one process
 	linesPerProcess = 3
+
+	// Count of entries kept in the history file
+	maxHistoryEntries = 1000
+	// Extra entries allowed in the history file before trimming it
+	historyTrimSlack = 100
+	// Durations under a minute are displayed with this precision
+	durationRoundingShort = 100 * time.Millisecond
+
+	// Count of failed files kept for a process. Only their count is kept beyond that
+	maxRecordedFailures = 1000
+
+	// Lines of output kept for a shell command, older lines are dropped
+	maxOutputLines = 10000
+	// Longer lines are cut, so that output without newlines stays bounded
+	maxOutputLineLength = 4096
 )
@@ -22,3 +22,11 @@ type ProcessAlreadyExistsError struct {
 func (p *ProcessAlreadyExistsError) Error() string {
 	return "process already exists with id : " + p.id
 }
+
+type ProcessNotRunningError struct {
+	id string
+}
+
+func (p *ProcessNotRunningError) Error() string {
+	return "process is not running, id : " + p.id
+}
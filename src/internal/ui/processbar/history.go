@@ -0,0 +1,248 @@
+package processbar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// HistoryEntry is a finished process, as saved in the history file
+type HistoryEntry struct {
+	Operation string    `json:"operation"`
+	Name      string    `json:"name"`
+	State     string    `json:"state"`
+	Files     int       `json:"files"`
+	Size      int64     `json:"size,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Error     string    `json:"error,omitempty"`
+}
+
+func newHistoryEntry(p Process) HistoryEntry {
+	entry := HistoryEntry{
+		Operation: p.Operation.String(),
+		Name:      p.Name,
+		State:     p.State.String(),
+		Files:     p.Total,
+		Size:      p.Size,
+		StartTime: p.StartTime,
+		EndTime:   p.DoneTime,
+	}
+	if p.State == Failed || p.State == Cancelled {
+		entry.Error = p.ErrorMsg
+	}
+	if entry.EndTime.IsZero() {
+		entry.EndTime = time.Now()
+	}
+	return entry
+}
+
+func (e HistoryEntry) Duration() time.Duration {
+	return e.EndTime.Sub(e.StartTime)
+}
+
+// FormatDuration returns the duration rounded for display, like "1.5s" or "2m3s"
+func (e HistoryEntry) FormatDuration() string {
+	return FormatDuration(e.Duration())
+}
+
+// FormatDuration rounds d for display, with less precision for longer durations
+func FormatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	if d < time.Minute {
+		return d.Round(durationRoundingShort).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// Details returns the count of files, size and duration, like "3 files, 1.2 MiB, 1.5s".
+// Size is left out if unknown, and only the duration is given for shell commands
+func (e HistoryEntry) Details() string {
+	// Shell commands don't work on files
+	if e.Operation == OpShellCommand.String() {
+		return e.FormatDuration()
+	}
+	details := fmt.Sprintf("%d files", e.Files)
+	if e.Files == 1 {
+		details = "1 file"
+	}
+	if e.Size > 0 {
+		details += ", " + common.FormatFileSize(e.Size)
+	}
+	return details + ", " + e.FormatDuration()
+}
+
+// StateIcon returns the icon of the state the process finished with
+func (e HistoryEntry) StateIcon() string {
+	for _, state := range []ProcessState{Successful, Cancelled, Failed} {
+		if state.String() == e.State {
+			return state.Icon()
+		}
+	}
+	return InOperation.Icon()
+}
+
+// History saves finished processes to a file, one JSON object per line.
+// Only the most recent entries are kept.
+type History struct {
+	path string
+
+	mu sync.Mutex
+	// Count of entries in the file, -1 until the file has been read
+	count int
+
+	pendingMu sync.Mutex
+	// Processes waiting to be saved by RecordInBackground, oldest first
+	pending []Process
+	// Set while a goroutine is saving the pending processes
+	saving bool
+	// Done once the goroutine saving the pending processes ends
+	savingWg sync.WaitGroup
+}
+
+func NewHistory(path string) *History {
+	return &History{path: path, count: -1}
+}
+
+// Record appends the finished process p to the history file
+func (h *History) Record(p Process) error {
+	data, err := json.Marshal(newHistoryEntry(p))
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count < 0 {
+		entries, err := ReadHistory(h.path)
+		if err != nil {
+			return err
+		}
+		h.count = len(entries)
+	}
+	file, err := os.OpenFile(h.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, utils.LogFilePerm)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	h.count++
+	// Trimming rewrites the whole file, so it is done only once in a while
+	if h.count > maxHistoryEntries+historyTrimSlack {
+		return h.trim()
+	}
+	return nil
+}
+
+// RecordInBackground appends p to the history file like Record, without
+// waiting for the file to be written. Processes are saved in call order
+func (h *History) RecordInBackground(p Process) {
+	h.pendingMu.Lock()
+	defer h.pendingMu.Unlock()
+	h.pending = append(h.pending, p)
+	if !h.saving {
+		h.saving = true
+		h.savingWg.Add(1)
+		go h.savePending()
+	}
+}
+
+// Flush waits until the processes given to RecordInBackground are saved
+func (h *History) Flush() {
+	h.savingWg.Wait()
+}
+
+func (h *History) savePending() {
+	defer h.savingWg.Done()
+	for {
+		h.pendingMu.Lock()
+		if len(h.pending) == 0 {
+			h.saving = false
+			h.pendingMu.Unlock()
+			return
+		}
+		p := h.pending[0]
+		h.pending = h.pending[1:]
+		h.pendingMu.Unlock()
+
+		if err := h.Record(p); err != nil {
+			slog.Error("Error while saving process to history", "id", p.ID, "error", err)
+		}
+	}
+}
+
+// trim keeps only the most recent maxHistoryEntries entries
+func (h *History) trim() error {
+	entries, err := ReadHistory(h.path)
+	if err != nil {
+		return err
+	}
+	entries = entries[max(0, len(entries)-maxHistoryEntries):]
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(data, '\n'))
+	}
+	temp := h.path + ".tmp"
+	if err = os.WriteFile(temp, buf.Bytes(), utils.LogFilePerm); err != nil {
+		return err
+	}
+	if err = os.Rename(temp, h.path); err != nil {
+		return errors.Join(err, os.Remove(temp))
+	}
+	h.count = len(entries)
+	return nil
+}
+
+// ReadHistory returns the entries of the history file at path, oldest first.
+// A missing file has no entries. Lines that can't be parsed are skipped.
+func ReadHistory(path string) ([]HistoryEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			slog.Warn("Skipping invalid process history entry", "file", filepath.Base(path),
+				"line", lineNum, "error", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("error while reading process history : %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,78 @@
+package processbar
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryTrim(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history.jsonl")
+	h := NewHistory(historyFile)
+	for i := range maxHistoryEntries + historyTrimSlack + 1 {
+		p := NewProcess(strconv.Itoa(i), "file"+strconv.Itoa(i), OpCopy, 1)
+		p.State = Successful
+		require.NoError(t, h.Record(p))
+	}
+	entries, err := ReadHistory(historyFile)
+	require.NoError(t, err)
+	require.Len(t, entries, maxHistoryEntries)
+	assert.Equal(t, "file"+strconv.Itoa(maxHistoryEntries+historyTrimSlack), entries[len(entries)-1].Name,
+		"Most recent entries should be kept")
+}
+
+func TestHistoryRecordInBackground(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history.jsonl")
+	h := NewHistory(historyFile)
+	count := 50
+	for i := range count {
+		h.RecordInBackground(NewProcess(strconv.Itoa(i), "file"+strconv.Itoa(i), OpCopy, 1))
+	}
+	h.Flush()
+	entries, err := ReadHistory(historyFile)
+	require.NoError(t, err)
+	require.Len(t, entries, count, "Every process should be saved once flushed")
+	for i, entry := range entries {
+		assert.Equal(t, "file"+strconv.Itoa(i), entry.Name, "Processes should be saved in call order")
+	}
+	// Nothing to wait for
+	h.Flush()
+}
+
+func TestReadHistory(t *testing.T) {
+	dir := t.TempDir()
+	entries, err := ReadHistory(filepath.Join(dir, "missing.jsonl"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	historyFile := filepath.Join(dir, "history.jsonl")
+	require.NoError(t, os.WriteFile(historyFile, []byte(
+		`{"operation":"copy","name":"a","state":"successful","files":1}`+"\nnot json\n\n"+
+			`{"operation":"delete","name":"b","state":"failed","files":2,"error":"denied"}`+"\n"), 0o600))
+	entries, err = ReadHistory(historyFile)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].Name)
+	assert.Equal(t, "denied", entries[1].Error)
+}
+
+func TestHistoryEntryFormatDuration(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	testdata := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{1234567 * time.Microsecond, "1.2s"},
+		{2*time.Minute + 3400*time.Millisecond, "2m3s"},
+		{12345 * time.Microsecond, "12ms"},
+	}
+	for _, tt := range testdata {
+		entry := HistoryEntry{StartTime: start, EndTime: start.Add(tt.duration)}
+		assert.Equal(t, tt.expected, entry.FormatDuration())
+	}
+}
@@ -3,6 +3,8 @@ package processbar
 import (
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/yorukot/superfile/src/internal/common"
 	"github.com/yorukot/superfile/src/internal/ui"
@@ -17,12 +19,24 @@ type Model struct {
 	height int
 	width  int
 
-	// TODO: Fix this. No mechanism to remove completed processes from memory
-	// processes map grows indefinitely
-	// Maybe, TTL or cleanup mechanism for successful/failed processes
+	// Finished processes are removed as per retention
 	processes map[string]Process
 	msgChan   chan UpdateMsg
 	reqCnt    int
+
+	retention Retention
+	// nil if finished processes are not saved
+	history *History
+}
+
+// Retention limits the finished processes kept in the process bar
+type Retention struct {
+	// Maximum count of finished processes, the oldest ones are removed first.
+	// 0 keeps all of them
+	MaxFinished int
+	// Finished processes are removed once they are finished for this long.
+	// 0 keeps them forever
+	TTL time.Duration
 }
 
 func New() Model {
@@ -56,6 +70,24 @@ func (m *Model) SetDimensions(width int, height int) {
 	m.height = height
 }
 
+func (m *Model) SetRetention(retention Retention) {
+	m.retention = retention
+	m.applyRetention(time.Now())
+}
+
+// SetHistory makes the model save every process that finishes to history
+func (m *Model) SetHistory(history *History) {
+	m.history = history
+}
+
+// FlushHistory waits until the finished processes are saved to history. It
+// must be called before quitting, as they are saved in background
+func (m *Model) FlushHistory() {
+	if m.history != nil {
+		m.history.Flush()
+	}
+}
+
 func (m *Model) AddProcess(p Process) error {
 	if _, ok := m.processes[p.ID]; ok {
 		return &ProcessAlreadyExistsError{id: p.ID}
@@ -64,18 +96,95 @@ func (m *Model) AddProcess(p Process) error {
 	return nil
 }
 
+// StartProcess adds a new process right away, for operations started from the
+// update loop that need their process before it runs
+func (m *Model) StartProcess(currentFile string, operation OperationType, total int) (Process, error) {
+	p := NewProcess(m.newUUIDForProcess(), currentFile, operation, total)
+	return p, m.AddProcess(p)
+}
+
 func (m *Model) AddOrUpdateProcess(p Process) {
 	m.processes[p.ID] = p
 }
 
 func (m *Model) UpdateExistingProcess(p Process) error {
-	if _, ok := m.processes[p.ID]; !ok {
+	old, ok := m.processes[p.ID]
+	if !ok {
 		return &NoProcessFoundError{id: p.ID}
 	}
+	if p.Finished() && p.DoneTime.IsZero() {
+		p.DoneTime = time.Now()
+	}
 	m.processes[p.ID] = p
+	if !old.Finished() && p.Finished() {
+		m.onProcessFinished(p)
+	}
 	return nil
 }
 
+func (m *Model) onProcessFinished(p Process) {
+	// The history file is read and written outside of the update loop
+	if m.history != nil {
+		m.history.RecordInBackground(p)
+	}
+	m.applyRetention(time.Now())
+}
+
+// ClearFinished removes all finished processes, and returns their count
+func (m *Model) ClearFinished() int {
+	return m.removeProcesses(func(p Process) bool {
+		return p.Finished()
+	})
+}
+
+// RemoveExpiredProcesses removes finished processes older than the retention TTL
+func (m *Model) RemoveExpiredProcesses(now time.Time) {
+	if m.retention.TTL <= 0 {
+		return
+	}
+	m.removeProcesses(func(p Process) bool {
+		return p.Finished() && now.Sub(p.DoneTime) >= m.retention.TTL
+	})
+}
+
+func (m *Model) applyRetention(now time.Time) {
+	m.RemoveExpiredProcesses(now)
+	if m.retention.MaxFinished <= 0 {
+		return
+	}
+	var finished []Process
+	for _, p := range m.processes {
+		if p.Finished() {
+			finished = append(finished, p)
+		}
+	}
+	if len(finished) <= m.retention.MaxFinished {
+		return
+	}
+	// Most recently finished first
+	slices.SortFunc(finished, func(a, b Process) int {
+		return b.DoneTime.Compare(a.DoneTime)
+	})
+	for _, p := range finished[m.retention.MaxFinished:] {
+		delete(m.processes, p.ID)
+	}
+	m.fixCursor()
+}
+
+func (m *Model) removeProcesses(remove func(p Process) bool) int {
+	removed := 0
+	for id, p := range m.processes {
+		if remove(p) {
+			delete(m.processes, id)
+			removed++
+		}
+	}
+	if removed > 0 {
+		m.fixCursor()
+	}
+	return removed
+}
+
 func (m *Model) GetByID(id string) (Process, bool) {
 	p, ok := m.processes[id]
 	return p, ok
@@ -90,6 +199,28 @@ func (m *Model) HasRunningProcesses() bool {
 	return false
 }
 
+// SelectedProcess returns the process under the cursor
+func (m *Model) SelectedProcess() (Process, bool) {
+	processes := m.getSortedProcesses()
+	if m.cursor < 0 || m.cursor >= len(processes) {
+		return Process{}, false
+	}
+	return processes[m.cursor], true
+}
+
+// CancelSelectedProcess requests cancellation of the process under the cursor
+func (m *Model) CancelSelectedProcess() error {
+	p, ok := m.SelectedProcess()
+	if !ok {
+		return &NoProcessFoundError{id: ""}
+	}
+	if p.State != InOperation {
+		return &ProcessNotRunningError{id: p.ID}
+	}
+	p.RequestCancel()
+	return nil
+}
+
 func (m *Model) Render(processBarFocused bool) string {
 	r := ui.ProcessBarRenderer(m.height, m.width, processBarFocused)
 	if !m.isValid() {
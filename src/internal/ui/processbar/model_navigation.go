@@ -42,6 +42,14 @@ func (m *Model) ListDown() {
 	}
 }
 
+// fixCursor keeps the cursor and render index valid after processes are removed
+func (m *Model) fixCursor() {
+	cntP := m.cntProcesses()
+	m.cursor = max(0, min(m.cursor, cntP-1))
+	m.renderIndex = min(m.renderIndex, m.cursor)
+	m.renderIndex = max(m.renderIndex, m.cursor-m.cntRenderableProcess()+1)
+}
+
 func (m *Model) cntRenderableProcess() int {
 	footerHeight := m.height - common.BorderPadding
 	return cntRenderableProcess(footerHeight)
@@ -4,7 +4,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -104,3 +106,97 @@ func TestModelSetDimenstions(t *testing.T) {
 	assert.Equal(t, minHeight, m.height, "Min value should be set")
 	assert.Equal(t, minWidth+1, m.width, "Given value should be set")
 }
+
+func TestCancelSelectedProcess(t *testing.T) {
+	m := New()
+	require.Error(t, m.CancelSelectedProcess(), "Nothing to cancel without processes")
+
+	p := NewProcess("1", "test", OpExtract, 10)
+	require.NoError(t, m.AddProcess(p))
+	require.NoError(t, m.CancelSelectedProcess())
+	assert.True(t, p.CancelRequested(), "Copy held by the operation should see the request")
+
+	p.State = Cancelled
+	m.AddOrUpdateProcess(p)
+	require.Error(t, m.CancelSelectedProcess(), "Only running process can be cancelled")
+}
+
+func TestRetention(t *testing.T) {
+	now := time.Now()
+	finish := func(m *Model, id string, doneTime time.Time) {
+		t.Helper()
+		p, ok := m.GetByID(id)
+		require.True(t, ok)
+		p.State = Successful
+		p.DoneTime = doneTime
+		require.NoError(t, m.UpdateExistingProcess(p))
+	}
+	newModel := func(retention Retention) *Model {
+		m := NewModelWithOptions(20, 10)
+		m.SetRetention(retention)
+		for _, id := range []string{"1", "2", "3", "running"} {
+			require.NoError(t, m.AddProcess(NewProcess(id, "test", OpCopy, 1)))
+		}
+		return &m
+	}
+
+	t.Run("Max finished count", func(t *testing.T) {
+		m := newModel(Retention{MaxFinished: 2})
+		finish(m, "1", now.Add(-3*time.Minute))
+		finish(m, "2", now.Add(-2*time.Minute))
+		finish(m, "3", now.Add(-time.Minute))
+		assert.Equal(t, 3, m.cntProcesses())
+		_, ok := m.GetByID("1")
+		assert.False(t, ok, "Oldest finished process should be removed")
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		m := newModel(Retention{TTL: time.Hour})
+		finish(m, "1", now.Add(-2*time.Hour))
+		finish(m, "2", now.Add(-time.Minute))
+		assert.Equal(t, 3, m.cntProcesses())
+		m.RemoveExpiredProcesses(now.Add(time.Hour))
+		assert.Equal(t, 2, m.cntProcesses())
+		_, ok := m.GetByID("running")
+		assert.True(t, ok, "Running processes are never removed")
+	})
+
+	t.Run("Clear finished", func(t *testing.T) {
+		m := newModel(Retention{})
+		finish(m, "1", now)
+		finish(m, "2", now)
+		m.ListUp()
+		assert.Equal(t, 3, m.cursor)
+		assert.Equal(t, 2, m.ClearFinished())
+		assert.Equal(t, 2, m.cntProcesses())
+		assert.Equal(t, 1, m.cursor, "Cursor should stay on a process")
+		assert.True(t, m.isValid())
+	})
+}
+
+func TestProcessHistoryRecording(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history.jsonl")
+	m := New()
+	m.SetHistory(NewHistory(historyFile))
+	p := NewProcess("1", "file.txt", OpCompress, 3)
+	require.NoError(t, m.AddProcess(p))
+	p.CurrentFile = "other.txt"
+	p.Done = 2
+	require.NoError(t, m.UpdateExistingProcess(p))
+	p.State = Failed
+	p.ErrorMsg = "disk full"
+	require.NoError(t, m.UpdateExistingProcess(p))
+	// Updates after finishing are not recorded again
+	require.NoError(t, m.UpdateExistingProcess(p))
+
+	m.FlushHistory()
+	entries, err := ReadHistory(historyFile)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "Process should be saved once flushed")
+	assert.Equal(t, "compress", entries[0].Operation)
+	assert.Equal(t, "file.txt", entries[0].Name)
+	assert.Equal(t, "failed", entries[0].State)
+	assert.Equal(t, "disk full", entries[0].Error)
+	assert.Equal(t, 3, entries[0].Files)
+	assert.False(t, entries[0].EndTime.Before(entries[0].StartTime))
+}
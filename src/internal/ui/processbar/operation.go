@@ -10,8 +10,46 @@ const (
 	OpDelete
 	OpCompress
 	OpExtract
+	OpAddToArchive
+	OpTestArchive
+	OpShellCommand
+	OpGitStage
+	OpGitUnstage
+	OpGitDiscard
+	OpGitCommit
 )
 
+func (op OperationType) String() string {
+	switch op {
+	case OpCopy:
+		return "copy"
+	case OpCut:
+		return "move"
+	case OpDelete:
+		return "delete"
+	case OpCompress:
+		return "compress"
+	case OpExtract:
+		return "extract"
+	case OpAddToArchive:
+		return "add to archive"
+	case OpTestArchive:
+		return "test archive"
+	case OpShellCommand:
+		return "shell command"
+	case OpGitStage:
+		return "git stage"
+	case OpGitUnstage:
+		return "git unstage"
+	case OpGitDiscard:
+		return "git discard"
+	case OpGitCommit:
+		return "git commit"
+	default:
+		return "unknown"
+	}
+}
+
 // GetIcon returns the appropriate icon for the operation type
 func (op OperationType) GetIcon() string {
 	switch op {
@@ -25,6 +63,14 @@ func (op OperationType) GetIcon() string {
 		return icon.CompressFile
 	case OpExtract:
 		return icon.ExtractFile
+	case OpAddToArchive:
+		return icon.CompressFile
+	case OpTestArchive:
+		return icon.Search
+	case OpShellCommand:
+		return icon.Terminal
+	case OpGitStage, OpGitUnstage, OpGitDiscard, OpGitCommit:
+		return icon.GitBranch
 	default:
 		return icon.InOperation
 	}
@@ -43,6 +89,20 @@ func (op OperationType) GetVerb() string {
 		return "Compressing"
 	case OpExtract:
 		return "Extracting"
+	case OpAddToArchive:
+		return "Adding"
+	case OpTestArchive:
+		return "Testing"
+	case OpShellCommand:
+		return "Running"
+	case OpGitStage:
+		return "Staging"
+	case OpGitUnstage:
+		return "Unstaging"
+	case OpGitDiscard:
+		return "Discarding"
+	case OpGitCommit:
+		return "Committing"
 	default:
 		return "Processing"
 	}
@@ -61,6 +121,20 @@ func (op OperationType) GetPastVerb() string {
 		return "Compressed"
 	case OpExtract:
 		return "Extracted"
+	case OpAddToArchive:
+		return "Added"
+	case OpTestArchive:
+		return "Tested"
+	case OpShellCommand:
+		return "Ran"
+	case OpGitStage:
+		return "Staged"
+	case OpGitUnstage:
+		return "Unstaged"
+	case OpGitDiscard:
+		return "Discarded"
+	case OpGitCommit:
+		return "Committed"
 	default:
 		return "Processed"
 	}
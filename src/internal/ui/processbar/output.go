@@ -0,0 +1,102 @@
+package processbar
+
+import (
+	"strings"
+	"sync"
+)
+
+// Output collects the output of a shell command, line by line. It is shared
+// by all copies of the process, and written by the goroutine running the command.
+// A carriage return starts the line again, so progress lines, like the ones of
+// rsync, replace themselves
+type Output struct {
+	mu    sync.Mutex
+	lines []string
+	// Line being written, not ended by a newline yet
+	partial strings.Builder
+	// A carriage return was read. It starts the line again, unless a newline follows
+	pendingCR bool
+	dropped   int
+	// Incremented on each write, so that readers know when to refresh
+	version int
+}
+
+func NewOutput() *Output {
+	return &Output{}
+}
+
+func (o *Output) Write(data []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, b := range data {
+		if o.pendingCR && b != '\n' {
+			o.partial.Reset()
+		}
+		o.pendingCR = false
+		switch b {
+		case '\n':
+			o.endLine()
+		case '\r':
+			o.pendingCR = true
+		default:
+			if o.partial.Len() < maxOutputLineLength {
+				o.partial.WriteByte(b)
+			}
+		}
+	}
+	o.version++
+	return len(data), nil
+}
+
+func (o *Output) endLine() {
+	o.lines = append(o.lines, o.partial.String())
+	o.partial.Reset()
+	if len(o.lines) > maxOutputLines {
+		// Drop in batches, so that lines are not moved on every write
+		drop := len(o.lines) - maxOutputLines + maxOutputLines/10
+		o.lines = append([]string(nil), o.lines[drop:]...)
+		o.dropped += drop
+	}
+}
+
+// Len returns the count of lines, including the one being written
+func (o *Output) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.partial.Len() > 0 {
+		return len(o.lines) + 1
+	}
+	return len(o.lines)
+}
+
+// Lines returns a copy of the lines in [start, end), clamped to the existing lines
+func (o *Output) Lines(start int, end int) []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	all := o.lines
+	if o.partial.Len() > 0 {
+		all = append(all[:len(all):len(all)], o.partial.String())
+	}
+	start = max(0, min(start, len(all)))
+	end = max(start, min(end, len(all)))
+	return append([]string(nil), all[start:end]...)
+}
+
+// Dropped returns the count of oldest lines that were dropped
+func (o *Output) Dropped() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.dropped
+}
+
+// Version changes whenever output is written
+func (o *Output) Version() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.version
+}
+
+// String returns all kept lines
+func (o *Output) String() string {
+	return strings.Join(o.Lines(0, o.Len()), "\n")
+}
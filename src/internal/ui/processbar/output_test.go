@@ -0,0 +1,53 @@
+package processbar
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput(t *testing.T) {
+	t.Run("Lines and partial line", func(t *testing.T) {
+		o := NewOutput()
+		_, err := o.Write([]byte("first\nsec"))
+		require.NoError(t, err)
+		_, _ = o.Write([]byte("ond\nthi"))
+		assert.Equal(t, 3, o.Len())
+		assert.Equal(t, []string{"second", "thi"}, o.Lines(1, 10))
+		assert.Empty(t, o.Lines(5, 10))
+		assert.Equal(t, "first\nsecond\nthi", o.String())
+	})
+
+	t.Run("Carriage return starts the line again", func(t *testing.T) {
+		o := NewOutput()
+		_, _ = o.Write([]byte("10%\r"))
+		_, _ = o.Write([]byte("50%\r100%\r\ndone\r\n"))
+		assert.Equal(t, "100%\ndone", o.String())
+	})
+
+	t.Run("Oldest lines are dropped", func(t *testing.T) {
+		o := NewOutput()
+		for i := range maxOutputLines + 1 {
+			_, _ = fmt.Fprintf(o, "line %d\n", i)
+		}
+		assert.Positive(t, o.Dropped())
+		assert.Equal(t, maxOutputLines+1, o.Len()+o.Dropped())
+		assert.Equal(t, []string{fmt.Sprintf("line %d", maxOutputLines)}, o.Lines(o.Len()-1, o.Len()))
+	})
+
+	t.Run("Long lines are cut", func(t *testing.T) {
+		o := NewOutput()
+		_, _ = o.Write([]byte(strings.Repeat("a", 2*maxOutputLineLength) + "\n"))
+		assert.Len(t, o.String(), maxOutputLineLength)
+	})
+
+	t.Run("Version changes on write", func(t *testing.T) {
+		o := NewOutput()
+		version := o.Version()
+		_, _ = o.Write([]byte("a"))
+		assert.NotEqual(t, version, o.Version())
+	})
+}
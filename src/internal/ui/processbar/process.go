@@ -2,6 +2,8 @@ package processbar
 
 import (
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
@@ -13,7 +15,10 @@ import (
 // Model for an individual process
 // Note : Its size is ~ 800 bytes
 type Process struct {
-	ID          string
+	ID string
+	// What the process works on, like the first file being copied. Unlike
+	// CurrentFile, it doesn't change while the process runs
+	Name        string
 	CurrentFile string
 	// TODO : We always want ErrorMsg to be set when State is
 	// moved to Cancelled or Failed. To ensure it, we need to only allow state
@@ -24,21 +29,110 @@ type Process struct {
 	State     ProcessState
 	Total     int
 	Done      int
+	// Total size in bytes of the files the process works on, 0 if unknown
+	Size      int64
+	StartTime time.Time
 	DoneTime  time.Time
+	// Files the process failed on. Only the first maxRecordedFailures are kept,
+	// FailedCount counts all of them
+	Failures    []FileError
+	FailedCount int
+	// Output of shell commands, nil for other operations
+	Output *Output
+	// Exit code of shell commands, -1 if the command could not run till its end
+	ExitCode int
+	// Shared by all copies of the process, so that the goroutine running the
+	// operation sees a cancel request made from the process bar
+	cancelRequested *atomic.Bool
 }
 
 func NewProcess(id string, currentFile string, operation OperationType, total int) Process {
 	prog := progress.New(common.GenerateGradientColor())
 	prog.PercentageStyle = common.FooterStyle
-	return Process{
+	p := Process{
 		ID:          id,
+		Name:        currentFile,
 		CurrentFile: currentFile,
 		Operation:   operation,
 		Progress:    prog,
 		State:       InOperation,
 		Total:       total,
 		Done:        0,
+		StartTime:   time.Now(),
+
+		cancelRequested: &atomic.Bool{},
+	}
+	if operation == OpShellCommand {
+		p.Output = NewOutput()
+	}
+	return p
+}
+
+// RequestCancel asks the operation running this process to stop. Operations
+// check it with CancelRequested and move the process to Cancelled.
+func (p *Process) RequestCancel() {
+	if p.cancelRequested != nil {
+		p.cancelRequested.Store(true)
+	}
+}
+
+func (p *Process) CancelRequested() bool {
+	return p.cancelRequested != nil && p.cancelRequested.Load()
+}
+
+// Finished reports whether the process is not running anymore, whatever the outcome
+func (p *Process) Finished() bool {
+	return p.State != InOperation
+}
+
+// Duration of the process, up to now while it is running
+func (p *Process) Duration() time.Duration {
+	if p.DoneTime.IsZero() {
+		return time.Since(p.StartTime)
+	}
+	return p.DoneTime.Sub(p.StartTime)
+}
+
+// FileError is a file or directory a process failed on
+type FileError struct {
+	Src string
+	// Where Src was going to, empty for operations without a destination
+	Dst string
+	Err string
+}
+
+// AddFailure records that the process failed on src
+func (p *Process) AddFailure(src string, dst string, err error) {
+	p.FailedCount++
+	if len(p.Failures) < maxRecordedFailures {
+		p.Failures = append(p.Failures, FileError{Src: src, Dst: dst, Err: err.Error()})
+	}
+}
+
+// FailureSummary returns the error to show for a process with failures, like
+// "3 items failed"
+func (p *Process) FailureSummary() string {
+	if p.FailedCount == 1 && len(p.Failures) == 1 {
+		return p.Failures[0].Err
 	}
+	return fmt.Sprintf("%d items failed", p.FailedCount)
+}
+
+// ErrorReport lists the recorded failures, one per line
+func (p *Process) ErrorReport() string {
+	var sb strings.Builder
+	for _, failure := range p.Failures {
+		fmt.Fprintf(&sb, "%s : %s\n", failure.Src, failure.Err)
+	}
+	if p.FailedCount > len(p.Failures) {
+		fmt.Fprintf(&sb, "... and %d more\n", p.FailedCount-len(p.Failures))
+	}
+	return sb.String()
+}
+
+// CanRetry reports whether the items the process failed on can be pasted again
+func (p *Process) CanRetry() bool {
+	return p.Finished() && len(p.Failures) > 0 && (p.Operation == OpCopy || p.Operation == OpCut)
 }
 
 type ProcessState int
@@ -50,6 +144,21 @@ const (
 	Failed
 )
 
+func (p ProcessState) String() string {
+	switch p {
+	case InOperation:
+		return "in operation"
+	case Successful:
+		return "successful"
+	case Cancelled:
+		return "cancelled"
+	case Failed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
 // TODO : Should we store in a global map for efficiency ? At least need to prerender
 // Yes, this is a Render() call, which is expensive
 func (p ProcessState) Icon() string {
@@ -73,6 +182,10 @@ func (p *Process) GetDisplayName() string {
 }
 
 func (p *Process) displayNameWithoutIcon() string {
+	// The command tells more than the verb
+	if p.Operation == OpShellCommand && (p.State == Cancelled || p.State == Failed) {
+		return p.Name + " : " + p.ErrorMsg
+	}
 	if p.State == Cancelled {
 		return p.Operation.GetVerb() + " cancelled : " + p.ErrorMsg
 	}
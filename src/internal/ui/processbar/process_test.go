@@ -1,6 +1,8 @@
 package processbar
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -94,3 +96,33 @@ func TestGetDisplayName(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessFailures(t *testing.T) {
+	t.Run("Single failure", func(t *testing.T) {
+		p := NewProcess("1", "dir", OpCopy, 3)
+		p.AddFailure("/src/dir/a.txt", "/dst/dir/a.txt", errors.New("permission denied"))
+		assert.Equal(t, "permission denied", p.FailureSummary())
+		assert.Equal(t, "/src/dir/a.txt : permission denied\n", p.ErrorReport())
+		assert.False(t, p.CanRetry(), "Running processes can't be retried")
+		p.State = Failed
+		assert.True(t, p.CanRetry())
+	})
+
+	t.Run("Failures over the limit are only counted", func(t *testing.T) {
+		p := NewProcess("1", "dir", OpCut, maxRecordedFailures+2)
+		for i := range maxRecordedFailures + 2 {
+			p.AddFailure(fmt.Sprintf("/src/%d", i), "", errors.New("failed"))
+		}
+		assert.Len(t, p.Failures, maxRecordedFailures)
+		assert.Equal(t, maxRecordedFailures+2, p.FailedCount)
+		assert.Equal(t, fmt.Sprintf("%d items failed", maxRecordedFailures+2), p.FailureSummary())
+		assert.Contains(t, p.ErrorReport(), "... and 2 more")
+	})
+
+	t.Run("Only copy and move can be retried", func(t *testing.T) {
+		p := NewProcess("1", "archive.zip", OpExtract, 1)
+		p.AddFailure("archive.zip", "", errors.New("failed"))
+		p.State = Failed
+		assert.False(t, p.CanRetry())
+	})
+}
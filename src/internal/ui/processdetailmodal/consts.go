@@ -0,0 +1,17 @@
+package processdetailmodal
+
+const (
+	detailHeadlineText = "Process details"
+	noFailuresText     = "No failed items"
+
+	// Lines for the process name, its state, and the title of the failures
+	headerLines = 3
+	// Line for the hints, or the result of the last action
+	footerLines = 1
+	// Each failure takes a line for the path, and one for the error
+	linesPerFailure = 2
+
+	// Largest dimensions, including borders
+	maxWidth  = 90
+	maxHeight = 30
+)
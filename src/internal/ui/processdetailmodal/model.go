@@ -0,0 +1,119 @@
+package processdetailmodal
+
+import (
+	"slices"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+func New() Model {
+	return Model{
+		width:  maxWidth,
+		height: maxHeight,
+	}
+}
+
+func (m *Model) Open(p processbar.Process) {
+	m.open = true
+	m.process = p
+	m.notice = ""
+	m.noticeIsError = false
+	m.cursor = 0
+	m.renderIndex = 0
+}
+
+func (m *Model) Close() {
+	m.open = false
+	m.process = processbar.Process{}
+}
+
+func (m *Model) IsOpen() bool {
+	return m.open
+}
+
+// ProcessID returns the ID of the process being shown
+func (m *Model) ProcessID() string {
+	return m.process.ID
+}
+
+// SetProcess updates the process being shown, as it can still be running
+func (m *Model) SetProcess(p processbar.Process) {
+	m.process = p
+	m.cursor = min(m.cursor, max(0, len(p.Failures)-1))
+	m.fixRenderIndex()
+}
+
+// SetNotice shows the result of the last action
+func (m *Model) SetNotice(notice string, isError bool) {
+	m.notice = notice
+	m.noticeIsError = isError
+}
+
+// SetMaxDimensions fits the modal into a screen of the given size
+func (m *Model) SetMaxDimensions(fullWidth int, fullHeight int) {
+	m.width = min(maxWidth, fullWidth-common.BorderPadding)
+	m.height = min(maxHeight, fullHeight-common.BorderPadding)
+	m.fixRenderIndex()
+}
+
+func (m *Model) GetWidth() int {
+	return m.width
+}
+
+func (m *Model) GetHeight() int {
+	return m.height
+}
+
+// HandleKey handles moving through the failures, and returns the action to
+// apply for the retry and copy hotkeys
+func (m *Model) HandleKey(msg string) common.ModelAction {
+	switch {
+	case slices.Contains(common.Hotkeys.ListUp, msg):
+		m.ListUp()
+	case slices.Contains(common.Hotkeys.ListDown, msg):
+		m.ListDown()
+	case slices.Contains(common.Hotkeys.Quit, msg),
+		slices.Contains(common.Hotkeys.CancelTyping, msg):
+		m.Close()
+	case slices.Contains(common.Hotkeys.RetryFailedItems, msg):
+		if !m.process.CanRetry() {
+			m.SetNotice("Only failed items of finished copy and move operations can be retried", true)
+			break
+		}
+		return common.RetryFailedAction{ProcessID: m.process.ID}
+	case slices.Contains(common.Hotkeys.CopyErrorList, msg):
+		if len(m.process.Failures) == 0 {
+			m.SetNotice("There are no errors to copy", true)
+			break
+		}
+		return common.CopyErrorListAction{ProcessID: m.process.ID}
+	}
+	return common.NoAction{}
+}
+
+func (m *Model) ListUp() {
+	if len(m.process.Failures) == 0 {
+		return
+	}
+	m.cursor = (m.cursor - 1 + len(m.process.Failures)) % len(m.process.Failures)
+	m.fixRenderIndex()
+}
+
+func (m *Model) ListDown() {
+	if len(m.process.Failures) == 0 {
+		return
+	}
+	m.cursor = (m.cursor + 1) % len(m.process.Failures)
+	m.fixRenderIndex()
+}
+
+func (m *Model) cntRenderableFailures() int {
+	return max(1, (m.height-common.BorderPadding-headerLines-footerLines)/linesPerFailure)
+}
+
+// fixRenderIndex keeps the cursor within the rendered failures
+func (m *Model) fixRenderIndex() {
+	m.renderIndex = min(m.renderIndex, m.cursor)
+	m.renderIndex = max(m.renderIndex, m.cursor-m.cntRenderableFailures()+1)
+}
@@ -0,0 +1,91 @@
+package processdetailmodal
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+func TestMain(m *testing.M) {
+	common.RunTestsWithConfig(m)
+}
+
+func failedProcess(op processbar.OperationType, failures int) processbar.Process {
+	p := processbar.NewProcess("id1", "photos", op, failures+1)
+	for i := range failures {
+		p.AddFailure(fmt.Sprintf("/src/photos/%d.jpg", i), fmt.Sprintf("/dst/photos/%d.jpg", i),
+			errors.New("permission denied"))
+	}
+	p.State = processbar.Failed
+	p.ErrorMsg = p.FailureSummary()
+	return p
+}
+
+func TestProcessDetailModal(t *testing.T) {
+	t.Run("Render failures", func(t *testing.T) {
+		m := New()
+		m.Open(failedProcess(processbar.OpCopy, 2))
+		require.True(t, m.IsOpen())
+		res := m.Render()
+		assert.Contains(t, res, "Failed items (2)")
+		assert.Contains(t, res, "/src/photos/1.jpg")
+		assert.Contains(t, res, "permission denied")
+	})
+
+	t.Run("Retry and copy actions", func(t *testing.T) {
+		m := New()
+		m.Open(failedProcess(processbar.OpCut, 1))
+		assert.Equal(t, common.RetryFailedAction{ProcessID: "id1"},
+			m.HandleKey(common.Hotkeys.RetryFailedItems[0]))
+		assert.Equal(t, common.CopyErrorListAction{ProcessID: "id1"},
+			m.HandleKey(common.Hotkeys.CopyErrorList[0]))
+	})
+
+	t.Run("Nothing to retry", func(t *testing.T) {
+		m := New()
+		m.Open(failedProcess(processbar.OpDelete, 1))
+		assert.Equal(t, common.NoAction{}, m.HandleKey(common.Hotkeys.RetryFailedItems[0]))
+		assert.NotEmpty(t, m.notice)
+
+		m.Open(processbar.NewProcess("id2", "file.txt", processbar.OpCopy, 1))
+		assert.Empty(t, m.notice, "Notice should be reset when opening")
+		assert.Equal(t, common.NoAction{}, m.HandleKey(common.Hotkeys.CopyErrorList[0]))
+		assert.Contains(t, m.Render(), noFailuresText)
+	})
+
+	t.Run("Scrolling keeps the cursor rendered", func(t *testing.T) {
+		m := New()
+		m.SetMaxDimensions(80, 12)
+		m.Open(failedProcess(processbar.OpCopy, 10))
+		renderable := m.cntRenderableFailures()
+		for range renderable {
+			m.ListDown()
+		}
+		assert.Equal(t, renderable, m.cursor)
+		assert.Equal(t, 1, m.renderIndex)
+		m.ListUp()
+		assert.Equal(t, renderable-1, m.cursor)
+		assert.Equal(t, 1, m.renderIndex, "Render index should stay while the cursor is visible")
+	})
+
+	t.Run("Updated process keeps the cursor valid", func(t *testing.T) {
+		m := New()
+		m.Open(failedProcess(processbar.OpCopy, 3))
+		m.ListUp()
+		m.SetProcess(failedProcess(processbar.OpCopy, 1))
+		assert.Equal(t, 0, m.cursor)
+	})
+
+	t.Run("Close with key", func(t *testing.T) {
+		m := New()
+		m.Open(failedProcess(processbar.OpCopy, 1))
+		assert.Equal(t, common.NoAction{}, m.HandleKey(common.Hotkeys.CancelTyping[0]))
+		assert.False(t, m.IsOpen())
+	})
+}
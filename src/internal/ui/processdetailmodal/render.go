@@ -0,0 +1,57 @@
+package processdetailmodal
+
+import (
+	"fmt"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui"
+)
+
+func (m *Model) Render() string {
+	r := ui.ProcessDetailRenderer(m.height, m.width)
+	r.SetBorderTitle(detailHeadlineText)
+	// Room left by the border, and the cursor
+	textWidth := m.width - common.BorderPadding - common.InnerPadding
+	p := m.process
+
+	title := p.Operation.String() + " " + p.Name
+	r.AddLines(" "+p.State.Icon()+" "+common.ModalStyle.Render(common.TruncateText(title, textWidth, "...")),
+		"   "+common.ModalStyle.Render(fmt.Sprintf("%s, %d/%d files done", p.State, p.Done, p.Total)))
+
+	if p.FailedCount == 0 {
+		r.AddLines(" " + common.ModalTitleStyle.Render(noFailuresText))
+	} else {
+		r.SetBorderInfoItems(fmt.Sprintf("%d/%d", m.cursor+1, len(p.Failures)))
+		r.AddLines(" " + common.ModalTitleStyle.Render(fmt.Sprintf("Failed items (%d)", p.FailedCount)))
+	}
+
+	end := min(len(p.Failures), m.renderIndex+m.cntRenderableFailures())
+	for i := m.renderIndex; i < end; i++ {
+		failure := p.Failures[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = common.FilePanelCursorStyle.Render(icon.Cursor + " ")
+		}
+		r.AddLines(cursor+common.ModalStyle.Render(common.TruncateMiddleText(failure.Src, textWidth, "...")),
+			"    "+common.ModalErrorStyle.Render(common.TruncateText(failure.Err, textWidth-common.InnerPadding, "...")))
+	}
+
+	r.AddLines(" " + m.footer())
+	return r.Render()
+}
+
+func (m *Model) footer() string {
+	if m.notice != "" {
+		if m.noticeIsError {
+			return common.ModalErrorStyle.Render(m.notice)
+		}
+		return common.ModalStyle.Render(m.notice)
+	}
+	if len(m.process.Failures) == 0 {
+		return ""
+	}
+	return common.ModalTitleStyle.Render(fmt.Sprintf("%s retry failed, %s copy errors",
+		common.GetHelpMenuHotkeyString(common.Hotkeys.RetryFailedItems),
+		common.GetHelpMenuHotkeyString(common.Hotkeys.CopyErrorList)))
+}
@@ -0,0 +1,25 @@
+package processdetailmodal
+
+import (
+	"github.com/yorukot/superfile/src/internal/ui/processbar"
+)
+
+// Modal showing a process, and every file it failed on. Failed items can be
+// retried, or their errors copied.
+// No need to name it as ProcessDetailModel. It will be imported as processdetailmodal.Model
+type Model struct {
+	open    bool
+	process processbar.Process
+
+	// Result of the last action, shown instead of the hints
+	notice        string
+	noticeIsError bool
+
+	// Cursor over the failures
+	cursor      int
+	renderIndex int
+
+	// Including borders
+	width  int
+	height int
+}
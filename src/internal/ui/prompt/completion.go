@@ -0,0 +1,243 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/yorukot/superfile/src/config/icon"
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// completion holds the candidates of the last tab completion. The popup stays
+// open, and tab cycles through the candidates, until any other key is pressed
+type completion struct {
+	// Input before the completed token
+	prefix     string
+	candidates []string
+	cursor     int
+}
+
+func (c *completion) active() bool {
+	return len(c.candidates) > 0
+}
+
+func (c *completion) reset() {
+	*c = completion{}
+}
+
+// executablesCache keeps the executables of $PATH, which takes a while to read
+type executablesCache struct {
+	// $PATH the names were read from, it is read again when it changes
+	path  string
+	names []string
+	read  bool
+}
+
+func (e *executablesCache) get() []string {
+	path := os.Getenv("PATH")
+	if !e.read || e.path != path {
+		*e = executablesCache{path: path, names: executablesInPath(path), read: true}
+	}
+	return e.names
+}
+
+func (e *executablesCache) reset() {
+	*e = executablesCache{}
+}
+
+// Complete the last token of the input, or cycle through the candidates
+// if the popup is already open
+func (m *Model) handleCompletion(cwdLocation string, forward bool) {
+	if m.completion.active() {
+		step := -1
+		if forward {
+			step = 1
+		}
+		m.completion.cursor = (m.completion.cursor + step + len(m.completion.candidates)) %
+			len(m.completion.candidates)
+	} else {
+		prefix, candidates := m.getCompletions(m.textInput.Value(), cwdLocation)
+		if len(candidates) == 0 {
+			return
+		}
+		m.completion = completion{prefix: prefix, candidates: candidates}
+		if !forward {
+			m.completion.cursor = len(candidates) - 1
+		}
+	}
+	m.textInput.SetValue(m.completion.prefix + quoteIfNeeded(m.completion.candidates[m.completion.cursor]))
+	m.textInput.CursorEnd()
+	// Nothing to cycle through. Next tab will complete the new input
+	if len(m.completion.candidates) == 1 {
+		m.completion.reset()
+	}
+}
+
+// getCompletions returns the input before the last token, and the ranked
+// candidates to replace that token with
+func (m *Model) getCompletions(value string, cwdLocation string) (string, []string) {
+	start := lastTokenStart(value)
+	prefix, token := value[:start], unquoteToken(value[start:])
+	isFirstToken := strings.TrimSpace(prefix) == ""
+
+	switch {
+	case !m.shellMode && isFirstToken:
+		names := make([]string, 0, len(m.commands))
+		for _, cmd := range m.commands {
+			names = append(names, cmd.command)
+		}
+		return prefix, rankCandidates(token, names)
+	case !m.shellMode:
+		command := getFirstToken(prefix)
+		idx := slices.IndexFunc(m.commands, func(cmd promptCommand) bool {
+			return cmd.command == command
+		})
+		if idx == -1 || !m.commands[idx].completeDirs {
+			return prefix, nil
+		}
+		return prefix, pathCandidates(token, cwdLocation, true)
+	case isFirstToken && !strings.ContainsAny(token, `/\`):
+		return prefix, rankCandidates(token, m.executables.get())
+	default:
+		return prefix, pathCandidates(token, cwdLocation, false)
+	}
+}
+
+// Index of the start of last token. Spaces inside quotes, or escaped, don't
+// split tokens
+func lastTokenStart(value string) int {
+	start := 0
+	// '\' is the path separator on windows
+	scanner := quoteScanner{literalBackslash: runtime.GOOS == utils.OsWindows}
+	for i, r := range value {
+		if scanner.next(r) == runeContent && scanner.isSeparator(r) {
+			start = i + 1
+		}
+	}
+	return start
+}
+
+func unquoteToken(token string) string {
+	if token == "" || (token[0] != '"' && token[0] != '\'') {
+		return token
+	}
+	return strings.TrimSuffix(token[1:], token[:1])
+}
+
+func quoteIfNeeded(candidate string) string {
+	switch {
+	case !strings.ContainsAny(candidate, " \t\"'"):
+		return candidate
+	case strings.ContainsRune(candidate, '"'):
+		return "'" + candidate + "'"
+	default:
+		return `"` + candidate + `"`
+	}
+}
+
+// Candidates are ranked with fzf, and kept in the given order for empty query
+func rankCandidates(query string, items []string) []string {
+	var result []string
+	if query == "" {
+		result = items
+	} else {
+		for _, match := range utils.FzfSearch(query, items) {
+			result = append(result, items[match.HayIndex])
+		}
+	}
+	return result[:min(len(result), maxCompletionCandidates)]
+}
+
+// Entries of the directory of token, relative to cwdLocation. Directories end
+// with a separator. Hidden entries are only listed once the name starts with '.'
+func pathCandidates(token string, cwdLocation string, dirsOnly bool) []string {
+	dirPart := token[:strings.LastIndexAny(token, `/`+string(filepath.Separator))+1]
+	base := token[len(dirPart):]
+	dir := cwdLocation
+	if dirPart != "" {
+		dir = utils.ResolveAbsPath(cwdLocation, dirPart)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		isDir := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			info, err := os.Stat(filepath.Join(dir, name))
+			isDir = err == nil && info.IsDir()
+		}
+		if isDir {
+			name += "/"
+		} else if dirsOnly {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	candidates := rankCandidates(base, names)
+	for i := range candidates {
+		candidates[i] = dirPart + candidates[i]
+	}
+	return candidates
+}
+
+// Sorted names of the executables in the directories of path, like $PATH
+func executablesInPath(path string) []string {
+	seen := make(map[string]struct{})
+	for _, dir := range filepath.SplitList(path) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || !isExecutable(info) {
+				continue
+			}
+			seen[entry.Name()] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func isExecutable(info os.FileInfo) bool {
+	if runtime.GOOS == utils.OsWindows {
+		ext := strings.ToLower(filepath.Ext(info.Name()))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd" || ext == ".com"
+	}
+	return info.Mode()&0o111 != 0
+}
+
+// Lines of a popup of candidates, scrolled so that the selected one is shown
+func popupLines(candidates []string, cursor int) []string {
+	start := max(0, min(cursor-completionPopupLines/2, len(candidates)-completionPopupLines))
+	end := min(len(candidates), start+completionPopupLines)
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		if i == cursor {
+			lines = append(lines, common.ModalCursorStyle.Render(" "+icon.Cursor+" ")+candidates[i])
+		} else {
+			lines = append(lines, "   "+candidates[i])
+		}
+	}
+	return lines
+}
@@ -0,0 +1,116 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func Test_lastTokenStart(t *testing.T) {
+	assert.Equal(t, 0, lastTokenStart(""))
+	assert.Equal(t, 0, lastTokenStart("cd"))
+	assert.Equal(t, 3, lastTokenStart("cd "))
+	assert.Equal(t, 3, lastTokenStart("cd dir/sub"))
+	assert.Equal(t, 3, lastTokenStart(`cd "dir with spaces/su`))
+	assert.Equal(t, 9, lastTokenStart(`cp 'a b' c`))
+	if runtime.GOOS != utils.OsWindows {
+		assert.Equal(t, 3, lastTokenStart(`cp a\ b`))
+	}
+}
+
+func Test_quoting(t *testing.T) {
+	assert.Equal(t, "dir/", quoteIfNeeded("dir/"))
+	assert.Equal(t, `"my dir/"`, quoteIfNeeded("my dir/"))
+	assert.Equal(t, `'say "hi"'`, quoteIfNeeded(`say "hi"`))
+	assert.Equal(t, "my dir/", unquoteToken(`"my dir/"`))
+	assert.Equal(t, "my dir/s", unquoteToken(`"my dir/s`))
+	assert.Equal(t, "abc", unquoteToken("abc"))
+}
+
+func TestModel_Completion(t *testing.T) {
+	curTestDir := t.TempDir()
+	binDir := filepath.Join(curTestDir, "bin")
+	utils.SetupDirectories(t, binDir, filepath.Join(curTestDir, "docs"),
+		filepath.Join(curTestDir, "dir with spaces"), filepath.Join(curTestDir, ".hidden"))
+	utils.SetupFiles(t, filepath.Join(curTestDir, "data.txt"), filepath.Join(binDir, "not_executable"))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "superprog"), nil, 0o755))
+
+	tabKey := tea.KeyMsg{Type: tea.KeyTab}
+	shiftTabKey := tea.KeyMsg{Type: tea.KeyShiftTab}
+	newModel := func(shellMode bool, value string) Model {
+		m := DefaultModel(defaultTestMaxHeight, defaultTestWidth)
+		m.Open(shellMode)
+		m.textInput.SetValue(value)
+		return m
+	}
+
+	t.Run("Single command is completed", func(t *testing.T) {
+		m := newModel(false, "comp")
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.Equal(t, CompressCommand, m.textInput.Value())
+		assert.False(t, m.completion.active(), "Popup is not needed for a single candidate")
+	})
+
+	t.Run("Tab cycles through candidates", func(t *testing.T) {
+		m := newModel(false, "cd ")
+		m.HandleUpdate(tabKey, curTestDir)
+		require.True(t, m.completion.active())
+		assert.Equal(t, []string{"bin/", "dir with spaces/", "docs/"}, m.completion.candidates,
+			"Only directories that are not hidden should be completed")
+		assert.Equal(t, "cd bin/", m.textInput.Value())
+		assert.Contains(t, ansi.Strip(m.Render()), "dir with spaces/")
+
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.Equal(t, `cd "dir with spaces/"`, m.textInput.Value())
+		m.HandleUpdate(shiftTabKey, curTestDir)
+		m.HandleUpdate(shiftTabKey, curTestDir)
+		assert.Equal(t, "cd docs/", m.textInput.Value())
+
+		m.HandleUpdate(utils.TeaRuneKeyMsg("x"), curTestDir)
+		assert.False(t, m.completion.active(), "Any other key should close the popup")
+	})
+
+	t.Run("Paths are completed relative to the panel", func(t *testing.T) {
+		m := newModel(false, "open ../.hid")
+		m.HandleUpdate(tabKey, filepath.Join(curTestDir, "docs"))
+		assert.Equal(t, "open ../.hidden/", m.textInput.Value())
+
+		m = newModel(false, "split ")
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.Equal(t, "split ", m.textInput.Value(), "Arguments of split should not be completed")
+	})
+
+	t.Run("Shell mode completes executables and files", func(t *testing.T) {
+		t.Setenv("PATH", binDir)
+		m := newModel(true, "superp")
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.Equal(t, "superprog", m.textInput.Value())
+
+		// Executables are read once while the prompt is open
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, "superprog2"), nil, 0o755))
+		m.textInput.SetValue("superp")
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.Equal(t, "superprog", m.textInput.Value())
+		m.Close()
+		m.Open(true)
+		m.textInput.SetValue("superp")
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.True(t, m.completion.active(), "New executables are found after opening again")
+
+		m = newModel(true, "not_exec")
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.Equal(t, "not_exec", m.textInput.Value())
+
+		m = newModel(true, "cat dat")
+		m.HandleUpdate(tabKey, curTestDir)
+		assert.Equal(t, "cat data.txt", m.textInput.Value())
+	})
+}
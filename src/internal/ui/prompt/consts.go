@@ -7,13 +7,38 @@ import "time"
 const (
 	promptHeadlineText = "superfile Prompt"
 
-	OpenCommand  = "open"
-	SplitCommand = "split"
-	CdCommand    = "cd"
+	OpenCommand     = "open"
+	SplitCommand    = "split"
+	CdCommand       = "cd"
+	MkdirCommand    = "mkdir"
+	TouchCommand    = "touch"
+	RenameCommand   = "rename"
+	SelectCommand   = "select"
+	UnselectCommand = "unselect"
+	SortCommand     = "sort"
+	HiddenCommand   = "hidden"
+	PreviewCommand  = "preview"
+	CopyCommand     = "copy"
+	MoveCommand     = "move"
+	DeleteCommand   = "delete"
+	ExtractCommand  = "extract"
+	CompressCommand = "compress"
+	PinCommand      = "pin"
+	CloseCommand    = "close"
+	FocusCommand    = "focus"
+	MacroCommand    = "macro"
+
+	// Arguments of commands
+	onArg   = "on"
+	offArg  = "off"
+	ascArg  = "asc"
+	descArg = "desc"
 
 	// We could later make this configurable. But, not needed now.
 	spfPromptChar   = ">"
 	shellPromptChar = ":"
+	// Shell commands starting with it run in background
+	backgroundPrefix = "&"
 
 	successMessagePrefix = "Success"
 	failureMessagePrefix = "Error"
@@ -22,8 +47,19 @@ const (
 	spfModeString   = "(SPF Mode)"
 
 	// Error message string
-	tokenizationError    = "Failed during tokenization"
-	splitCommandArgError = "split command should not be given arguments"
+	tokenizationError  = "Failed during tokenization"
+	noArgCommandError  = "%s command should not be given arguments"
+	oneArgCommandError = "%s command needs exactly one argument, received %d"
+	onOffArgError      = "%s command needs 'on' or 'off' as argument"
+	sortArgCountError  = "sort command needs one or two arguments, received %d"
+	sortOrderArgError  = "sort order should be 'asc' or 'desc'"
+	sortKindArgError   = "Invalid sort kind : %s, expected one of %s"
+	focusArgError      = "focus command needs a panel number starting from 1"
+	macroArgCountError = "macro command needs one or two arguments, received %d"
+	macroRegisterError = "Invalid macro register : %s, expected a single letter"
+	macroCountError    = "macro replay count should be a number from 1 to %d"
+
+	emptyBackgroundCommandError = "No command to run in background"
 
 	// Timeout for command executed for shell substitution
 	shellSubTimeout        = 1000 * time.Millisecond
@@ -34,15 +70,23 @@ const (
 	PromptMinWidth  = 10
 	PromptMinHeight = 3
 
+	// Candidates after this are dropped, as nobody would cycle through them
+	maxCompletionCandidates = 100
+	// Candidates shown at once in the completion popup
+	completionPopupLines = 8
+
+	historySearchTitle = "History search"
+	noHistoryMatchText = "No matching command"
+
+	// Borders, input line, two section separators and one line of result message
+	resultReservedLines = 6
+
 	defaultTestWidth     = 100
 	defaultTestMaxHeight = 100
 
 	// UI dimension constants for prompt modal
 	// promptInputPadding is total padding for prompt input fields
 	promptInputPadding = 6 // 2 + 1 + 2 + 1 (borders and spacing)
-
-	// expectedArgCount is the expected number of prompt arguments
-	expectedArgCount = 2
 )
 
 func modeString(shellMode bool) string {
@@ -62,9 +106,10 @@ func shellPrompt(shellMode bool) string {
 func defaultCommandSlice() []promptCommand {
 	return []promptCommand{
 		{
-			command:     OpenCommand,
-			usage:       OpenCommand + " <PATH>",
-			description: "Open a new panel at a specified path",
+			command:      OpenCommand,
+			usage:        OpenCommand + " <PATH>",
+			description:  "Open a new panel at a specified path",
+			completeDirs: true,
 		},
 		{
 			command:     SplitCommand,
@@ -72,9 +117,97 @@ func defaultCommandSlice() []promptCommand {
 			description: "Open a new panel at a current file panel's path",
 		},
 		{
-			command:     CdCommand,
-			usage:       CdCommand + " <PATH>",
-			description: "Change directory of current panel",
+			command:      CdCommand,
+			usage:        CdCommand + " <PATH>",
+			description:  "Change directory of current panel",
+			completeDirs: true,
+		},
+		{
+			command:     MkdirCommand,
+			usage:       MkdirCommand + " <NAME>",
+			description: "Create a directory in current panel",
+		},
+		{
+			command:     TouchCommand,
+			usage:       TouchCommand + " <NAME>",
+			description: "Create an empty file in current panel",
+		},
+		{
+			command:     RenameCommand,
+			usage:       RenameCommand + " <NEW_NAME>",
+			description: "Rename the focused item",
+		},
+		{
+			command:     SelectCommand,
+			usage:       SelectCommand + " <GLOB>",
+			description: "Select items whose name matches the pattern, like '*.go'",
+		},
+		{
+			command:     UnselectCommand,
+			usage:       UnselectCommand,
+			description: "Clear the selection of current panel",
+		},
+		{
+			command:     SortCommand,
+			usage:       SortCommand + " <KIND> [asc|desc]",
+			description: "Sort current panel by name, size, date, type or natural",
+		},
+		{
+			command:     HiddenCommand,
+			usage:       HiddenCommand + " <on|off>",
+			description: "Show or hide hidden files",
+		},
+		{
+			command:     PreviewCommand,
+			usage:       PreviewCommand + " <on|off>",
+			description: "Open or close the file preview panel",
+		},
+		{
+			command:      CopyCommand,
+			usage:        CopyCommand + " <PATH>",
+			description:  "Copy selected items, or the focused item, to a directory",
+			completeDirs: true,
+		},
+		{
+			command:      MoveCommand,
+			usage:        MoveCommand + " <PATH>",
+			description:  "Move selected items, or the focused item, to a directory",
+			completeDirs: true,
+		},
+		{
+			command:     DeleteCommand,
+			usage:       DeleteCommand,
+			description: "Delete selected items, or the focused item, after confirmation",
+		},
+		{
+			command:     ExtractCommand,
+			usage:       ExtractCommand,
+			description: "Extract the focused archive",
+		},
+		{
+			command:     CompressCommand,
+			usage:       CompressCommand + " <NAME>",
+			description: "Compress selected items, or the focused item, to an archive",
+		},
+		{
+			command:     PinCommand,
+			usage:       PinCommand,
+			description: "Pin or unpin the directory of current panel",
+		},
+		{
+			command:     CloseCommand,
+			usage:       CloseCommand,
+			description: "Close current panel",
+		},
+		{
+			command:     FocusCommand,
+			usage:       FocusCommand + " <N>",
+			description: "Focus the panel at position N, starting from 1",
+		},
+		{
+			command:     MacroCommand,
+			usage:       MacroCommand + " <REGISTER> [COUNT]",
+			description: "Replay the keys recorded in a macro register COUNT times",
 		},
 	}
 }
@@ -0,0 +1,74 @@
+package prompt
+
+import (
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+// User-defined commands of the [commands] table are run from the SPF prompt by
+// their name, without arguments. Built-in commands take precedence over them
+
+func isBuiltinCommand(name string) bool {
+	return slices.ContainsFunc(defaultCommandSlice(), func(cmd promptCommand) bool {
+		return cmd.command == name
+	})
+}
+
+func getCustomCommandAction(name string) (common.CustomCommandAction, bool) {
+	if _, ok := common.Config.Commands[name]; !ok || isBuiltinCommand(name) {
+		return common.CustomCommandAction{}, false
+	}
+	return common.CustomCommandAction{Name: name}, true
+}
+
+// Hints and completions of the user-defined commands, sorted by name
+func customCommandSlice() []promptCommand {
+	var res []promptCommand
+	for _, name := range slices.Sorted(maps.Keys(common.Config.Commands)) {
+		if isBuiltinCommand(name) {
+			continue
+		}
+		command := common.Config.Commands[name]
+		res = append(res, promptCommand{
+			command:     name,
+			usage:       name,
+			description: command.GetDescription(),
+		})
+	}
+	return res
+}
+
+// GetSPFTemplateAction returns the action of the SPF command of a user-defined
+// command, once its placeholders are expanded. Shell substitution is resolved
+// first so that the expanded paths are never substituted
+func GetSPFTemplateAction(template string, values ShellPlaceholders, cwdLocation string) (common.ModelAction, error) {
+	noAction := common.NoAction{}
+	command, err := resolveShellSubstitution(shellSubTimeout, template, cwdLocation)
+	if err != nil {
+		return noAction, err
+	}
+	command, err = expandPlaceholders(command, values, spfQuote, quoteScanner{})
+	if err != nil {
+		return noAction, err
+	}
+	args, err := tokenizeWithQuotes(command)
+	if err != nil {
+		return noAction, err
+	}
+	if len(args) == 0 {
+		return noAction, errors.New("empty spf command")
+	}
+
+	action, err := getSPFCommandAction(args[0], args[1:])
+	var cmdErr invalidCmdError
+	if errors.As(err, &cmdErr) {
+		return noAction, errors.New(cmdErr.uiMessage())
+	}
+	if _, ok := action.(common.CustomCommandAction); ok {
+		return noAction, errors.New("user-defined commands cannot run other user-defined commands")
+	}
+	return action, err
+}
@@ -0,0 +1,90 @@
+package prompt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/internal/common"
+)
+
+func setCustomCommands(t *testing.T, commands map[string]common.CustomCommand) {
+	t.Helper()
+	original := common.Config.Commands
+	common.Config.Commands = commands
+	t.Cleanup(func() {
+		common.Config.Commands = original
+	})
+}
+
+func TestCustomCommandsInPrompt(t *testing.T) {
+	setCustomCommands(t, map[string]common.CustomCommand{
+		"todo":  {Run: "grep TODO %s", Description: "Find TODOs"},
+		"split": {SPF: "open /"},
+	})
+
+	t.Run("Run by name", func(t *testing.T) {
+		action, err := getPromptAction(false, "todo", defaultTestCwd)
+		require.NoError(t, err)
+		assert.Equal(t, common.CustomCommandAction{Name: "todo"}, action)
+	})
+
+	t.Run("Arguments are rejected", func(t *testing.T) {
+		_, err := getPromptAction(false, "todo abc", defaultTestCwd)
+		assert.Error(t, err)
+	})
+
+	t.Run("Built-in commands take precedence", func(t *testing.T) {
+		action, err := getPromptAction(false, SplitCommand, defaultTestCwd)
+		require.NoError(t, err)
+		assert.Equal(t, common.SplitPanelAction{}, action)
+	})
+
+	t.Run("Hints and completion", func(t *testing.T) {
+		m := DefaultModel(defaultTestMaxHeight, defaultTestWidth)
+		m.Open(false)
+		m.textInput.SetValue("tod")
+		m.handleCompletion(defaultTestCwd, true)
+		assert.Equal(t, "todo", m.textInput.Value())
+		m.textInput.SetValue("")
+		assert.Contains(t, m.Render(), "Find TODOs")
+	})
+}
+
+func TestGetSPFTemplateAction(t *testing.T) {
+	setCustomCommands(t, map[string]common.CustomCommand{
+		"backup": {SPF: "copy ~/backup"},
+	})
+	values := ShellPlaceholders{
+		FocusedFile: `/tmp/a "b".txt`,
+		CurrentDir:  "/tmp",
+		OtherDir:    `/home/x\y`,
+	}
+
+	testdata := []struct {
+		name     string
+		template string
+		expected common.ModelAction
+	}{
+		{"Other directory", "copy %D", common.CopyToAction{Location: `/home/x\y`}},
+		{"Quoted focused file", "rename %f", common.RenameAction{NewName: `/tmp/a "b".txt`}},
+		{"Current directory", "open %d", common.OpenPanelAction{Location: "/tmp"}},
+		{"Inside double quotes", `open "%d"`, common.OpenPanelAction{Location: "%d"}},
+		{"No placeholder", "split", common.SplitPanelAction{}},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			action, err := GetSPFTemplateAction(tt.template, values, defaultTestCwd)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, action)
+		})
+	}
+
+	for _, template := range []string{"backup", "cd %s", "cd", ""} {
+		t.Run("Invalid template "+template, func(t *testing.T) {
+			_, err := GetSPFTemplateAction(template, values, defaultTestCwd)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package prompt
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// history of the commands entered in one mode of the prompt, oldest first.
+// It is saved to a file, one command per line, unless path is empty
+type history struct {
+	path    string
+	maxSize int
+	entries []string
+
+	// Index of the recalled entry, len(entries) while not recalling
+	cursor int
+	// Input typed before the recall started, restored after the newest entry
+	draft string
+}
+
+func newHistory(maxSize int) history {
+	return history{maxSize: maxSize}
+}
+
+// load reads the entries saved at path, and saves new entries there from now on
+func (h *history) load(path string) error {
+	h.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	h.entries = nil
+	for line := range strings.Lines(string(data)) {
+		if line = strings.TrimSpace(line); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	h.trim()
+	h.resetRecall()
+	return nil
+}
+
+// add makes command the newest entry, removing any older copy of it
+func (h *history) add(command string) error {
+	command = strings.TrimSpace(command)
+	h.resetRecall()
+	if command == "" || h.maxSize == 0 {
+		return nil
+	}
+	h.entries = slices.DeleteFunc(h.entries, func(entry string) bool {
+		return entry == command
+	})
+	h.entries = append(h.entries, command)
+	h.trim()
+	h.resetRecall()
+	if h.path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), utils.UserDirPerm); err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, []byte(strings.Join(h.entries, "\n")+"\n"), utils.ConfigFilePerm)
+}
+
+func (h *history) trim() {
+	if len(h.entries) > h.maxSize {
+		h.entries = h.entries[len(h.entries)-h.maxSize:]
+	}
+}
+
+func (h *history) resetRecall() {
+	h.cursor = len(h.entries)
+	h.draft = ""
+}
+
+// previous returns the entry before the recalled one. current is the input,
+// kept as draft when the recall starts. ok is false when there is no older entry
+func (h *history) previous(current string) (string, bool) {
+	if h.cursor == 0 {
+		return "", false
+	}
+	if h.cursor == len(h.entries) {
+		h.draft = current
+	}
+	h.cursor--
+	return h.entries[h.cursor], true
+}
+
+// next returns the entry after the recalled one, or the draft after the newest
+// entry. ok is false while not recalling
+func (h *history) next() (string, bool) {
+	if h.cursor >= len(h.entries) {
+		return "", false
+	}
+	h.cursor++
+	if h.cursor == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.cursor], true
+}
+
+// search returns the entries matching query, best match first. Without a
+// query, the most recent entries come first
+func (h *history) search(query string) []string {
+	newestFirst := slices.Clone(h.entries)
+	slices.Reverse(newestFirst)
+	return rankCandidates(query, newestFirst)
+}
+
+// LoadHistory reads the histories of both modes, and saves them to the same
+// files from now on
+func (m *Model) LoadHistory(spfPath string, shellPath string) error {
+	return errors.Join(m.spfHistory.load(spfPath), m.shellHistory.load(shellPath))
+}
+
+func (m *Model) currentHistory() *history {
+	if m.shellMode {
+		return &m.shellHistory
+	}
+	return &m.spfHistory
+}
+
+// Replace the input with the older entry of the history if older is set, or
+// with the newer one
+func (m *Model) recallHistory(older bool) {
+	var entry string
+	var ok bool
+	if older {
+		entry, ok = m.currentHistory().previous(m.textInput.Value())
+	} else {
+		entry, ok = m.currentHistory().next()
+	}
+	if !ok {
+		return
+	}
+	m.completion.reset()
+	m.textInput.SetValue(entry)
+	m.textInput.CursorEnd()
+}
+
+func (m *Model) openHistorySearch() {
+	m.completion.reset()
+	m.historySearch = historySearch{
+		active:  true,
+		matches: m.currentHistory().search(m.textInput.Value()),
+	}
+}
+
+// Keys typed during the search update the query. Confirming puts the selected
+// entry in the input, without running it
+func (m *Model) handleHistorySearchKey(msg tea.KeyMsg) tea.Cmd {
+	search := &m.historySearch
+	switch {
+	case slices.Contains(common.Hotkeys.ConfirmTyping, msg.String()):
+		if len(search.matches) > 0 {
+			m.textInput.SetValue(search.matches[search.cursor])
+			m.textInput.CursorEnd()
+		}
+		*search = historySearch{}
+	case slices.Contains(common.Hotkeys.CancelTyping, msg.String()):
+		*search = historySearch{}
+	case msg.String() == "ctrl+r" || msg.String() == "down" || msg.String() == "up":
+		if len(search.matches) == 0 {
+			return nil
+		}
+		step := 1
+		if msg.String() == "up" {
+			step = -1
+		}
+		search.cursor = (search.cursor + step + len(search.matches)) % len(search.matches)
+	default:
+		var cmd tea.Cmd
+		m.textInput, cmd = m.textInput.Update(msg)
+		search.matches = m.currentHistory().search(m.textInput.Value())
+		search.cursor = 0
+		return cmd
+	}
+	return nil
+}
+
+func (m *Model) historySearchLines() []string {
+	if len(m.historySearch.matches) == 0 {
+		return []string{" " + historySearchTitle + " : " + noHistoryMatchText}
+	}
+	return append([]string{" " + historySearchTitle + " :"},
+		popupLines(m.historySearch.matches, m.historySearch.cursor)...)
+}
@@ -0,0 +1,145 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func Test_history(t *testing.T) {
+	t.Run("Entries are deduplicated and trimmed", func(t *testing.T) {
+		h := newHistory(3)
+		for _, cmd := range []string{"a", "b", "a", " ", "c", "d"} {
+			require.NoError(t, h.add(cmd))
+		}
+		assert.Equal(t, []string{"a", "c", "d"}, h.entries)
+	})
+
+	t.Run("Size zero disables the history", func(t *testing.T) {
+		h := newHistory(0)
+		require.NoError(t, h.add("a"))
+		assert.Empty(t, h.entries)
+	})
+
+	t.Run("Recall restores the draft", func(t *testing.T) {
+		h := newHistory(10)
+		require.NoError(t, h.add("a"))
+		require.NoError(t, h.add("b"))
+		_, ok := h.next()
+		assert.False(t, ok, "Nothing newer while not recalling")
+
+		entry, _ := h.previous("draft")
+		assert.Equal(t, "b", entry)
+		entry, _ = h.previous("b")
+		assert.Equal(t, "a", entry)
+		_, ok = h.previous("a")
+		assert.False(t, ok)
+		entry, _ = h.next()
+		assert.Equal(t, "b", entry)
+		entry, _ = h.next()
+		assert.Equal(t, "draft", entry)
+	})
+
+	t.Run("History is saved and loaded", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state", "history")
+		h := newHistory(2)
+		require.NoError(t, h.load(path), "Missing file is not an error")
+		require.NoError(t, h.add("first"))
+		require.NoError(t, h.add("second"))
+		require.NoError(t, h.add("third"))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "second\nthird\n", string(data))
+
+		loaded := newHistory(1)
+		require.NoError(t, loaded.load(path))
+		assert.Equal(t, []string{"third"}, loaded.entries)
+	})
+
+	t.Run("Search ranks newest first without query", func(t *testing.T) {
+		h := newHistory(10)
+		for _, cmd := range []string{"cd docs", "open file", "cd bin"} {
+			require.NoError(t, h.add(cmd))
+		}
+		assert.Equal(t, []string{"cd bin", "open file", "cd docs"}, h.search(""))
+		assert.Equal(t, []string{"open file"}, h.search("open"))
+	})
+}
+
+func TestModel_History(t *testing.T) {
+	curTestDir := t.TempDir()
+	upKey := tea.KeyMsg{Type: tea.KeyUp}
+	downKey := tea.KeyMsg{Type: tea.KeyDown}
+	ctrlRKey := tea.KeyMsg{Type: tea.KeyCtrlR}
+	enterKey := tea.KeyMsg{Type: tea.KeyEnter}
+
+	newModel := func(t *testing.T) Model {
+		m := DefaultModel(defaultTestMaxHeight, defaultTestWidth)
+		m.spfHistory = newHistory(10)
+		m.shellHistory = newHistory(10)
+		require.NoError(t, m.LoadHistory(filepath.Join(t.TempDir(), "spf"), filepath.Join(t.TempDir(), "shell")))
+		return m
+	}
+	run := func(m *Model, value string) {
+		m.textInput.SetValue(value)
+		m.HandleUpdate(enterKey, curTestDir)
+	}
+
+	t.Run("Each mode has its own history", func(t *testing.T) {
+		m := newModel(t)
+		m.Open(false)
+		run(&m, "split")
+		m.Open(true)
+		run(&m, "echo hi")
+
+		m.HandleUpdate(upKey, curTestDir)
+		assert.Equal(t, "echo hi", m.textInput.Value())
+		m.HandleUpdate(upKey, curTestDir)
+		assert.Equal(t, "echo hi", m.textInput.Value(), "SPF commands are not in shell history")
+		m.HandleUpdate(downKey, curTestDir)
+		assert.Empty(t, m.textInput.Value())
+
+		m.Open(false)
+		m.HandleUpdate(upKey, curTestDir)
+		assert.Equal(t, "split", m.textInput.Value())
+	})
+
+	t.Run("Reverse search fills the input", func(t *testing.T) {
+		m := newModel(t)
+		m.Open(true)
+		run(&m, "echo first")
+		run(&m, "ls -la")
+		run(&m, "echo second")
+
+		m.HandleUpdate(ctrlRKey, curTestDir)
+		require.True(t, m.historySearch.active)
+		for _, r := range "echo" {
+			m.HandleUpdate(utils.TeaRuneKeyMsg(string(r)), curTestDir)
+		}
+		assert.Len(t, m.historySearch.matches, 2)
+		assert.Contains(t, ansi.Strip(m.Render()), historySearchTitle)
+
+		m.HandleUpdate(ctrlRKey, curTestDir)
+		selected := m.historySearch.matches[1]
+		m.HandleUpdate(enterKey, curTestDir)
+		assert.False(t, m.historySearch.active)
+		assert.Equal(t, selected, m.textInput.Value(), "Confirming the search should not run the command")
+
+		m.HandleUpdate(ctrlRKey, curTestDir)
+		for _, r := range "zzz" {
+			m.HandleUpdate(utils.TeaRuneKeyMsg(string(r)), curTestDir)
+		}
+		assert.Contains(t, ansi.Strip(m.Render()), noHistoryMatchText)
+		m.HandleUpdate(tea.KeyMsg{Type: tea.KeyEsc}, curTestDir)
+		assert.False(t, m.historySearch.active)
+		assert.True(t, m.IsOpen(), "Cancelling the search should keep the prompt open")
+	})
+}
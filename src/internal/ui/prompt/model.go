@@ -26,11 +26,13 @@ func GenerateModel(spfPromptHotkey string, shellPromptHotkey string, closeOnSucc
 		open:              false,
 		shellMode:         true,
 		textInput:         common.GeneratePromptTextInput(),
-		commands:          defaultCommandSlice(),
+		commands:          append(defaultCommandSlice(), customCommandSlice()...),
 		spfPromptHotkey:   spfPromptHotkey,
 		shellPromptHotkey: shellPromptHotkey,
 		actionSuccess:     true,
 		closeOnSuccess:    closeOnSuccess,
+		spfHistory:        newHistory(common.Config.PromptHistorySize),
+		shellHistory:      newHistory(common.Config.PromptHistorySize),
 	}
 	m.SetMaxHeight(maxHeight)
 	m.SetWidth(width)
@@ -48,11 +50,20 @@ func (m *Model) HandleUpdate(msg tea.Msg, cwdLocation string) (common.ModelActio
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.historySearch.active {
+			return action, m.handleHistorySearchKey(msg)
+		}
 		switch {
 		case slices.Contains(common.Hotkeys.ConfirmTyping, msg.String()):
 			action = m.handleConfirm(cwdLocation)
 		case slices.Contains(common.Hotkeys.CancelTyping, msg.String()):
 			m.Close()
+		case msg.String() == "tab" || msg.String() == "shift+tab":
+			m.handleCompletion(cwdLocation, msg.String() == "tab")
+		case msg.String() == "up" || msg.String() == "down":
+			m.recallHistory(msg.String() == "up")
+		case msg.String() == "ctrl+r":
+			m.openHistorySearch()
 		default:
 			cmd = m.handleNormalKeyInput(msg)
 		}
@@ -84,7 +95,11 @@ func (m *Model) handleConfirm(cwdLocation string) common.ModelAction {
 		m.resultMsg = err.Error()
 		m.actionSuccess = false
 	}
+	if histErr := m.currentHistory().add(m.textInput.Value()); histErr != nil {
+		slog.Error("Error while saving prompt history", "error", histErr)
+	}
 	m.textInput.SetValue("")
+	m.completion.reset()
 	return action
 }
 
@@ -100,11 +115,24 @@ func (m *Model) handleNormalKeyInput(msg tea.KeyMsg) tea.Cmd {
 	}
 	m.resultMsg = ""
 	m.actionSuccess = true
+	m.completion.reset()
 	return cmd
 }
 
 // After action is performed, model will update the Model with results
 func (m *Model) HandleShellCommandResults(retCode int, output string) {
+	m.setShellCommandResults(retCode, output)
+	m.CloseOnSuccessIfNeeded()
+}
+
+// ShowShellCommandResults opens the prompt with the results of a command that
+// was not typed in it
+func (m *Model) ShowShellCommandResults(retCode int, output string) {
+	m.Open(true)
+	m.setShellCommandResults(retCode, output)
+}
+
+func (m *Model) setShellCommandResults(retCode int, output string) {
 	m.actionSuccess = retCode == 0
 	m.resultMsg = fmt.Sprintf("Command exited with status %d", retCode)
 
@@ -114,7 +142,6 @@ func (m *Model) HandleShellCommandResults(retCode int, output string) {
 	} else {
 		m.resultMsg += " (No output)"
 	}
-	m.CloseOnSuccessIfNeeded()
 }
 
 // After action is performed, model will update the prompt.Model with results
@@ -125,34 +152,45 @@ func (m *Model) HandleSPFActionResults(success bool, msg string) {
 	m.CloseOnSuccessIfNeeded()
 }
 
+// ShowSPFActionResults opens the prompt with the results of an action that was
+// not typed in it
+func (m *Model) ShowSPFActionResults(success bool, msg string) {
+	m.Open(false)
+	m.actionSuccess = success
+	m.resultMsg = msg
+}
+
 func (m *Model) Render() string {
 	r := ui.PromptRenderer(m.maxHeight, m.width)
 	r.SetBorderTitle(m.headline + " " + modeString(m.shellMode))
 	r.AddLines(" " + m.textInput.View())
 
-	if !m.shellMode {
-		// To make sure its added one time only per render call
-		hintSectionAdded := false
+	var hints []string
+	switch {
+	case m.historySearch.active:
+		hints = m.historySearchLines()
+	case m.completion.active():
+		hints = popupLines(m.completion.candidates, m.completion.cursor)
+	case !m.shellMode:
 		if m.textInput.Value() == "" {
-			if !hintSectionAdded {
-				r.AddSection()
-				hintSectionAdded = true
-			}
-			r.AddLines(" '" + m.shellPromptHotkey + "' - Get into Shell mode")
+			hints = append(hints, " '"+m.shellPromptHotkey+"' - Get into Shell mode")
 		}
 		command := getFirstToken(m.textInput.Value())
 		for _, cmd := range m.commands {
 			if strings.HasPrefix(cmd.command, command) {
-				if !hintSectionAdded {
-					r.AddSection()
-					hintSectionAdded = true
-				}
-				r.AddLines(" '" + cmd.usage + "' - " + cmd.description)
+				hints = append(hints, " '"+cmd.usage+"' - "+cmd.description)
 			}
 		}
-	} else if m.textInput.Value() == "" {
+	case m.textInput.Value() == "":
+		hints = append(hints, " '"+m.spfPromptHotkey+"' - Get into SPF mode")
+	}
+	// Hints are cut so that the result message is never truncated
+	if m.resultMsg != "" {
+		hints = hints[:max(0, min(len(hints), m.maxHeight-resultReservedLines))]
+	}
+	if len(hints) > 0 {
 		r.AddSection()
-		r.AddLines(" '" + m.spfPromptHotkey + "' - Get into SPF mode")
+		r.AddLines(hints...)
 	}
 
 	if m.resultMsg != "" {
@@ -170,6 +208,8 @@ func (m *Model) Render() string {
 
 func (m *Model) Open(shellMode bool) {
 	m.open = true
+	// Programs installed since the last opening can be completed
+	m.executables.reset()
 	m.setShellMode(shellMode)
 	_ = m.textInput.Focus()
 }
@@ -177,12 +217,15 @@ func (m *Model) Open(shellMode bool) {
 func (m *Model) setShellMode(shellMode bool) {
 	m.shellMode = shellMode
 	m.textInput.Prompt = shellPrompt(m.shellMode) + " "
+	m.currentHistory().resetRecall()
 }
 
 func (m *Model) Close() {
 	m.open = false
 	m.setShellMode(true)
 	m.textInput.SetValue("")
+	m.completion.reset()
+	m.historySearch = historySearch{}
 }
 
 func (m *Model) IsOpen() bool {
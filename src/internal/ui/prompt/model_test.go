@@ -286,10 +286,21 @@ func TestModel_Render(t *testing.T) {
 			"│ 'open <PATH>' - Open a new panel at a│\n" +
 			"│ 'split' - Open a new panel at a curre│\n" +
 			"│ 'cd <PATH>' - Change directory of cur│\n" +
+			"│ 'mkdir <NAME>' - Create a directory i│\n" +
+			"│ 'touch <NAME>' - Create an empty file│\n" +
 			"╰──────────────────────────────────────╯"
 		assert.Equal(t, exp, res)
 	})
 
+	t.Run("Result message is not hidden by suggestions", func(t *testing.T) {
+		m := GenerateModel(spfPromptChar, shellPromptChar, true, 10, 40)
+		m.Open(false)
+		m.HandleSPFActionResults(false, "abc")
+		resLines := strings.Split(ansi.Strip(m.Render()), "\n")
+		require.Len(t, resLines, 10)
+		assert.Contains(t, resLines[len(resLines)-2], failureMessagePrefix+" : abc")
+	})
+
 	t.Run("Test User Input", func(t *testing.T) {
 		execute := func(input string, expected string) {
 			// Changing this will need test adjustments
@@ -345,23 +356,15 @@ func TestModel_Render(t *testing.T) {
 		assert.Equal(t, exp, res)
 	})
 	shellModeSuggestion := "':' - Get into Shell mode"
-	var openCmdSuggestion string
-	var splitCmdSuggestion string
-	var cdCmdSuggestion string
+	cmdSuggestions := make(map[string]string)
+	var allCmdSuggestions []string
 	for _, cmd := range defaultCommandSlice() {
 		curSuggestion := "'" + cmd.usage + "' - " + cmd.description
-
-		switch cmd.command {
-		case OpenCommand:
-			openCmdSuggestion = curSuggestion
-		case SplitCommand:
-			splitCmdSuggestion = curSuggestion
-		case CdCommand:
-			cdCmdSuggestion = curSuggestion
-		default:
-			assert.Fail(t, "Unknow command")
-		}
+		cmdSuggestions[cmd.command] = curSuggestion
+		allCmdSuggestions = append(allCmdSuggestions, curSuggestion)
 	}
+	openCmdSuggestion := cmdSuggestions[OpenCommand]
+	cdCmdSuggestion := cmdSuggestions[CdCommand]
 
 	testdataSuggestions := []struct {
 		name                string
@@ -369,14 +372,9 @@ func TestModel_Render(t *testing.T) {
 		expectedSuggestions []string
 	}{
 		{
-			name:      "No Input",
-			textInput: "",
-			expectedSuggestions: []string{
-				shellModeSuggestion,
-				openCmdSuggestion,
-				splitCmdSuggestion,
-				cdCmdSuggestion,
-			},
+			name:                "No Input",
+			textInput:           "",
+			expectedSuggestions: append([]string{shellModeSuggestion}, allCmdSuggestions...),
 		},
 		{
 			name:      "Command without args",
@@ -390,6 +388,9 @@ func TestModel_Render(t *testing.T) {
 			textInput: "c",
 			expectedSuggestions: []string{
 				cdCmdSuggestion,
+				cmdSuggestions[CopyCommand],
+				cmdSuggestions[CompressCommand],
+				cmdSuggestions[CloseCommand],
 			},
 		},
 		{
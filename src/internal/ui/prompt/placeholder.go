@@ -0,0 +1,118 @@
+package prompt
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"unicode"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// ShellPlaceholders are the values of the placeholders of shell commands.
+// Empty values are only an error when their placeholder is used
+type ShellPlaceholders struct {
+	// %f
+	FocusedFile string
+	// %s, selected items, or the focused one when nothing is selected
+	SelectedFiles []string
+	// %d
+	CurrentDir string
+	// %D, directory of the next panel
+	OtherDir string
+}
+
+// Env returns the environment variables $fx and $fs, holding the focused file
+// and the newline separated selected files
+func (p ShellPlaceholders) Env() []string {
+	return []string{
+		"fx=" + p.FocusedFile,
+		"fs=" + strings.Join(p.SelectedFiles, "\n"),
+	}
+}
+
+// ExpandShellPlaceholders replaces %f, %s, %d and %D with their values, quoted
+// for the shell. Placeholders are only expanded when they are a whole word
+// outside of quotes, so that '%' in arguments like `date +%s` or
+// `printf "%d\n"` are kept. An escaped placeholder like `\%f` is kept too,
+// except on windows where '\' is the path separator
+func ExpandShellPlaceholders(command string, values ShellPlaceholders) (string, error) {
+	scanner := quoteScanner{literalBackslash: runtime.GOOS == utils.OsWindows}
+	return expandPlaceholders(command, values, shellQuote, scanner)
+}
+
+// expandPlaceholders expands the placeholders with quote. Quotes and escapes
+// are read with scanner, like the SPF prompt tokenizer does
+func expandPlaceholders(command string, values ShellPlaceholders, quote func(string) string,
+	scanner quoteScanner) (string, error) {
+	var res strings.Builder
+	cmdRunes := []rune(command)
+	wordStart := true
+	for i := 0; i < len(cmdRunes); i++ {
+		r := cmdRunes[i]
+		kind := scanner.next(r)
+		if wordStart && kind == runeContent && scanner.quoteOpen == 0 && isPlaceholderWord(cmdRunes[i:]) {
+			expanded, err := expandPlaceholder(cmdRunes[i+1], values, quote)
+			if err != nil {
+				return "", err
+			}
+			res.WriteString(expanded)
+			// The placeholder letter is never a quote or an escape
+			i++
+			wordStart = false
+			continue
+		}
+		wordStart = kind == runeContent && scanner.isSeparator(r)
+		res.WriteRune(r)
+	}
+	return res.String(), nil
+}
+
+// Whether runes start with a placeholder followed by the end of the word
+func isPlaceholderWord(runes []rune) bool {
+	return len(runes) >= 2 && runes[0] == '%' && strings.ContainsRune("fsdD", runes[1]) &&
+		(len(runes) == 2 || unicode.IsSpace(runes[2]))
+}
+
+func expandPlaceholder(placeholder rune, values ShellPlaceholders, quote func(string) string) (string, error) {
+	var paths []string
+	switch placeholder {
+	case 'f':
+		if values.FocusedFile == "" {
+			return "", errors.New("no focused file for %f")
+		}
+		paths = []string{values.FocusedFile}
+	case 's':
+		if len(values.SelectedFiles) == 0 {
+			return "", errors.New("no selected file for %s")
+		}
+		paths = values.SelectedFiles
+	case 'd':
+		paths = []string{values.CurrentDir}
+	case 'D':
+		if values.OtherDir == "" {
+			return "", errors.New("no other panel for %D")
+		}
+		paths = []string{values.OtherDir}
+	}
+
+	quoted := make([]string, 0, len(paths))
+	for _, path := range paths {
+		quoted = append(quoted, quote(path))
+	}
+	return strings.Join(quoted, " "), nil
+}
+
+// Quote s as a single word, for /bin/sh or for powershell on windows
+func shellQuote(s string) string {
+	if runtime.GOOS == utils.OsWindows {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Quote s as a single token for the SPF prompt tokenizer
+func spfQuote(s string) string {
+	// The SPF prompt tokenizer escapes with '\' inside any quotes
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,67 @@
+package prompt
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestExpandShellPlaceholders(t *testing.T) {
+	if runtime.GOOS == utils.OsWindows {
+		t.Skip("Expected values use the quoting of /bin/sh")
+	}
+	values := ShellPlaceholders{
+		FocusedFile:   "/tmp/it's.txt",
+		SelectedFiles: []string{"/tmp/a b", "/tmp/c"},
+		CurrentDir:    "/tmp",
+		OtherDir:      "/home/$user",
+	}
+
+	testdata := []struct {
+		name     string
+		command  string
+		expected string
+	}{
+		{"No placeholder", "ls -la", "ls -la"},
+		{"Focused file", "cat %f", `cat '/tmp/it'\''s.txt'`},
+		{"Selected files", "rm %s", "rm '/tmp/a b' '/tmp/c'"},
+		{"Directories", "cp %f %D", `cp '/tmp/it'\''s.txt' '/home/$user'`},
+		{"Current dir", "cd %d", "cd '/tmp'"},
+		{"Around whitespace", "cp\t%f  %D\n", "cp\t'/tmp/it'\\''s.txt'  '/home/$user'\n"},
+		{"Inside double quotes", `echo "%D"`, `echo "%D"`},
+		{"Inside single quotes", `echo '%f'`, `echo '%f'`},
+		{"Escaped", `echo \%f`, `echo \%f`},
+		{"Part of a word", "date +%s", "date +%s"},
+		{"Format string", `printf "%d\n" 5`, `printf "%d\n" 5`},
+		{"Unquoted format string", "printf %d%s 5 a", "printf %d%s 5 a"},
+		{"After a quoted word", `echo ""%f`, `echo ""%f`},
+		{"Unknown placeholder", "date +%Y %", "date +%Y %"},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := ExpandShellPlaceholders(tt.command, values)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+
+	t.Run("Missing values are errors only when used", func(t *testing.T) {
+		empty := ShellPlaceholders{CurrentDir: "/tmp"}
+		res, err := ExpandShellPlaceholders("ls %d", empty)
+		require.NoError(t, err)
+		assert.Equal(t, "ls '/tmp'", res)
+		for _, command := range []string{"cat %f", "rm %s", "cd %D"} {
+			_, err = ExpandShellPlaceholders(command, empty)
+			assert.Error(t, err, command)
+		}
+	})
+}
+
+func TestShellPlaceholdersEnv(t *testing.T) {
+	values := ShellPlaceholders{FocusedFile: "/a", SelectedFiles: []string{"/a", "/b"}}
+	assert.Equal(t, []string{"fx=/a", "fs=/a\n/b"}, values.Env())
+}
@@ -130,13 +130,58 @@ func getClosingBracket(r rune) rune {
 	}
 }
 
+// Kinds of the runes of a command, as read by quoteScanner
+type runeKind int
+
+const (
+	// Part of the content, quoted or not
+	runeContent runeKind = iota
+	// Character following a '\'
+	runeEscaped
+	runeEscape
+	runeQuoteOpen
+	runeQuoteClose
+)
+
+// quoteScanner tracks the quotes and the escapes of a command, one rune at a
+// time. '\' escapes the next character, even inside quotes
+type quoteScanner struct {
+	quoteOpen rune // 0:none, '\'' or '"'
+	escaped   bool
+	// '\' is content, like in windows paths
+	literalBackslash bool
+}
+
+func (q *quoteScanner) next(r rune) runeKind {
+	switch {
+	case q.escaped:
+		q.escaped = false
+		return runeEscaped
+	case r == '\\' && !q.literalBackslash:
+		q.escaped = true
+		return runeEscape
+	case q.quoteOpen == 0 && (r == '"' || r == '\''):
+		q.quoteOpen = r
+		return runeQuoteOpen
+	case q.quoteOpen == r:
+		q.quoteOpen = 0
+		return runeQuoteClose
+	default:
+		return runeContent
+	}
+}
+
+// Whether r, returned as runeContent, separates words
+func (q *quoteScanner) isSeparator(r rune) bool {
+	return q.quoteOpen == 0 && unicode.IsSpace(r)
+}
+
 // splits command into tokens while respecting quotes and escapes
 func tokenizeWithQuotes(command string) ([]string, error) {
 	var (
-		tokens    []string
-		buffer    strings.Builder
-		quoteOpen rune // 0:none, '\'' or '"'
-		escaped   bool
+		tokens  []string
+		buffer  strings.Builder
+		scanner quoteScanner
 	)
 
 	// Initialize tokens as empty slice instead of nil
@@ -149,8 +194,8 @@ func tokenizeWithQuotes(command string) ([]string, error) {
 	}
 
 	for _, r := range command {
-		switch {
-		case escaped:
+		switch scanner.next(r) {
+		case runeEscaped:
 			// Only allow escaping of specific characters that have special meaning
 			switch r {
 			case '"', '\'', '\\', ' ':
@@ -161,26 +206,21 @@ func tokenizeWithQuotes(command string) ([]string, error) {
 				buffer.WriteRune('\\')
 				buffer.WriteRune(r)
 			}
-			escaped = false
-		case r == '\\':
-			escaped = true
-		case quoteOpen == 0 && (r == '"' || r == '\''):
-			quoteOpen = r
-		case quoteOpen == r:
+		case runeEscape, runeQuoteOpen:
+		case runeQuoteClose:
 			// End of quoted section - always flush (even if empty)
 			flush()
-			quoteOpen = 0
-		case unicode.IsSpace(r) && quoteOpen == 0:
-			// Only flush if we have content
-			if buffer.Len() > 0 {
+		case runeContent:
+			if !scanner.isSeparator(r) {
+				buffer.WriteRune(r)
+			} else if buffer.Len() > 0 {
+				// Only flush if we have content
 				flush()
 			}
-		default:
-			buffer.WriteRune(r)
 		}
 	}
 
-	if escaped || quoteOpen != 0 {
+	if scanner.escaped || scanner.quoteOpen != 0 {
 		return nil, errors.New("unmatched quotes or escape characters in command")
 	}
 
@@ -191,3 +231,34 @@ func tokenizeWithQuotes(command string) ([]string, error) {
 
 	return tokens, nil
 }
+
+// SplitPromptCommands splits a script into SPF prompt commands, separated by
+// newlines or by ';' outside of quotes. Lines starting with '#' are comments
+func SplitPromptCommands(script string) []string {
+	var commands []string
+	for line := range strings.Lines(script) {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		var (
+			buffer  strings.Builder
+			scanner quoteScanner
+		)
+		flush := func() {
+			if command := strings.TrimSpace(buffer.String()); command != "" {
+				commands = append(commands, command)
+			}
+			buffer.Reset()
+		}
+		for _, r := range line {
+			if scanner.next(r) == runeContent && r == ';' && scanner.quoteOpen == 0 {
+				flush()
+				continue
+			}
+			buffer.WriteRune(r)
+		}
+		flush()
+	}
+	return commands
+}
@@ -531,3 +531,23 @@ func Test_tokenizeWithQuotes(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitPromptCommands(t *testing.T) {
+	testdata := []struct {
+		name     string
+		script   string
+		expected []string
+	}{
+		{"Empty", "", nil},
+		{"Semicolons", "cd /srv; split;sort size desc", []string{"cd /srv", "split", "sort size desc"}},
+		{"Lines and comments", "# Logs\nopen /var/log\n\n  # indented comment\nsplit ; \n",
+			[]string{"open /var/log", "split"}},
+		{"Quoted semicolons", `mkdir "a;b"; touch 'c;d'`, []string{`mkdir "a;b"`, `touch 'c;d'`}},
+		{"Hash inside a command", "touch #1", []string{"touch #1"}},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SplitPromptCommands(tt.script))
+		})
+	}
+}
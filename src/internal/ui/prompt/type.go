@@ -19,6 +19,14 @@ type Model struct {
 	shellMode bool
 	textInput textinput.Model
 	resultMsg string
+	// Popup of tab completion candidates
+	completion completion
+	// Executables of $PATH for completion, read once per opening of the prompt
+	executables executablesCache
+	// Entered commands of each mode
+	spfHistory    history
+	shellHistory  history
+	historySearch historySearch
 
 	// Whether the user intended action was successful
 	actionSuccess bool
@@ -35,4 +43,14 @@ type promptCommand struct {
 	command     string
 	usage       string
 	description string
+	// Whether tab completes the argument with directories
+	completeDirs bool
+}
+
+// historySearch is the fuzzy search of the history of current mode. The input
+// is the query while it is active
+type historySearch struct {
+	active  bool
+	matches []string
+	cursor  int
 }
@@ -1,10 +1,16 @@
 package prompt
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/macro"
+	"github.com/yorukot/superfile/src/internal/ui/sortmodel"
 )
 
 func getPromptAction(shellMode bool, value string, cwdLocation string) (common.ModelAction, error) {
@@ -13,9 +19,14 @@ func getPromptAction(shellMode bool, value string, cwdLocation string) (common.M
 		return noAction, nil
 	}
 	if shellMode {
-		return common.ShellCommandAction{
-			Command: value,
-		}, nil
+		command, background := strings.CutPrefix(value, backgroundPrefix)
+		if !background {
+			return common.ShellCommandAction{Command: value}, nil
+		}
+		if command = strings.TrimSpace(command); command == "" {
+			return noAction, invalidCmdError{uiMsg: emptyBackgroundCommandError}
+		}
+		return common.ShellCommandAction{Command: command, Background: true}, nil
 	}
 
 	promptArgs, err := tokenizePromptCommand(value, cwdLocation)
@@ -26,40 +37,174 @@ func getPromptAction(shellMode bool, value string, cwdLocation string) (common.M
 		}
 	}
 
-	switch promptArgs[0] {
-	case "split":
-		if len(promptArgs) != 1 {
-			return noAction, invalidCmdError{
-				uiMsg: splitCommandArgError,
+	return getSPFCommandAction(promptArgs[0], promptArgs[1:])
+}
+
+// GetSPFAction returns the action of an SPF prompt command that was not typed in
+// the prompt, like the startup commands. Errors hold the message for the user
+func GetSPFAction(command string, cwdLocation string) (common.ModelAction, error) {
+	action, err := getPromptAction(false, command, cwdLocation)
+	var cmdErr invalidCmdError
+	if errors.As(err, &cmdErr) {
+		return action, errors.New(cmdErr.uiMessage())
+	}
+	return action, err
+}
+
+func getSPFCommandAction(command string, args []string) (common.ModelAction, error) {
+	switch command {
+	case SplitCommand, UnselectCommand, DeleteCommand, ExtractCommand, PinCommand, CloseCommand:
+		if len(args) != 0 {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: fmt.Sprintf(noArgCommandError, command),
+			}
+		}
+		return getNoArgCommandAction(command), nil
+	case CdCommand, OpenCommand, MkdirCommand, TouchCommand, RenameCommand, SelectCommand,
+		CopyCommand, MoveCommand, CompressCommand, FocusCommand:
+		if len(args) != 1 {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: fmt.Sprintf(oneArgCommandError, command, len(args)),
 			}
 		}
-		return common.SplitPanelAction{}, nil
-	case "cd":
-		if len(promptArgs) != expectedArgCount {
-			return noAction, invalidCmdError{
-				uiMsg: fmt.Sprintf("cd command needs exactly one argument, received %d",
-					len(promptArgs)-1),
+		return getOneArgCommandAction(command, args[0])
+	case HiddenCommand, PreviewCommand:
+		if len(args) != 1 || (args[0] != onArg && args[0] != offArg) {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: fmt.Sprintf(onOffArgError, command),
 			}
 		}
-		return common.CDCurrentPanelAction{
-			Location: promptArgs[1],
-		}, nil
-	case "open":
-		if len(promptArgs) != expectedArgCount {
-			return noAction, invalidCmdError{
-				uiMsg: fmt.Sprintf("open command needs exactly one argument, received %d",
-					len(promptArgs)-1),
+		if command == HiddenCommand {
+			return common.SetHiddenFilesAction{Show: args[0] == onArg}, nil
+		}
+		return common.SetPreviewAction{Show: args[0] == onArg}, nil
+	case SortCommand:
+		return getSortAction(args)
+	case MacroCommand:
+		return getMacroAction(args)
+	default:
+		action, ok := getCustomCommandAction(command)
+		if !ok {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: "Invalid spf command : " + command,
 			}
 		}
-		return common.OpenPanelAction{
-			Location: promptArgs[1],
-		}, nil
+		if len(args) != 0 {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: fmt.Sprintf(noArgCommandError, command),
+			}
+		}
+		return action, nil
+	}
+}
 
+func getNoArgCommandAction(command string) common.ModelAction {
+	switch command {
+	case SplitCommand:
+		return common.SplitPanelAction{}
+	case UnselectCommand:
+		return common.UnselectAction{}
+	case DeleteCommand:
+		return common.DeleteAction{}
+	case ExtractCommand:
+		return common.ExtractAction{}
+	case PinCommand:
+		return common.TogglePinAction{}
+	case CloseCommand:
+		return common.ClosePanelAction{}
 	default:
-		return noAction, invalidCmdError{
-			uiMsg: "Invalid spf command : " + promptArgs[0],
+		return common.NoAction{}
+	}
+}
+
+func getOneArgCommandAction(command string, arg string) (common.ModelAction, error) {
+	switch command {
+	case CdCommand:
+		return common.CDCurrentPanelAction{Location: arg}, nil
+	case OpenCommand:
+		return common.OpenPanelAction{Location: arg}, nil
+	case MkdirCommand, TouchCommand:
+		return common.CreateItemAction{Name: arg, IsDir: command == MkdirCommand}, nil
+	case RenameCommand:
+		return common.RenameAction{NewName: arg}, nil
+	case SelectCommand:
+		// Only validates the pattern
+		if _, err := filepath.Match(arg, ""); err != nil {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg:        "Invalid pattern : " + arg,
+				wrappedError: err,
+			}
+		}
+		return common.SelectAction{Pattern: arg}, nil
+	case CopyCommand, MoveCommand:
+		return common.CopyToAction{Location: arg, Cut: command == MoveCommand}, nil
+	case CompressCommand:
+		return common.CompressToAction{Name: arg}, nil
+	case FocusCommand:
+		panelNum, err := strconv.Atoi(arg)
+		if err != nil || panelNum < 1 {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: focusArgError,
+			}
+		}
+		return common.FocusPanelAction{Index: panelNum - 1}, nil
+	default:
+		return common.NoAction{}, nil
+	}
+}
+
+// Sort kinds are named like in the sort options menu, in lower case
+func getSortAction(args []string) (common.ModelAction, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return common.NoAction{}, invalidCmdError{
+			uiMsg: fmt.Sprintf(sortArgCountError, len(args)),
+		}
+	}
+	kinds := make([]string, 0, len(sortmodel.SortOptionsShortStr))
+	for _, kind := range sortmodel.SortOptionsShortStr {
+		kinds = append(kinds, strings.ToLower(kind))
+	}
+	kind := slices.Index(kinds, strings.ToLower(args[0]))
+	if kind == -1 {
+		return common.NoAction{}, invalidCmdError{
+			uiMsg: fmt.Sprintf(sortKindArgError, args[0], strings.Join(kinds, ", ")),
+		}
+	}
+	action := common.SortAction{Kind: kind}
+	if len(args) == 2 {
+		if args[1] != ascArg && args[1] != descArg {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: sortOrderArgError,
+			}
+		}
+		action.SetOrder = true
+		action.Reversed = args[1] == descArg
+	}
+	return action, nil
+}
+
+func getMacroAction(args []string) (common.ModelAction, error) {
+	if len(args) == 0 || len(args) > 2 {
+		return common.NoAction{}, invalidCmdError{
+			uiMsg: fmt.Sprintf(macroArgCountError, len(args)),
+		}
+	}
+	if !macro.IsValidRegister(args[0]) {
+		return common.NoAction{}, invalidCmdError{
+			uiMsg: fmt.Sprintf(macroRegisterError, args[0]),
+		}
+	}
+	action := common.ReplayMacroAction{Register: args[0], Count: 1}
+	if len(args) == 2 {
+		count, err := strconv.Atoi(args[1])
+		if err != nil || count < 1 || count > macro.MaxReplayCount {
+			return common.NoAction{}, invalidCmdError{
+				uiMsg: fmt.Sprintf(macroCountError, macro.MaxReplayCount),
+			}
 		}
+		action.Count = count
 	}
+	return action, nil
 }
 
 // Only allocates memory proportional to first token's size
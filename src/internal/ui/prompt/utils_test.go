@@ -1,11 +1,13 @@
 package prompt
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/yorukot/superfile/src/internal/common"
+	"github.com/yorukot/superfile/src/internal/ui/macro"
 )
 
 func TestModel_getPromptAction(t *testing.T) {
@@ -39,6 +41,25 @@ func TestModel_getPromptAction(t *testing.T) {
 			expectedErr:    false,
 			expectedErrMsg: "",
 		},
+		{
+			name:      "Background shell command",
+			text:      "& make build",
+			shellMode: true,
+			expectecAction: common.ShellCommandAction{
+				Command:    "make build",
+				Background: true,
+			},
+			expectedErr:    false,
+			expectedErrMsg: "",
+		},
+		{
+			name:           "Empty background shell command",
+			text:           "&  ",
+			shellMode:      true,
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: emptyBackgroundCommandError,
+		},
 		{
 			name:           "Tokenization failure",
 			text:           "cd ${sdfdsf", // Missing "}"
@@ -53,7 +74,7 @@ func TestModel_getPromptAction(t *testing.T) {
 			shellMode:      false,
 			expectecAction: common.NoAction{},
 			expectedErr:    true,
-			expectedErrMsg: splitCommandArgError,
+			expectedErrMsg: fmt.Sprintf(noArgCommandError, SplitCommand),
 		},
 		{
 			name:           "cd with 0 arguments",
@@ -103,6 +124,129 @@ func TestModel_getPromptAction(t *testing.T) {
 			expectedErr:    true,
 			expectedErrMsg: "open command needs exactly one argument, received 2",
 		},
+		{
+			name:           "Correct mkdir command",
+			text:           MkdirCommand + " abc/xyz",
+			expectecAction: common.CreateItemAction{Name: "abc/xyz", IsDir: true},
+		},
+		{
+			name:           "Correct touch command",
+			text:           TouchCommand + " 'file name.txt'",
+			expectecAction: common.CreateItemAction{Name: "file name.txt", IsDir: false},
+		},
+		{
+			name:           "Correct rename command",
+			text:           RenameCommand + " new.txt",
+			expectecAction: common.RenameAction{NewName: "new.txt"},
+		},
+		{
+			name:           "Correct select command",
+			text:           SelectCommand + " *.go",
+			expectecAction: common.SelectAction{Pattern: "*.go"},
+		},
+		{
+			name:           "select with invalid pattern",
+			text:           SelectCommand + " [a-",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: "Invalid pattern : [a-",
+		},
+		{
+			name:           "unselect with arguments",
+			text:           UnselectCommand + " *.go",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: "unselect command should not be given arguments",
+		},
+		{
+			name:           "Sort without order",
+			text:           SortCommand + " Size",
+			expectecAction: common.SortAction{Kind: 1},
+		},
+		{
+			name:           "Sort with order",
+			text:           SortCommand + " natural desc",
+			expectecAction: common.SortAction{Kind: 4, SetOrder: true, Reversed: true},
+		},
+		{
+			name:           "Sort with invalid kind",
+			text:           SortCommand + " color",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: "Invalid sort kind : color, expected one of name, size, date, type, natural",
+		},
+		{
+			name:           "Sort with invalid order",
+			text:           SortCommand + " name up",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: sortOrderArgError,
+		},
+		{
+			name:           "Correct hidden command",
+			text:           HiddenCommand + " on",
+			expectecAction: common.SetHiddenFilesAction{Show: true},
+		},
+		{
+			name:           "preview with invalid argument",
+			text:           PreviewCommand + " yes",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: "preview command needs 'on' or 'off' as argument",
+		},
+		{
+			name:           "Correct move command",
+			text:           MoveCommand + " ../abc",
+			expectecAction: common.CopyToAction{Location: "../abc", Cut: true},
+		},
+		{
+			name:           "Correct compress command",
+			text:           CompressCommand + " out.tar.gz",
+			expectecAction: common.CompressToAction{Name: "out.tar.gz"},
+		},
+		{
+			name:           "Correct focus command",
+			text:           FocusCommand + " 2",
+			expectecAction: common.FocusPanelAction{Index: 1},
+		},
+		{
+			name:           "focus with invalid number",
+			text:           FocusCommand + " 0",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: focusArgError,
+		},
+		{
+			name:           "Correct macro command",
+			text:           MacroCommand + " a",
+			expectecAction: common.ReplayMacroAction{Register: "a", Count: 1},
+		},
+		{
+			name:           "macro command with count",
+			text:           MacroCommand + " Q 12",
+			expectecAction: common.ReplayMacroAction{Register: "Q", Count: 12},
+		},
+		{
+			name:           "macro with invalid register",
+			text:           MacroCommand + " 1",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: fmt.Sprintf(macroRegisterError, "1"),
+		},
+		{
+			name:           "macro with invalid count",
+			text:           MacroCommand + " a 0",
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: fmt.Sprintf(macroCountError, macro.MaxReplayCount),
+		},
+		{
+			name:           "macro without register",
+			text:           MacroCommand,
+			expectecAction: common.NoAction{},
+			expectedErr:    true,
+			expectedErrMsg: fmt.Sprintf(macroArgCountError, 0),
+		},
 	}
 
 	for _, tt := range testdata {
@@ -1,6 +1,7 @@
 package sidebar
 
 import (
+	"errors"
 	"log/slog"
 	"slices"
 
@@ -101,6 +102,9 @@ func (s *Model) UpdateDirectories() {
 
 // TogglePinnedDirectory adds or removes a directory from the pinned list.
 func (s *Model) TogglePinnedDirectory(dir string) error {
+	if s.disabled {
+		return errors.New("sidebar is disabled")
+	}
 	return s.pinnedMgr.Toggle(dir)
 }
 
@@ -83,6 +83,26 @@ func ZoxideRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
 	return PromptRenderer(totalHeight, totalWidth)
 }
 
+func CompressModalRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return PromptRenderer(totalHeight, totalWidth)
+}
+
+func InputModalRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return PromptRenderer(totalHeight, totalWidth)
+}
+
+func ProcessHistoryRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return PromptRenderer(totalHeight, totalWidth)
+}
+
+func ProcessDetailRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return PromptRenderer(totalHeight, totalWidth)
+}
+
+func CommandOutputRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
+	return PromptRenderer(totalHeight, totalWidth)
+}
+
 func HelpMenuRenderer(totalHeight int, totalWidth int) *rendering.Renderer {
 	cfg := rendering.DefaultRendererConfig(totalHeight, totalWidth)
 	cfg.ContentFGColor = common.ModalFGColor
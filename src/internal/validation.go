@@ -87,10 +87,10 @@ func (m *model) validateLayout() error { //nolint:gocognit // cumilation of vali
 				"file panel %v error : %w", i, err)
 		}
 
-		// Validate search bar width matches panel width minus padding
-		if panel.SearchBar.Width != panel.GetWidth()-common.InnerPadding {
+		// Validate search bar width matches panel width minus padding and search indicator
+		if panel.SearchBar.Width != panel.SearchBarWidth() {
 			return fmt.Errorf("file panel %v search bar width mismatch: expected %v, got %v",
-				i, panel.GetWidth()-common.InnerPadding, panel.SearchBar.Width)
+				i, panel.SearchBarWidth(), panel.SearchBar.Width)
 		}
 	}
 
@@ -0,0 +1,567 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bodgit/sevenzip"
+	"github.com/nwaples/rardecode"
+
+	"github.com/yorukot/superfile/src/pkg/cache"
+)
+
+// Archives can be browsed like directories. A path inside an archive is the
+// path of the archive file joined with the entry's path inside of it, like
+// /home/user/backup.tar.gz/etc/hosts. Such paths are called virtual paths here.
+
+const (
+	listingCacheSize       = 16
+	listingCacheExpiration = 10 * time.Minute
+)
+
+var ErrEntryNotFound = errors.New("entry not found in archive")
+
+// Listing of all archive entries is read once and kept in cache. For tar based
+// formats listing requires decompressing the whole archive, which is slow.
+//
+//nolint:gochecknoglobals // Lazily initialized singleton
+var getListingCache = sync.OnceValue(func() *cache.Cache[*listing] {
+	return cache.New[*listing](listingCacheSize, listingCacheExpiration)
+})
+
+// walkFunc is called for every entry in an archive. name is the slash separated
+// path of the entry, cleaned unless walking with walkRaw. open is only valid
+// during the call.
+type walkFunc func(name string, info fs.FileInfo, open func() (io.ReadCloser, error)) error
+
+// errStopWalk can be returned from a walkFunc to stop walking without an error
+var errStopWalk = errors.New("stop walk")
+
+type listing struct {
+	// key is the entry name
+	entries map[string]fs.FileInfo
+	// key is the directory name, "" for the root of archive. Entries are sorted by name
+	children map[string][]fs.DirEntry
+	summary  Summary
+}
+
+// Summary describes the contents of an archive
+type Summary struct {
+	// Count of all entries, including the directories
+	EntryCount       int
+	UncompressedSize int64
+	// Size of the archive file
+	CompressedSize int64
+}
+
+// Ratio returns the compressed size as a fraction of the uncompressed size.
+// ok is false for archives without any content
+func (s Summary) Ratio() (float64, bool) {
+	if s.UncompressedSize == 0 {
+		return 0, false
+	}
+	return float64(s.CompressedSize) / float64(s.UncompressedSize), true
+}
+
+// SplitPath splits path into the path of the archive file and the slash
+// separated path of the entry inside it. inner is empty when path is the
+// archive itself. ok is false if path is not an archive or inside of one.
+func SplitPath(path string) (archivePath string, inner string, ok bool) {
+	path = filepath.Clean(path)
+	for candidate := path; ; {
+		if info, err := os.Stat(candidate); err == nil {
+			if !info.Mode().IsRegular() || !DetectFormat(candidate).Browsable() {
+				return "", "", false
+			}
+			rel, err := filepath.Rel(candidate, path)
+			if err != nil {
+				return "", "", false
+			}
+			if rel == "." {
+				rel = ""
+			}
+			return candidate, filepath.ToSlash(rel), true
+		}
+		parent := filepath.Dir(candidate)
+		if parent == candidate {
+			return "", "", false
+		}
+		candidate = parent
+	}
+}
+
+// InArchive reports whether path points to an entry inside an archive
+func InArchive(path string) bool {
+	_, inner, ok := SplitPath(path)
+	return ok && inner != ""
+}
+
+// ReadDir is like os.ReadDir, but also reads directories inside archives, with
+// the archive itself being treated as the root directory.
+func ReadDir(path string) ([]fs.DirEntry, error) {
+	entries, err := os.ReadDir(path)
+	if err == nil {
+		return entries, nil
+	}
+	archivePath, inner, ok := SplitPath(path)
+	if !ok {
+		return nil, err
+	}
+	l, err := getListing(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if info, exists := l.entries[inner]; inner != "" && (!exists || !info.IsDir()) {
+		return nil, fmt.Errorf("%s is not a directory in archive", path)
+	}
+	// Listing is shared via the cache, callers must not be able to modify it
+	return slices.Clone(l.children[inner]), nil
+}
+
+// Stat is like os.Stat, but also works for entries inside archives
+func Stat(path string) (fs.FileInfo, error) {
+	info, err := os.Stat(path)
+	if err == nil {
+		return info, nil
+	}
+	if entryInfo, entryErr := statEntry(path); entryErr == nil {
+		return entryInfo, nil
+	}
+	return nil, err
+}
+
+// Lstat is like os.Lstat, but also works for entries inside archives
+func Lstat(path string) (fs.FileInfo, error) {
+	info, err := os.Lstat(path)
+	if err == nil {
+		return info, nil
+	}
+	if entryInfo, entryErr := statEntry(path); entryErr == nil {
+		return entryInfo, nil
+	}
+	return nil, err
+}
+
+func statEntry(path string) (fs.FileInfo, error) {
+	archivePath, inner, ok := SplitPath(path)
+	if !ok || inner == "" {
+		return nil, ErrEntryNotFound
+	}
+	l, err := getListing(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	info, exists := l.entries[inner]
+	if !exists {
+		return nil, fmt.Errorf("%w : %s", ErrEntryNotFound, inner)
+	}
+	return info, nil
+}
+
+// GetSummary returns the summary of the archive at archivePath
+func GetSummary(archivePath string) (Summary, error) {
+	l, err := getListing(archivePath)
+	if err != nil {
+		return Summary{}, err
+	}
+	return l.summary, nil
+}
+
+// CountFiles returns the count of non directory entries at or under the
+// entry at path. For the archive itself, all of its files are counted
+func CountFiles(path string) (int, error) {
+	archivePath, inner, ok := SplitPath(path)
+	if !ok {
+		return 0, fmt.Errorf("%s is not inside an archive", path)
+	}
+	l, err := getListing(archivePath)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for name, info := range l.entries {
+		if !info.IsDir() && isUnder(name, inner) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Extract writes the entry at path, which must be inside an archive, to target
+// on the disk. Directories are extracted along with all of their contents.
+// onFile, if not nil, is called with the name of every extracted file.
+func Extract(path string, target string, onFile func(name string)) error {
+	archivePath, inner, ok := SplitPath(path)
+	if !ok || inner == "" {
+		return fmt.Errorf("%s is not inside an archive", path)
+	}
+	found := false
+	err := walk(archivePath, func(name string, info fs.FileInfo, open func() (io.ReadCloser, error)) error {
+		if !isUnder(name, inner) {
+			return nil
+		}
+		found = true
+		rel := strings.TrimPrefix(strings.TrimPrefix(name, inner), "/")
+		entryTarget := filepath.Join(target, filepath.FromSlash(rel))
+		if err := extractEntry(info, open, entryTarget); err != nil {
+			return err
+		}
+		if onFile != nil && !info.IsDir() {
+			onFile(name)
+		}
+		// Single file entry can't have anything else under it
+		if rel == "" && !info.IsDir() {
+			return errStopWalk
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		// Directories that are implied by their children, but have no entry of their own
+		if _, err = statEntry(path); err != nil {
+			return err
+		}
+		return os.MkdirAll(target, dirModeForParents)
+	}
+	return nil
+}
+
+func extractEntry(info fs.FileInfo, open func() (io.ReadCloser, error), target string) error {
+	mode := info.Mode()
+	switch {
+	case mode.IsDir():
+		return os.MkdirAll(target, mode.Perm()|0o700) //nolint:mnd // Owner must be able to write into it
+	case mode&fs.ModeSymlink != 0:
+		link, err := readLinkTarget(info, open)
+		if err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(target), dirModeForParents); err != nil {
+			return err
+		}
+		return os.Symlink(link, target)
+	case isTarHardLink(info):
+		// Hard links are skipped
+		return nil
+	case mode.IsRegular():
+		if err := os.MkdirAll(filepath.Dir(target), dirModeForParents); err != nil {
+			return err
+		}
+		reader, err := open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		return writeFile(target, reader, mode.Perm())
+	default:
+		return nil
+	}
+}
+
+// tar keeps the link target in the header, zip and 7z store it as the content
+func readLinkTarget(info fs.FileInfo, open func() (io.ReadCloser, error)) (string, error) {
+	if header, ok := info.Sys().(*tar.Header); ok {
+		return header.Linkname, nil
+	}
+	reader, err := open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	link, err := io.ReadAll(reader)
+	return string(link), err
+}
+
+func getListing(archivePath string) (*listing, error) {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	key := archivePath + "|" + strconv.FormatInt(info.ModTime().UnixNano(), 10) +
+		"|" + strconv.FormatInt(info.Size(), 10)
+	if l, ok := getListingCache().Get(key); ok {
+		return l, nil
+	}
+	l, err := readListing(archivePath, info.ModTime())
+	if err != nil {
+		return nil, err
+	}
+	l.summary.CompressedSize = info.Size()
+	getListingCache().Set(key, l)
+	return l, nil
+}
+
+func readListing(archivePath string, modTime time.Time) (*listing, error) {
+	l := &listing{
+		entries:  make(map[string]fs.FileInfo),
+		children: make(map[string][]fs.DirEntry),
+	}
+	err := walk(archivePath, func(name string, info fs.FileInfo, _ func() (io.ReadCloser, error)) error {
+		l.entries[name] = info
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Many archives don't have entries for directories
+	for name := range l.entries {
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			if _, exists := l.entries[dir]; exists {
+				break
+			}
+			l.entries[dir] = dirInfo{name: path.Base(dir), modTime: modTime}
+		}
+	}
+	for name, info := range l.entries {
+		if !info.IsDir() {
+			l.summary.UncompressedSize += info.Size()
+		}
+		parent := path.Dir(name)
+		if parent == "." {
+			parent = ""
+		}
+		l.children[parent] = append(l.children[parent], fs.FileInfoToDirEntry(info))
+	}
+	l.summary.EntryCount = len(l.entries)
+	for _, entries := range l.children {
+		slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+			return strings.Compare(a.Name(), b.Name())
+		})
+	}
+	return l, nil
+}
+
+// walk calls fn for every entry in the archive with cleaned entry names.
+// Encrypted archives are read with the password kept for the session
+func walk(archivePath string, fn walkFunc) error {
+	return walkRaw(
+		archivePath,
+		SessionPassword(archivePath),
+		func(rawName string, info fs.FileInfo, open func() (io.ReadCloser, error)) error {
+			name, ok := cleanEntryName(rawName)
+			if !ok {
+				return nil
+			}
+			return fn(name, info, open)
+		},
+	)
+}
+
+// walkRaw calls fn for every entry in the archive with names as they are stored
+// in the archive, only with the separators normalized. password is only
+// used for encrypted archives.
+func walkRaw(archivePath string, password string, fn walkFunc) error {
+	format := DetectFormat(archivePath)
+	var err error
+	switch {
+	case format == Zip:
+		err = walkZip(archivePath, password, fn)
+	case format == SevenZip:
+		err = walkSevenZip(archivePath, password, fn)
+	case format == Rar:
+		err = walkRar(archivePath, password, fn)
+	case format.IsTar():
+		err = walkTar(archivePath, format, fn)
+	default:
+		err = fmt.Errorf("archive format of %s can't be browsed", archivePath)
+	}
+	if errors.Is(err, errStopWalk) {
+		return nil
+	}
+	return err
+}
+
+func walkZip(archivePath string, password string, fn walkFunc) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		open := file.Open
+		if file.Flags&zipFlagEncrypted != 0 {
+			open = func() (io.ReadCloser, error) {
+				return openEncryptedZipFile(file, password)
+			}
+		}
+		if err = fn(file.Name, file.FileInfo(), open); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkSevenZip(archivePath string, password string, fn walkFunc) error {
+	reader, err := sevenzip.OpenReaderWithPassword(archivePath, password)
+	if err != nil {
+		return passwordError(err, password)
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		open := func() (io.ReadCloser, error) {
+			fileReader, err := file.Open()
+			if err != nil {
+				return nil, passwordError(err, password)
+			}
+			return &passwordErrorReader{ReadCloser: fileReader, password: password}, nil
+		}
+		if err = fn(strings.ReplaceAll(file.Name, "\\", "/"), file.FileInfo(), open); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkRar(archivePath string, password string, fn walkFunc) error {
+	reader, err := rardecode.OpenReader(archivePath, password)
+	if err != nil {
+		return passwordError(err, password)
+	}
+	defer reader.Close()
+	open := func() (io.ReadCloser, error) {
+		return &passwordErrorReader{ReadCloser: io.NopCloser(reader), password: password}, nil
+	}
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return passwordError(err, password)
+		}
+		if err = fn(header.Name, rarFileInfo{header}, open); err != nil {
+			return err
+		}
+	}
+}
+
+func walkTar(archivePath string, format Format, fn walkFunc) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decompressor, err := NewDecompressor(file, format)
+	if err != nil {
+		return err
+	}
+	defer decompressor.Close()
+
+	reader := tar.NewReader(decompressor)
+	open := func() (io.ReadCloser, error) {
+		return io.NopCloser(reader), nil
+	}
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+		if err = fn(header.Name, header.FileInfo(), open); err != nil {
+			return err
+		}
+	}
+}
+
+// Errors of the 7z and rar readers that are caused by the password. The
+// libraries don't export them, so they are matched by their exact message
+// along the error chain. A wrong 7z password only shows up as a checksum error.
+const (
+	sevenZipNoPasswordMsg = "aes7z: no password set"
+	sevenZipChecksumMsg   = "sevenzip: checksum error"
+	rarBadPasswordMsg     = "rardecode: incorrect password"
+)
+
+func hasErrorMessage(err error, msg string) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if err.Error() == msg {
+			return true
+		}
+	}
+	return false
+}
+
+// passwordError converts the errors of 7z and rar readers about encryption.
+// Other errors are returned unchanged.
+func passwordError(err error, password string) error {
+	switch {
+	case hasErrorMessage(err, sevenZipNoPasswordMsg),
+		password == "" && hasErrorMessage(err, rarBadPasswordMsg):
+		return fmt.Errorf("%w : %w", ErrPasswordRequired, err)
+	case password != "" && (hasErrorMessage(err, rarBadPasswordMsg) || hasErrorMessage(err, sevenZipChecksumMsg)):
+		return fmt.Errorf("%w : %w", ErrWrongPassword, err)
+	default:
+		return err
+	}
+}
+
+type passwordErrorReader struct {
+	io.ReadCloser
+	password string
+}
+
+func (p *passwordErrorReader) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	return n, passwordError(err, p.password)
+}
+
+// cleanEntryName normalizes entry names like "./dir/", "/dir" or "dir//file".
+// Names trying to go above the root are kept inside it, as this is only
+// used for browsing. See safeEntryName for extraction. ok is false for the
+// root itself.
+func cleanEntryName(name string) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	return name, name != ""
+}
+
+// isUnder reports whether name is dir itself or an entry inside of it. Every
+// entry is under the root, dir ""
+func isUnder(name string, dir string) bool {
+	return dir == "" || name == dir || strings.HasPrefix(name, dir+"/")
+}
+
+// dirInfo is the info of directories that don't have an entry in the archive
+type dirInfo struct {
+	name    string
+	modTime time.Time
+}
+
+func (d dirInfo) Name() string       { return d.name }
+func (d dirInfo) Size() int64        { return 0 }
+func (d dirInfo) Mode() fs.FileMode  { return fs.ModeDir | dirModeForParents }
+func (d dirInfo) ModTime() time.Time { return d.modTime }
+func (d dirInfo) IsDir() bool        { return true }
+func (d dirInfo) Sys() any           { return nil }
+
+// rar headers don't provide an fs.FileInfo of their own
+type rarFileInfo struct {
+	header *rardecode.FileHeader
+}
+
+func (r rarFileInfo) Name() string       { return path.Base(r.header.Name) }
+func (r rarFileInfo) Size() int64        { return r.header.UnPackedSize }
+func (r rarFileInfo) Mode() fs.FileMode  { return r.header.Mode() }
+func (r rarFileInfo) ModTime() time.Time { return r.header.ModificationTime }
+func (r rarFileInfo) IsDir() bool        { return r.header.IsDir }
+func (r rarFileInfo) Sys() any           { return r.header }
@@ -0,0 +1,209 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// createZip writes a zip with the given entries, without any directory entries
+func TestSplitPath(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "test.zip")
+	utils.SetupZip(t, archivePath, map[string]string{"a/b.txt": "b"})
+
+	testdata := []struct {
+		name            string
+		path            string
+		expectedArchive string
+		expectedInner   string
+		expectedOk      bool
+	}{
+		{"Archive itself", archivePath, archivePath, "", true},
+		{"Entry inside archive", filepath.Join(archivePath, "a", "b.txt"), archivePath, "a/b.txt", true},
+		{"Regular directory", dir, "", "", false},
+		{"Missing file in directory", filepath.Join(dir, "missing", "x"), "", "", false},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			archive, inner, ok := SplitPath(tt.path)
+			assert.Equal(t, tt.expectedArchive, archive)
+			assert.Equal(t, tt.expectedInner, inner)
+			assert.Equal(t, tt.expectedOk, ok)
+		})
+	}
+	assert.False(t, InArchive(archivePath))
+	assert.True(t, InArchive(filepath.Join(archivePath, "a")))
+}
+
+func TestBrowseZip(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "test.zip")
+	utils.SetupZip(t, archivePath, map[string]string{
+		"top.txt":       "top",
+		"dir/file1.txt": "file1",
+		"dir/sub/f.txt": "f",
+	})
+
+	t.Run("ReadDir at root", func(t *testing.T) {
+		entries, err := ReadDir(archivePath)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "dir", entries[0].Name())
+		assert.True(t, entries[0].IsDir(), "implied directory should be listed as directory")
+		assert.Equal(t, "top.txt", entries[1].Name())
+	})
+
+	t.Run("ReadDir of nested directory", func(t *testing.T) {
+		entries, err := ReadDir(filepath.Join(archivePath, "dir"))
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "file1.txt", entries[0].Name())
+		assert.Equal(t, "sub", entries[1].Name())
+	})
+
+	t.Run("ReadDir of file fails", func(t *testing.T) {
+		_, err := ReadDir(filepath.Join(archivePath, "top.txt"))
+		require.Error(t, err)
+	})
+
+	t.Run("Stat entries", func(t *testing.T) {
+		info, err := Stat(filepath.Join(archivePath, "dir", "file1.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "file1.txt", info.Name())
+		assert.Equal(t, int64(len("file1")), info.Size())
+		_, err = Lstat(filepath.Join(archivePath, "missing"))
+		require.Error(t, err)
+	})
+
+	t.Run("CountFiles", func(t *testing.T) {
+		count, err := CountFiles(filepath.Join(archivePath, "dir"))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		count, err = CountFiles(archivePath)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Extract file", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "out.txt")
+		require.NoError(t, Extract(filepath.Join(archivePath, "top.txt"), target, nil))
+		data, err := os.ReadFile(target)
+		require.NoError(t, err)
+		assert.Equal(t, "top", string(data))
+	})
+
+	t.Run("Extract directory", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "dir")
+		var extracted []string
+		require.NoError(t, Extract(filepath.Join(archivePath, "dir"), target, func(name string) {
+			extracted = append(extracted, name)
+		}))
+		assert.ElementsMatch(t, []string{"dir/file1.txt", "dir/sub/f.txt"}, extracted)
+		data, err := os.ReadFile(filepath.Join(target, "sub", "f.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "f", string(data))
+		_, err = os.Stat(filepath.Join(target, "top.txt"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestBrowseTarGz(t *testing.T) {
+	srcDir := t.TempDir()
+	file := filepath.Join(srcDir, "sub", "file.txt")
+	utils.SetupDirectories(t, filepath.Dir(file))
+	utils.SetupFilesWithData(t, []byte("content"), file)
+
+	archivePath := filepath.Join(t.TempDir(), "test.tar.gz")
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w, err := NewWriter(f, TarGz, DefaultLevel)
+	require.NoError(t, err)
+	for _, path := range []string{filepath.Dir(file), file} {
+		info, err := os.Lstat(path)
+		require.NoError(t, err)
+		rel, err := filepath.Rel(srcDir, path)
+		require.NoError(t, err)
+		require.NoError(t, w.AddFile(path, rel, info))
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	entries, err := ReadDir(filepath.Join(archivePath, "sub"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "file.txt", entries[0].Name())
+
+	target := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, Extract(filepath.Join(archivePath, "sub", "file.txt"), target, nil))
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+}
+
+func TestGetSummary(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "test.zip")
+	utils.SetupZip(t, archivePath, map[string]string{
+		"a.txt":     "aaaa",
+		"dir/b.txt": "bb",
+	})
+	info, err := os.Stat(archivePath)
+	require.NoError(t, err)
+
+	summary, err := GetSummary(archivePath)
+	require.NoError(t, err)
+	// "dir" is counted too, even though it has no entry of its own
+	assert.Equal(t, 3, summary.EntryCount)
+	assert.Equal(t, int64(6), summary.UncompressedSize)
+	assert.Equal(t, info.Size(), summary.CompressedSize)
+	ratio, ok := summary.Ratio()
+	assert.True(t, ok)
+	assert.InDelta(t, float64(info.Size())/6, ratio, 0.0001)
+
+	_, ok = Summary{}.Ratio()
+	assert.False(t, ok)
+}
+
+func TestPasswordError(t *testing.T) {
+	// Errors like the ones of the 7z and rar readers
+	noPassword := fmt.Errorf("reading file : %w", errors.New(sevenZipNoPasswordMsg))
+	checksum := errors.New(sevenZipChecksumMsg)
+	badPassword := errors.New(rarBadPasswordMsg)
+	corrupt := errors.New("rardecode: corrupt block header")
+
+	testdata := []struct {
+		name     string
+		err      error
+		password string
+		expected error
+	}{
+		{"No error", nil, "", nil},
+		{"No 7z password", noPassword, "", ErrPasswordRequired},
+		{"No rar password", badPassword, "", ErrPasswordRequired},
+		{"Wrong rar password", badPassword, "secret", ErrWrongPassword},
+		{"Wrong 7z password", checksum, "secret", ErrWrongPassword},
+		{"Corrupt 7z without password", checksum, "", checksum},
+		{"Other errors are kept", corrupt, "secret", corrupt},
+		{"End of file", io.EOF, "secret", io.EOF},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			err := passwordError(tt.err, tt.password)
+			if tt.expected == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expected)
+			if !errors.Is(tt.expected, ErrWrongPassword) && !errors.Is(tt.expected, ErrPasswordRequired) {
+				assert.NotErrorIs(t, err, ErrWrongPassword, "Only password errors should be converted")
+			}
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // Required by the WinZip AES specification
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Encrypted zip entries use either the traditional PKWARE encryption, called
+// ZipCrypto, or the WinZip AES encryption. Both can be read, but only AES is
+// used for writing, as ZipCrypto is easily broken.
+// See https://www.winzip.com/en/support/aes-encryption/
+
+const (
+	zipFlagEncrypted      = 0x1
+	zipFlagDataDescriptor = 0x8
+
+	zipMethodAES   = 99
+	zipVersionAES  = 51
+	zipExtraAESID  = 0x9901
+	zipExtraAESLen = 7
+	// AE-1 keeps the CRC of the content, unlike AE-2
+	aesVendorVersion1 = 1
+	aesVendorID       = "AE"
+	aesStrength256    = 3
+
+	aesKeyDerivationIterations = 1000
+	aesVerifierLen             = 2
+	aesAuthCodeLen             = 10
+
+	zipCryptoHeaderLen = 12
+)
+
+var (
+	ErrPasswordRequired = errors.New("archive is encrypted, password required")
+	ErrWrongPassword    = errors.New("wrong password")
+
+	ErrEncryptionNotSupported = errors.New("encryption is only supported for zip archives")
+)
+
+// Passwords the user chose to keep for the session, keyed by archive path
+var sessionPasswords sync.Map //nolint:gochecknoglobals // Shared by all archive operations of the session
+
+// SetSessionPassword keeps password for archivePath, so that browsing and
+// extracting it doesn't need the password again until superfile exits.
+// An empty password forgets the kept one.
+func SetSessionPassword(archivePath string, password string) {
+	if password == "" {
+		sessionPasswords.Delete(filepath.Clean(archivePath))
+		return
+	}
+	sessionPasswords.Store(filepath.Clean(archivePath), password)
+}
+
+// SessionPassword returns the password kept for archivePath, or ""
+func SessionPassword(archivePath string) string {
+	if password, ok := sessionPasswords.Load(filepath.Clean(archivePath)); ok {
+		if s, isString := password.(string); isString {
+			return s
+		}
+	}
+	return ""
+}
+
+// IsEncrypted reports whether extracting the archive needs a password
+func IsEncrypted(archivePath string) (bool, error) {
+	format := DetectFormat(archivePath)
+	switch {
+	case format == Zip:
+		reader, err := zip.OpenReader(archivePath)
+		if err != nil {
+			return false, err
+		}
+		defer reader.Close()
+		for _, file := range reader.File {
+			if file.Flags&zipFlagEncrypted != 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	case format == SevenZip || format == Rar:
+		// There is no flag to check, reading the first file tells
+		err := walkRaw(archivePath, "", func(_ string, info fs.FileInfo, open func() (io.ReadCloser, error)) error {
+			if !info.Mode().IsRegular() || info.Size() == 0 {
+				return nil
+			}
+			reader, err := open()
+			if err != nil {
+				return err
+			}
+			defer reader.Close()
+			if _, err = reader.Read(make([]byte, 1)); err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+			return errStopWalk
+		})
+		if errors.Is(err, ErrPasswordRequired) {
+			return true, nil
+		}
+		return false, err
+	default:
+		return false, nil
+	}
+}
+
+// openEncryptedZipFile decrypts and decompresses an encrypted zip entry
+func openEncryptedZipFile(file *zip.File, password string) (io.ReadCloser, error) {
+	if password == "" {
+		return nil, fmt.Errorf("%w : %s", ErrPasswordRequired, file.Name)
+	}
+	raw, err := file.OpenRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	method := file.Method
+	checkCRC := true
+	var decrypted io.Reader
+	if file.Method == zipMethodAES {
+		extra, ok := parseAESExtra(file.Extra)
+		if !ok {
+			return nil, fmt.Errorf("invalid AES encryption field : %s", file.Name)
+		}
+		method = extra.method
+		checkCRC = extra.version == aesVendorVersion1
+		decrypted, err = newAESReader(raw, password, extra.strength, file.CompressedSize64)
+	} else {
+		decrypted, err = newZipCryptoReader(raw, password, zipCryptoCheckByte(file))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w : %s", err, file.Name)
+	}
+
+	var reader io.ReadCloser
+	switch method {
+	case zip.Store:
+		reader = io.NopCloser(decrypted)
+	case zip.Deflate:
+		reader = flate.NewReader(decrypted)
+	default:
+		return nil, fmt.Errorf("%w : method %d", zip.ErrAlgorithm, method)
+	}
+	if checkCRC {
+		reader = &crcReader{ReadCloser: reader, hash: crc32.NewIEEE(), expected: file.CRC32}
+	}
+	if file.Method != zipMethodAES {
+		reader = &zipCryptoErrorReader{ReadCloser: reader}
+	}
+	return reader, nil
+}
+
+// zipCryptoErrorReader reports corrupted content as a wrong password. The
+// password check of ZipCrypto is a single byte, so about 1 in 256 wrong
+// passwords are only detected by the decompression or by the CRC
+type zipCryptoErrorReader struct {
+	io.ReadCloser
+}
+
+func (z *zipCryptoErrorReader) Read(p []byte) (int, error) {
+	n, err := z.ReadCloser.Read(p)
+	var corruptErr flate.CorruptInputError
+	if errors.Is(err, zip.ErrChecksum) || errors.As(err, &corruptErr) {
+		return n, fmt.Errorf("%w : %w", ErrWrongPassword, err)
+	}
+	return n, err
+}
+
+// crcReader fails at the end of the content if its CRC doesn't match
+type crcReader struct {
+	io.ReadCloser
+	hash     hash.Hash32
+	expected uint32
+}
+
+func (c *crcReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.hash.Write(p[:n])
+	if errors.Is(err, io.EOF) && c.hash.Sum32() != c.expected {
+		return n, zip.ErrChecksum
+	}
+	return n, err
+}
+
+// ZipCrypto
+
+type zipCryptoKeys [3]uint32
+
+func newZipCryptoKeys(password string) *zipCryptoKeys {
+	k := &zipCryptoKeys{0x12345678, 0x23456789, 0x34567890}
+	for _, b := range []byte(password) {
+		k.update(b)
+	}
+	return k
+}
+
+func (k *zipCryptoKeys) update(b byte) {
+	k[0] = crc32Update(k[0], b)
+	k[1] = (k[1]+(k[0]&0xff))*134775813 + 1  //nolint:mnd // Constants of the algorithm
+	k[2] = crc32Update(k[2], byte(k[1]>>24)) //nolint:mnd // Highest byte
+}
+
+func (k *zipCryptoKeys) decrypt(b byte) byte {
+	temp := k[2] | 2                      //nolint:mnd // Constants of the algorithm
+	plain := b ^ byte((temp*(temp^1))>>8) //nolint:mnd // Constants of the algorithm
+	k.update(plain)
+	return plain
+}
+
+func crc32Update(crc uint32, b byte) uint32 {
+	return crc32.IEEETable[byte(crc)^b] ^ (crc >> 8) //nolint:mnd // Shift by a byte
+}
+
+// The last byte of the encryption header is used to verify the password. It
+// is the high byte of the CRC, or of the modification time when the CRC is
+// only written after the content.
+func zipCryptoCheckByte(file *zip.File) byte {
+	if file.Flags&zipFlagDataDescriptor != 0 {
+		return byte(file.ModifiedTime >> 8) //nolint:staticcheck,mnd // MS-DOS time is what the check uses
+	}
+	return byte(file.CRC32 >> 24) //nolint:mnd // Highest byte
+}
+
+type zipCryptoReader struct {
+	reader io.Reader
+	keys   *zipCryptoKeys
+}
+
+func newZipCryptoReader(raw io.Reader, password string, checkByte byte) (io.Reader, error) {
+	keys := newZipCryptoKeys(password)
+	header := make([]byte, zipCryptoHeaderLen)
+	if _, err := io.ReadFull(raw, header); err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = keys.decrypt(header[i])
+	}
+	if header[zipCryptoHeaderLen-1] != checkByte {
+		return nil, ErrWrongPassword
+	}
+	return &zipCryptoReader{reader: raw, keys: keys}, nil
+}
+
+func (z *zipCryptoReader) Read(p []byte) (int, error) {
+	n, err := z.reader.Read(p)
+	for i := range n {
+		p[i] = z.keys.decrypt(p[i])
+	}
+	return n, err
+}
+
+// WinZip AES
+
+type aesExtra struct {
+	version  uint16
+	strength byte
+	method   uint16
+}
+
+func parseAESExtra(extra []byte) (aesExtra, bool) {
+	for len(extra) >= 4 {
+		id := binary.LittleEndian.Uint16(extra)
+		size := int(binary.LittleEndian.Uint16(extra[2:]))
+		extra = extra[4:]
+		if size > len(extra) {
+			return aesExtra{}, false
+		}
+		if id == zipExtraAESID && size == zipExtraAESLen && string(extra[2:4]) == aesVendorID {
+			return aesExtra{
+				version:  binary.LittleEndian.Uint16(extra),
+				strength: extra[4],
+				method:   binary.LittleEndian.Uint16(extra[5:]),
+			}, true
+		}
+		extra = extra[size:]
+	}
+	return aesExtra{}, false
+}
+
+func newAESExtra(method uint16) []byte {
+	extra := make([]byte, 0, 4+zipExtraAESLen) //nolint:mnd // Header of the field
+	extra = binary.LittleEndian.AppendUint16(extra, zipExtraAESID)
+	extra = binary.LittleEndian.AppendUint16(extra, zipExtraAESLen)
+	extra = binary.LittleEndian.AppendUint16(extra, aesVendorVersion1)
+	extra = append(extra, aesVendorID...)
+	extra = append(extra, aesStrength256)
+	return binary.LittleEndian.AppendUint16(extra, method)
+}
+
+// Key length in bytes for the strength in the extra field. Salt is half of it.
+func aesKeyLen(strength byte) (int, error) {
+	switch strength {
+	case 1, 2, 3: //nolint:mnd // 128, 192 and 256 bits
+		return 8 + 8*int(strength), nil //nolint:mnd // 16, 24 or 32 bytes
+	default:
+		return 0, fmt.Errorf("invalid AES strength %d", strength)
+	}
+}
+
+func deriveAESKeys(password string, salt []byte, keyLen int) ([]byte, []byte, []byte, error) {
+	key, err := pbkdf2.Key(sha1.New, password, salt, aesKeyDerivationIterations, 2*keyLen+aesVerifierLen)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return key[:keyLen], key[keyLen : 2*keyLen], key[2*keyLen:], nil
+}
+
+// winZipCTR is AES in counter mode, but with a little endian counter starting
+// at 1, unlike cipher.NewCTR
+type winZipCTR struct {
+	block     cipher.Block
+	counter   [aes.BlockSize]byte
+	keyStream [aes.BlockSize]byte
+	pos       int
+}
+
+func newWinZipCTR(key []byte) (*winZipCTR, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &winZipCTR{block: block, pos: aes.BlockSize}, nil
+}
+
+func (c *winZipCTR) XORKeyStream(dst, src []byte) {
+	for i := range src {
+		if c.pos == aes.BlockSize {
+			for j := range c.counter {
+				c.counter[j]++
+				if c.counter[j] != 0 {
+					break
+				}
+			}
+			c.block.Encrypt(c.keyStream[:], c.counter[:])
+			c.pos = 0
+		}
+		dst[i] = src[i] ^ c.keyStream[c.pos]
+		c.pos++
+	}
+}
+
+// aesReader decrypts the content, and verifies the authentication code at its end
+type aesReader struct {
+	raw  io.Reader
+	data io.Reader
+	ctr  *winZipCTR
+	mac  hash.Hash
+}
+
+func newAESReader(raw io.Reader, password string, strength byte, compressedSize uint64) (io.Reader, error) {
+	keyLen, err := aesKeyLen(strength)
+	if err != nil {
+		return nil, err
+	}
+	saltLen := keyLen / 2 //nolint:mnd // Salt is half of the key
+	header := make([]byte, saltLen+aesVerifierLen)
+	if _, err = io.ReadFull(raw, header); err != nil {
+		return nil, err
+	}
+	encKey, authKey, verifier, err := deriveAESKeys(password, header[:saltLen], keyLen)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(verifier, header[saltLen:]) {
+		return nil, ErrWrongPassword
+	}
+	dataLen := int64(compressedSize) - int64(len(header)) - aesAuthCodeLen //nolint:gosec // Sizes fit in int64
+	if dataLen < 0 {
+		return nil, zip.ErrFormat
+	}
+	ctr, err := newWinZipCTR(encKey)
+	if err != nil {
+		return nil, err
+	}
+	return &aesReader{
+		raw:  raw,
+		data: io.LimitReader(raw, dataLen),
+		ctr:  ctr,
+		mac:  hmac.New(sha1.New, authKey),
+	}, nil
+}
+
+func (a *aesReader) Read(p []byte) (int, error) {
+	n, err := a.data.Read(p)
+	a.mac.Write(p[:n])
+	a.ctr.XORKeyStream(p[:n], p[:n])
+	if errors.Is(err, io.EOF) {
+		authCode := make([]byte, aesAuthCodeLen)
+		if _, readErr := io.ReadFull(a.raw, authCode); readErr != nil {
+			return n, readErr
+		}
+		if !hmac.Equal(authCode, a.mac.Sum(nil)[:aesAuthCodeLen]) {
+			return n, zip.ErrChecksum
+		}
+	}
+	return n, err
+}
+
+// aesWriter encrypts everything written to it, and counts the written bytes
+type aesWriter struct {
+	writer  io.Writer
+	ctr     *winZipCTR
+	mac     hash.Hash
+	written int64
+}
+
+func (a *aesWriter) Write(p []byte) (int, error) {
+	encrypted := make([]byte, len(p))
+	a.ctr.XORKeyStream(encrypted, p)
+	a.mac.Write(encrypted)
+	n, err := a.writer.Write(encrypted)
+	a.written += int64(n)
+	return n, err
+}
+
+// addEncryptedFile writes the file at path as an AES-256 encrypted, deflate
+// compressed entry. Sizes and CRC are only known afterwards, so they go into
+// the data descriptor.
+func (z *zipWriter) addEncryptedFile(path string, header *zip.FileHeader) error {
+	header.Method = zipMethodAES
+	header.ReaderVersion = zipVersionAES
+	header.Flags |= zipFlagEncrypted | zipFlagDataDescriptor
+	header.Extra = append(header.Extra, newAESExtra(zip.Deflate)...)
+	raw, err := z.writer.CreateRaw(header)
+	if err != nil {
+		return err
+	}
+
+	keyLen, _ := aesKeyLen(aesStrength256)
+	salt := make([]byte, keyLen/2) //nolint:mnd // Salt is half of the key
+	if _, err = rand.Read(salt); err != nil {
+		return err
+	}
+	encKey, authKey, verifier, err := deriveAESKeys(z.password, salt, keyLen)
+	if err != nil {
+		return err
+	}
+	ctr, err := newWinZipCTR(encKey)
+	if err != nil {
+		return err
+	}
+	if _, err = raw.Write(append(bytes.Clone(salt), verifier...)); err != nil {
+		return err
+	}
+
+	encrypted := &aesWriter{writer: raw, ctr: ctr, mac: hmac.New(sha1.New, authKey)}
+	compressor, err := flate.NewWriter(encrypted, z.level)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	crc := crc32.NewIEEE()
+	size, err := io.Copy(io.MultiWriter(compressor, crc), file)
+	if err != nil {
+		return err
+	}
+	if err = compressor.Close(); err != nil {
+		return err
+	}
+	if _, err = raw.Write(encrypted.mac.Sum(nil)[:aesAuthCodeLen]); err != nil {
+		return err
+	}
+
+	header.CRC32 = crc.Sum32()
+	header.UncompressedSize64 = uint64(size) //nolint:gosec // Size is never negative
+	compressedSize := len(salt) + aesVerifierLen + int(encrypted.written) + aesAuthCodeLen
+	header.CompressedSize64 = uint64(compressedSize) //nolint:gosec // Same
+	header.UncompressedSize = uint32(min(header.UncompressedSize64, uint64(^uint32(0))))
+	header.CompressedSize = uint32(min(header.CompressedSize64, uint64(^uint32(0))))
+	return nil
+}
@@ -0,0 +1,228 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestEncryptedZipRoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	file := filepath.Join(srcDir, "secret.txt")
+	content := bytes.Repeat([]byte("confidential "), 100)
+	utils.SetupFilesWithData(t, content, file)
+
+	archivePath := filepath.Join(t.TempDir(), "secret.zip")
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w, err := NewWriterWithPassword(f, Zip, DefaultLevel, "hunter2")
+	require.NoError(t, err)
+	info, err := os.Lstat(file)
+	require.NoError(t, err)
+	require.NoError(t, w.AddFile(file, "secret.txt", info))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	encrypted, err := IsEncrypted(archivePath)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	_, err = ExtractArchive(archivePath, t.TempDir(), ExtractOptions{})
+	require.ErrorIs(t, err, ErrPasswordRequired)
+	_, err = ExtractArchive(archivePath, t.TempDir(), ExtractOptions{Password: "wrong"})
+	require.ErrorIs(t, err, ErrWrongPassword)
+
+	outDir := t.TempDir()
+	_, err = ExtractArchive(archivePath, outDir, ExtractOptions{Password: "hunter2"})
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(outDir, "secret.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	t.Run("Session password", func(t *testing.T) {
+		SetSessionPassword(archivePath, "hunter2")
+		t.Cleanup(func() { SetSessionPassword(archivePath, "") })
+		target := filepath.Join(t.TempDir(), "secret.txt")
+		require.NoError(t, Extract(filepath.Join(archivePath, "secret.txt"), target, nil))
+		data, err := os.ReadFile(target)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+	})
+}
+
+func TestNewWriterWithPasswordUnsupportedFormat(t *testing.T) {
+	_, err := NewWriterWithPassword(&bytes.Buffer{}, TarGz, DefaultLevel, "pass")
+	require.ErrorIs(t, err, ErrEncryptionNotSupported)
+}
+
+// createZipCryptoZip writes a zip with one stored entry, encrypted with ZipCrypto
+func createZipCryptoZip(t *testing.T, archivePath string, name string, content []byte, password string) {
+	t.Helper()
+	keys := newZipCryptoKeys(password)
+	encrypt := func(plain byte) byte {
+		temp := keys[2] | 2
+		cipherByte := plain ^ byte((temp*(temp^1))>>8)
+		keys.update(plain)
+		return cipherByte
+	}
+	crc := crc32.ChecksumIEEE(content)
+	plain := append(make([]byte, zipCryptoHeaderLen-1), byte(crc>>24))
+	plain = append(plain, content...)
+	encrypted := make([]byte, len(plain))
+	for i, b := range plain {
+		encrypted[i] = encrypt(b)
+	}
+
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	fw, err := w.CreateRaw(&zip.FileHeader{
+		Name:               name,
+		Method:             zip.Store,
+		Flags:              zipFlagEncrypted,
+		CRC32:              crc,
+		CompressedSize64:   uint64(len(encrypted)),
+		UncompressedSize64: uint64(len(content)),
+	})
+	require.NoError(t, err)
+	_, err = fw.Write(encrypted)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func TestZipCryptoExtract(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "legacy.zip")
+	createZipCryptoZip(t, archivePath, "a.txt", []byte("legacy content"), "pass")
+
+	_, err := ExtractArchive(archivePath, t.TempDir(), ExtractOptions{Password: "wrong"})
+	require.ErrorIs(t, err, ErrWrongPassword)
+
+	outDir := t.TempDir()
+	_, err = ExtractArchive(archivePath, outDir, ExtractOptions{Password: "pass"})
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(outDir, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "legacy content", string(data))
+}
+
+// The fixtures are made by other tools with the password "secret":
+// zip -P (Info-ZIP), and bsdtar with --options zip:encryption=...
+func TestExtractZipEncryptedByOtherTools(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "encrypted_content.txt"))
+	require.NoError(t, err)
+	tests := []struct {
+		fixture   string
+		entryName string
+	}{
+		{"zipcrypto_infozip.zip", "content.txt"},
+		// Written with -fd, so the sizes and the CRC are in a data descriptor
+		{"zipcrypto_descriptor_infozip.zip", "content.txt"},
+		{"zipcrypto_bsdtar.zip", "content.txt"},
+		{"aes128_bsdtar.zip", "content.txt"},
+		{"aes256_bsdtar.zip", "content.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			archivePath := filepath.Join("testdata", tt.fixture)
+			encrypted, err := IsEncrypted(archivePath)
+			require.NoError(t, err)
+			assert.True(t, encrypted)
+
+			_, err = ExtractArchive(archivePath, t.TempDir(), ExtractOptions{})
+			require.ErrorIs(t, err, ErrPasswordRequired)
+			_, err = ExtractArchive(archivePath, t.TempDir(), ExtractOptions{Password: "wrong"})
+			require.ErrorIs(t, err, ErrWrongPassword)
+
+			outDir := t.TempDir()
+			_, err = ExtractArchive(archivePath, outDir, ExtractOptions{Password: "secret"})
+			require.NoError(t, err)
+			data, err := os.ReadFile(filepath.Join(outDir, tt.entryName))
+			require.NoError(t, err)
+			assert.Equal(t, content, data)
+		})
+	}
+}
+
+// findCollidingPassword returns a wrong password that passes the one byte
+// password check of the first entry of a ZipCrypto archive
+func findCollidingPassword(t *testing.T, archivePath string, password string) string {
+	t.Helper()
+	r, err := zip.OpenReader(archivePath)
+	require.NoError(t, err)
+	defer r.Close()
+	file := r.File[0]
+	for i := range 10000 {
+		candidate := fmt.Sprintf("wrong%d", i)
+		if candidate == password {
+			continue
+		}
+		raw, err := file.OpenRaw()
+		require.NoError(t, err)
+		if _, err = newZipCryptoReader(raw, candidate, zipCryptoCheckByte(file)); err == nil {
+			return candidate
+		}
+	}
+	require.FailNow(t, "No colliding password found")
+	return ""
+}
+
+func TestZipCryptoWrongPasswordPassingCheck(t *testing.T) {
+	storedPath := filepath.Join(t.TempDir(), "stored.zip")
+	createZipCryptoZip(t, storedPath, "a.txt", []byte("legacy content"), "pass")
+	tests := []struct {
+		name        string
+		archivePath string
+		password    string
+	}{
+		{"Stored", storedPath, "pass"},
+		{"Deflated", filepath.Join("testdata", "zipcrypto_infozip.zip"), "secret"},
+		{"Deflated with data descriptor", filepath.Join("testdata", "zipcrypto_descriptor_infozip.zip"), "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrongPassword := findCollidingPassword(t, tt.archivePath, tt.password)
+			_, err := ExtractArchive(tt.archivePath, t.TempDir(), ExtractOptions{Password: wrongPassword})
+			require.ErrorIs(t, err, ErrWrongPassword)
+		})
+	}
+}
+
+// Archives written by superfile should be readable by other tools
+func TestEncryptedZipReadByBsdtar(t *testing.T) {
+	if _, err := exec.LookPath("bsdtar"); err != nil {
+		t.Skip("bsdtar is not installed")
+	}
+	srcDir := t.TempDir()
+	file := filepath.Join(srcDir, "secret.txt")
+	content := bytes.Repeat([]byte("confidential "), 100)
+	utils.SetupFilesWithData(t, content, file)
+
+	archivePath := filepath.Join(t.TempDir(), "secret.zip")
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w, err := NewWriterWithPassword(f, Zip, DefaultLevel, "hunter2")
+	require.NoError(t, err)
+	info, err := os.Lstat(file)
+	require.NoError(t, err)
+	require.NoError(t, w.AddFile(file, "secret.txt", info))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	outDir := t.TempDir()
+	output, err := exec.Command("bsdtar", "--passphrase", "hunter2", "-xf", archivePath, "-C", outDir).CombinedOutput()
+	require.NoError(t, err, string(output))
+	data, err := os.ReadFile(filepath.Join(outDir, "secret.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+}
@@ -0,0 +1,179 @@
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ExtractOptions configures ExtractArchive
+type ExtractOptions struct {
+	// Count of leading path components removed from every entry name, like
+	// tar's --strip-components. Entries with fewer components are skipped.
+	StripComponents int
+	// OnEntry, if not nil, is called after every extracted entry with its name.
+	// Extraction stops and returns the error if it returns one.
+	OnEntry func(name string, info fs.FileInfo) error
+	// Password for encrypted archives. The password kept for the session is
+	// used if empty
+	Password string
+}
+
+// ExtractReport lists entries that needed special handling during extraction
+type ExtractReport struct {
+	// Entries that were skipped for having an absolute path, or a path going
+	// above the output directory. Names are as stored in the archive.
+	Rejected []string
+	// Symlinks that were extracted, but point outside of the output directory.
+	// Entries that would be written through them are rejected.
+	// Names are as stored in the archive.
+	EscapingSymlinks []string
+}
+
+// HasIssues reports whether any entry was rejected or needs attention
+func (r ExtractReport) HasIssues() bool {
+	return len(r.Rejected) > 0 || len(r.EscapingSymlinks) > 0
+}
+
+// ExtractArchive extracts the whole archive at src into outputDir, entry by
+// entry. Permission bits stored in the archive are kept.
+// Entries that could end up outside of outputDir are never written, and are
+// listed in the report instead.
+func ExtractArchive(src string, outputDir string, opts ExtractOptions) (ExtractReport, error) {
+	var report ExtractReport
+	realOutputDir, err := filepath.EvalSymlinks(outputDir)
+	if err != nil {
+		return report, err
+	}
+	password := opts.Password
+	if password == "" {
+		password = SessionPassword(src)
+	}
+	err = walkRaw(src, password, func(rawName string, info fs.FileInfo, open func() (io.ReadCloser, error)) error {
+		name, ok := safeEntryName(rawName)
+		if !ok {
+			report.Rejected = append(report.Rejected, rawName)
+			return nil
+		}
+		if name, ok = stripComponents(name, opts.StripComponents); !ok || name == "" {
+			return nil
+		}
+		target := filepath.Join(realOutputDir, filepath.FromSlash(name))
+		// Catches entries that would be written through an escaping symlink
+		// extracted earlier
+		if !parentWithinDir(realOutputDir, target) {
+			report.Rejected = append(report.Rejected, rawName)
+			return nil
+		}
+		// Never write through a symlink of a duplicate entry
+		if existing, lstatErr := os.Lstat(target); lstatErr == nil && existing.Mode()&fs.ModeSymlink != 0 {
+			if err := os.Remove(target); err != nil {
+				return err
+			}
+		}
+
+		if info.Mode()&fs.ModeSymlink != 0 {
+			link, err := readLinkTarget(info, open)
+			if err != nil {
+				return err
+			}
+			if !symlinkWithinDir(realOutputDir, target, link) {
+				report.EscapingSymlinks = append(report.EscapingSymlinks, rawName)
+			}
+			if err = os.MkdirAll(filepath.Dir(target), dirModeForParents); err != nil {
+				return err
+			}
+			if err = os.Symlink(link, target); err != nil {
+				return err
+			}
+		} else if err := extractEntry(info, open, target); err != nil {
+			return err
+		}
+
+		if opts.OnEntry != nil {
+			return opts.OnEntry(name, info)
+		}
+		return nil
+	})
+	return report, err
+}
+
+// SingleTopLevelDir returns the name of the only top level entry of the archive,
+// if that is a directory
+func SingleTopLevelDir(archivePath string) (string, bool) {
+	l, err := getListing(archivePath)
+	if err != nil {
+		return "", false
+	}
+	root := l.children[""]
+	if len(root) != 1 || !root[0].IsDir() {
+		return "", false
+	}
+	return root[0].Name(), true
+}
+
+// safeEntryName cleans name, and reports whether it stays inside the output
+// directory. Absolute names and names going above the root are not safe.
+// The root itself, like "./", is safe and returned as "".
+func safeEntryName(name string) (string, bool) {
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", false
+	}
+	name = path.Clean(name)
+	if name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	if name == "." {
+		return "", true
+	}
+	return name, true
+}
+
+func stripComponents(name string, count int) (string, bool) {
+	for range count {
+		_, rest, found := strings.Cut(name, "/")
+		if !found {
+			return "", false
+		}
+		name = rest
+	}
+	return name, true
+}
+
+// symlinkWithinDir reports whether the symlink at target, pointing to link,
+// resolves to a location inside dir
+func symlinkWithinDir(dir string, target string, link string) bool {
+	if filepath.IsAbs(link) {
+		return isWithinDir(dir, link)
+	}
+	return isWithinDir(dir, filepath.Join(filepath.Dir(target), link))
+}
+
+// parentWithinDir reports whether the parent of target stays inside dir, with
+// the symlinks in its already existing part resolved
+func parentWithinDir(dir string, target string) bool {
+	for parent := filepath.Dir(target); ; {
+		resolved, err := filepath.EvalSymlinks(parent)
+		if err == nil {
+			return isWithinDir(dir, resolved)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return false
+		}
+		next := filepath.Dir(parent)
+		if next == parent {
+			return false
+		}
+		parent = next
+	}
+}
+
+func isTarHardLink(info fs.FileInfo) bool {
+	header, ok := info.Sys().(*tar.Header)
+	return ok && header.Typeflag == tar.TypeLink
+}
@@ -0,0 +1,116 @@
+package archive
+
+import (
+	"archive/tar"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// createTar writes a tar with the given headers. Regular files get their name
+// as the content
+func createTar(t *testing.T, archivePath string, headers []*tar.Header) {
+	t.Helper()
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w := tar.NewWriter(f)
+	for _, header := range headers {
+		content := []byte(header.Name)
+		if header.Typeflag == tar.TypeReg {
+			header.Size = int64(len(content))
+		}
+		require.NoError(t, w.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err = w.Write(content)
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func regHeader(name string) *tar.Header {
+	return &tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644}
+}
+
+func TestExtractArchiveUnsafeEntries(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "evil.tar")
+	createTar(t, archivePath, []*tar.Header{
+		regHeader("ok.txt"),
+		regHeader("../escape.txt"),
+		regHeader("dir/../../escape2.txt"),
+		regHeader("/abs.txt"),
+		{Name: "link", Typeflag: tar.TypeSymlink, Linkname: dir},
+		regHeader("link/through.txt"),
+		{Name: "inner", Typeflag: tar.TypeSymlink, Linkname: "ok.txt"},
+	})
+	outDir := filepath.Join(dir, "out")
+	require.NoError(t, os.Mkdir(outDir, 0o755))
+
+	report, err := ExtractArchive(archivePath, outDir, ExtractOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"../escape.txt", "dir/../../escape2.txt", "/abs.txt", "link/through.txt"},
+		report.Rejected)
+	assert.Equal(t, []string{"link"}, report.EscapingSymlinks)
+	assert.True(t, report.HasIssues())
+
+	assert.FileExists(t, filepath.Join(outDir, "ok.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "escape.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "escape2.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "through.txt"))
+	link, err := os.Readlink(filepath.Join(outDir, "inner"))
+	require.NoError(t, err)
+	assert.Equal(t, "ok.txt", link)
+}
+
+func TestExtractArchiveStripComponents(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "test.zip")
+	utils.SetupZip(t, archivePath, map[string]string{
+		"project/a.txt":     "a",
+		"project/sub/b.txt": "b",
+	})
+	top, ok := SingleTopLevelDir(archivePath)
+	require.True(t, ok)
+	assert.Equal(t, "project", top)
+
+	outDir := t.TempDir()
+	var extracted []string
+	_, err := ExtractArchive(archivePath, outDir, ExtractOptions{
+		StripComponents: 1,
+		OnEntry: func(name string, _ fs.FileInfo) error {
+			extracted = append(extracted, name)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a.txt", "sub/b.txt"}, extracted)
+	assert.FileExists(t, filepath.Join(outDir, "a.txt"))
+	assert.FileExists(t, filepath.Join(outDir, "sub", "b.txt"))
+}
+
+func TestExtractArchiveStop(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "test.tar")
+	createTar(t, archivePath, []*tar.Header{regHeader("a.txt"), regHeader("b.txt")})
+	errStop := errors.New("stop")
+
+	_, err := ExtractArchive(archivePath, dir, ExtractOptions{
+		OnEntry: func(string, fs.FileInfo) error {
+			return errStop
+		},
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.FileExists(t, filepath.Join(dir, "a.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "b.txt"))
+
+	_, ok := SingleTopLevelDir(archivePath)
+	assert.False(t, ok)
+}
@@ -0,0 +1,144 @@
+package archive
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type Format int
+
+// NOTE: Order of writable formats here is the order in which they are
+// offered in the compress modal
+const (
+	Zip Format = iota
+	Tar
+	TarGz
+	TarXz
+	TarZst
+	TarBz2
+	SevenZip
+	Rar
+	Unknown
+)
+
+const (
+	MinLevel     = 1
+	MaxLevel     = 9
+	DefaultLevel = 6
+)
+
+// formatExtensions maps each format to the extensions it is recognized by.
+// First extension is the one used for newly created archives
+var formatExtensions = map[Format][]string{ //nolint: gochecknoglobals // Effectively const
+	Zip:      {".zip"},
+	Tar:      {".tar"},
+	TarGz:    {".tar.gz", ".tgz"},
+	TarXz:    {".tar.xz", ".txz"},
+	TarZst:   {".tar.zst", ".tzst"},
+	TarBz2:   {".tar.bz2", ".tbz2", ".tbz"},
+	SevenZip: {".7z"},
+	Rar:      {".rar"},
+	Unknown:  {},
+}
+
+// WritableFormats returns the formats that superfile can create archives in.
+// tar.bz2 is missing as there is no bzip2 writer available, and 7z and rar
+// as there are no writers for them at all.
+func WritableFormats() []Format {
+	return []Format{Zip, Tar, TarGz, TarXz, TarZst}
+}
+
+func (f Format) String() string {
+	switch f {
+	case Zip:
+		return "zip"
+	case Tar:
+		return "tar"
+	case TarGz:
+		return "tar.gz"
+	case TarXz:
+		return "tar.xz"
+	case TarZst:
+		return "tar.zst"
+	case TarBz2:
+		return "tar.bz2"
+	case SevenZip:
+		return "7z"
+	case Rar:
+		return "rar"
+	case Unknown:
+		fallthrough
+	default:
+		return "unknown"
+	}
+}
+
+// Extension returns the extension used for new archives of this format
+func (f Format) Extension() string {
+	exts := formatExtensions[f]
+	if len(exts) == 0 {
+		return ""
+	}
+	return exts[0]
+}
+
+func (f Format) Writable() bool {
+	return slices.Contains(WritableFormats(), f)
+}
+
+// Browsable reports whether the entries of the format can be listed and read
+// without extracting the whole archive first
+func (f Format) Browsable() bool {
+	return f == Zip || f == SevenZip || f == Rar || f.IsTar()
+}
+
+// SupportsEncryption reports whether archives of the format can be created
+// with a password
+func (f Format) SupportsEncryption() bool {
+	return f == Zip
+}
+
+func (f Format) IsTar() bool {
+	switch f {
+	case Tar, TarGz, TarXz, TarZst, TarBz2:
+		return true
+	case Zip, SevenZip, Rar, Unknown:
+		return false
+	default:
+		return false
+	}
+}
+
+// SupportsLevel reports whether compression level has any effect for the format
+func (f Format) SupportsLevel() bool {
+	return f.Writable() && f != Tar
+}
+
+// DetectFormat returns the archive format based on the file name.
+func DetectFormat(path string) Format {
+	name := strings.ToLower(filepath.Base(path))
+	for f, exts := range formatExtensions {
+		for _, ext := range exts {
+			if strings.HasSuffix(name, ext) {
+				// ".tar" is a suffix of none of the others, so there is no
+				// ambiguity in the match order
+				return f
+			}
+		}
+	}
+	return Unknown
+}
+
+// TrimExtension removes any known archive extension from name
+func TrimExtension(name string) string {
+	lower := strings.ToLower(name)
+	for _, exts := range formatExtensions {
+		for _, ext := range exts {
+			if strings.HasSuffix(lower, ext) && len(name) > len(ext) {
+				return name[:len(name)-len(ext)]
+			}
+		}
+	}
+	return name
+}
@@ -0,0 +1,52 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	testdata := []struct {
+		path     string
+		expected Format
+	}{
+		{"/a/b/file.zip", Zip},
+		{"file.ZIP", Zip},
+		{"file.tar", Tar},
+		{"file.tar.gz", TarGz},
+		{"file.tgz", TarGz},
+		{"file.tar.xz", TarXz},
+		{"file.txz", TarXz},
+		{"file.tar.zst", TarZst},
+		{"file.tar.bz2", TarBz2},
+		{"file.tbz", TarBz2},
+		{"file.7z", SevenZip},
+		{"file.rar", Rar},
+		{"file.gz", Unknown},
+		{"file.txt", Unknown},
+		{"tar", Unknown},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DetectFormat(tt.path))
+		})
+	}
+}
+
+func TestTrimExtension(t *testing.T) {
+	assert.Equal(t, "file", TrimExtension("file.tar.gz"))
+	assert.Equal(t, "file(1)", TrimExtension("file(1).zip"))
+	assert.Equal(t, "file.txt", TrimExtension("file.txt"))
+	assert.Equal(t, ".zip", TrimExtension(".zip"))
+}
+
+func TestWritableFormats(t *testing.T) {
+	for _, f := range WritableFormats() {
+		assert.True(t, f.Writable(), "%s should be writable", f)
+		assert.Equal(t, f, DetectFormat("name"+f.Extension()))
+	}
+	assert.False(t, TarBz2.Writable())
+	assert.False(t, SevenZip.Writable())
+	assert.False(t, Tar.SupportsLevel())
+}
@@ -0,0 +1,63 @@
+package archive
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Mode for parent directories that are missing in the archive
+const dirModeForParents = 0o755
+
+// NewDecompressor wraps r with the decompressor for the given tar based format.
+func NewDecompressor(r io.Reader, format Format) (io.ReadCloser, error) {
+	switch format {
+	case Tar:
+		return io.NopCloser(r), nil
+	case TarGz:
+		return gzip.NewReader(r)
+	case TarBz2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case TarXz:
+		xzReader, err := xz.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xzReader), nil
+	case TarZst:
+		zstdReader, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zstdReader.IOReadCloser(), nil
+	case Zip, SevenZip, Rar, Unknown:
+		fallthrough
+	default:
+		return nil, fmt.Errorf("not a tar based format : %s", format)
+	}
+}
+
+func writeFile(target string, reader io.Reader, mode os.FileMode) error {
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(file, reader)
+	return err
+}
+
+func isWithinDir(dir string, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
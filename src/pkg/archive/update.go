@@ -0,0 +1,235 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const (
+	// Bytes of the header needed to find the compression level
+	levelHeaderLen = 32
+
+	gzipXFLOffset  = 8
+	gzipXFLBest    = 2
+	gzipXFLFastest = 4
+
+	xzStreamHeaderLen = 12
+	xzLZMA2FilterID   = 0x21
+	// Shift of the smallest LZMA2 dictionary size
+	xzMinDictShift = 12
+)
+
+// Updater adds files to an existing zip or tar based archive. The updated
+// archive is written to a temporary file next to the original one, which is
+// only replaced once everything was written.
+type Updater struct {
+	archivePath string
+	temp        *os.File
+	writer      Writer
+}
+
+// NewUpdater starts updating the archive at archivePath. Its existing entries
+// are copied right away, except the ones at or under any of the replaced
+// names, which are expected to be added again.
+// Entries are added without encryption, so encrypted zip archives can't be updated.
+// Zip entries are copied as they are. Compressed tar archives are compressed
+// again at the level found in their header for gzip and xz, and at
+// DefaultLevel for zstd, whose header doesn't tell it.
+func NewUpdater(archivePath string, replaced []string) (*Updater, error) {
+	format := DetectFormat(archivePath)
+	if !format.Writable() {
+		return nil, fmt.Errorf("%w : %s", ErrFormatNotWritable, format)
+	}
+	encrypted, err := IsEncrypted(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		return nil, fmt.Errorf("%w : cannot add files to encrypted archives", ErrEncryptionNotSupported)
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(archivePath), "."+filepath.Base(archivePath)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	u := &Updater{archivePath: archivePath, temp: temp}
+	isReplaced := func(rawName string) bool {
+		name, _ := cleanEntryName(rawName)
+		return slices.ContainsFunc(replaced, func(r string) bool {
+			return r != "" && isUnder(name, r)
+		})
+	}
+	if format == Zip {
+		zw := newZipWriter(temp, DefaultLevel, "")
+		u.writer = zw
+		err = copyZipEntries(archivePath, zw, isReplaced)
+	} else {
+		var w Writer
+		if w, err = NewWriter(temp, format, originalLevel(archivePath, format)); err == nil {
+			u.writer = w
+			if tw, ok := w.(*tarWriter); ok {
+				err = copyTarEntries(archivePath, format, tw, isReplaced)
+			} else {
+				err = fmt.Errorf("unexpected writer %T for %s", w, format)
+			}
+		}
+	}
+	if err != nil {
+		return nil, errors.Join(err, u.Abort())
+	}
+	return u, nil
+}
+
+// AddFile adds the file at path under name, like Writer.AddFile
+func (u *Updater) AddFile(path string, name string, info os.FileInfo) error {
+	return u.writer.AddFile(path, name, info)
+}
+
+// Close finishes the updated archive, and replaces the original one with it.
+// The permission bits of the original are kept. The original is left
+// untouched if anything fails.
+func (u *Updater) Close() error {
+	info, err := os.Stat(u.archivePath)
+	if err == nil {
+		err = u.writer.Close()
+	}
+	if err == nil {
+		err = u.temp.Sync()
+	}
+	if err == nil {
+		err = u.temp.Chmod(info.Mode().Perm())
+	}
+	if err == nil {
+		err = u.temp.Close()
+	}
+	if err == nil {
+		err = os.Rename(u.temp.Name(), u.archivePath)
+	}
+	if err != nil {
+		return errors.Join(err, u.Abort())
+	}
+	return nil
+}
+
+// Abort discards the updated archive, leaving the original one untouched
+func (u *Updater) Abort() error {
+	if u.writer != nil {
+		// The result doesn't matter, as the file is removed anyway
+		_ = u.writer.Close()
+	}
+	// Closing twice only gives fs.ErrClosed, which doesn't matter here
+	if err := u.temp.Close(); err != nil && !errors.Is(err, fs.ErrClosed) {
+		return errors.Join(err, os.Remove(u.temp.Name()))
+	}
+	return os.Remove(u.temp.Name())
+}
+
+// copyZipEntries copies the entries without recompressing them
+func copyZipEntries(archivePath string, zw *zipWriter, skip func(name string) bool) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if skip(file.Name) {
+			continue
+		}
+		if err = zw.writer.Copy(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyTarEntries(archivePath string, format Format, tw *tarWriter, skip func(name string) bool) error {
+	return walkTar(archivePath, format, func(name string, info fs.FileInfo, open func() (io.ReadCloser, error)) error {
+		header, ok := info.Sys().(*tar.Header)
+		if !ok {
+			return fmt.Errorf("unexpected header for tar entry %s", name)
+		}
+		if skip(name) {
+			return nil
+		}
+		if err := tw.writer.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || isTarHardLink(info) {
+			return nil
+		}
+		reader, err := open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		_, err = io.Copy(tw.writer, reader)
+		return err
+	})
+}
+
+// originalLevel returns the compression level the archive was written with, as
+// far as its header tells. DefaultLevel is returned when it is unknown.
+func originalLevel(archivePath string, format Format) int {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return DefaultLevel
+	}
+	defer file.Close()
+	header := make([]byte, levelHeaderLen)
+	n, _ := io.ReadFull(file, header)
+	header = header[:n]
+	switch format {
+	case TarGz:
+		return gzipLevel(header)
+	case TarXz:
+		return xzLevel(header)
+	default:
+		return DefaultLevel
+	}
+}
+
+// The gzip header only tells whether the fastest or the best compression was used
+func gzipLevel(header []byte) int {
+	if len(header) <= gzipXFLOffset {
+		return DefaultLevel
+	}
+	switch header[gzipXFLOffset] {
+	case gzipXFLBest:
+		return MaxLevel
+	case gzipXFLFastest:
+		return MinLevel
+	default:
+		return DefaultLevel
+	}
+}
+
+// The level is read back from the LZMA2 dictionary size of the first block,
+// which is what the level sets when writing. Like xz presets, larger
+// dictionaries are higher levels.
+func xzLevel(header []byte) int {
+	if len(header) <= xzStreamHeaderLen+1 {
+		return DefaultLevel
+	}
+	flags := header[xzStreamHeaderLen+1]
+	// Only a single filter without sizes in the block header is expected, as
+	// written by this package. The xz tool writes sizes, and shrinks the
+	// dictionary for small inputs, so its archives get DefaultLevel, which is
+	// also its default preset.
+	if flags != 0 {
+		return DefaultLevel
+	}
+	filter := header[xzStreamHeaderLen+2:]
+	if len(filter) < 3 || filter[0] != xzLZMA2FilterID || filter[1] != 1 {
+		return DefaultLevel
+	}
+	// The dictionary size is 2^11 to 2^40, encoded with its highest bits
+	dictBits := int(filter[2])/2 + xzMinDictShift
+	return min(max(dictBits-xzBaseDictCapShift, MinLevel), MaxLevel)
+}
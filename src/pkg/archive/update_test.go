@@ -0,0 +1,176 @@
+package archive
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// writeArchive creates an archive of paths, named relative to baseDir
+func writeArchive(t *testing.T, archivePath string, format Format, baseDir string, paths ...string) {
+	t.Helper()
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w, err := NewWriter(f, format, DefaultLevel)
+	require.NoError(t, err)
+	addFiles(t, w, baseDir, paths...)
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
+
+func addFiles(t *testing.T, w Writer, baseDir string, paths ...string) {
+	t.Helper()
+	for _, path := range paths {
+		info, err := os.Lstat(path)
+		require.NoError(t, err)
+		rel, err := filepath.Rel(baseDir, path)
+		require.NoError(t, err)
+		require.NoError(t, w.AddFile(path, rel, info))
+	}
+}
+
+func TestUpdater(t *testing.T) {
+	oldDir := t.TempDir()
+	newDir := t.TempDir()
+	utils.SetupDirectories(t, filepath.Join(oldDir, "sub"), filepath.Join(newDir, "sub"))
+	utils.SetupFilesWithData(t, []byte("old"), filepath.Join(oldDir, "kept.txt"),
+		filepath.Join(oldDir, "sub", "replaced.txt"))
+	utils.SetupFilesWithData(t, []byte("new"), filepath.Join(newDir, "added.txt"),
+		filepath.Join(newDir, "sub", "new.txt"))
+
+	for _, format := range WritableFormats() {
+		t.Run(format.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			archivePath := filepath.Join(dir, "test"+format.Extension())
+			writeArchive(t, archivePath, format, oldDir, filepath.Join(oldDir, "kept.txt"),
+				filepath.Join(oldDir, "sub"), filepath.Join(oldDir, "sub", "replaced.txt"))
+			require.NoError(t, os.Chmod(archivePath, 0o600))
+
+			u, err := NewUpdater(archivePath, []string{"sub", "added.txt"})
+			require.NoError(t, err)
+			addFiles(t, u, newDir, filepath.Join(newDir, "added.txt"),
+				filepath.Join(newDir, "sub"), filepath.Join(newDir, "sub", "new.txt"))
+			require.NoError(t, u.Close())
+
+			info, err := os.Stat(archivePath)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "temporary file should be gone")
+
+			outDir := t.TempDir()
+			_, err = ExtractArchive(archivePath, outDir, ExtractOptions{})
+			require.NoError(t, err)
+			for name, content := range map[string]string{
+				"kept.txt": "old", "added.txt": "new", filepath.Join("sub", "new.txt"): "new",
+			} {
+				data, err := os.ReadFile(filepath.Join(outDir, name))
+				require.NoError(t, err)
+				assert.Equal(t, content, string(data))
+			}
+			assert.NoFileExists(t, filepath.Join(outDir, "sub", "replaced.txt"))
+		})
+	}
+}
+
+func TestUpdaterAbort(t *testing.T) {
+	srcDir := t.TempDir()
+	file := filepath.Join(srcDir, "file.txt")
+	utils.SetupFilesWithData(t, []byte("content"), file)
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "test.zip")
+	writeArchive(t, archivePath, Zip, srcDir, file)
+	original, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+
+	u, err := NewUpdater(archivePath, nil)
+	require.NoError(t, err)
+	addFiles(t, u, srcDir, file)
+	require.NoError(t, u.Abort())
+
+	data, err := os.ReadFile(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, original, data)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestNewUpdaterUnsupported(t *testing.T) {
+	dir := t.TempDir()
+	_, err := NewUpdater(filepath.Join(dir, "test.tar.bz2"), nil)
+	require.ErrorIs(t, err, ErrFormatNotWritable)
+
+	archivePath := filepath.Join(dir, "encrypted.zip")
+	createZipCryptoZip(t, archivePath, "secret.txt", []byte("secret"), "pw")
+	_, err = NewUpdater(archivePath, nil)
+	require.ErrorIs(t, err, ErrEncryptionNotSupported)
+}
+
+func TestUpdaterKeepsCompressionLevel(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	utils.SetupFilesWithData(t, []byte("content"), file)
+	tests := []struct {
+		format   Format
+		level    int
+		expected int
+	}{
+		{TarGz, MinLevel, MinLevel},
+		{TarGz, MaxLevel, MaxLevel},
+		// Other levels are not in the gzip header
+		{TarGz, 4, DefaultLevel},
+		{TarXz, MinLevel, MinLevel},
+		{TarXz, 3, 3},
+		{TarXz, MaxLevel, MaxLevel},
+		{TarZst, MaxLevel, DefaultLevel},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s level %d", tt.format, tt.level), func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "test"+tt.format.Extension())
+			f, err := os.Create(archivePath)
+			require.NoError(t, err)
+			w, err := NewWriter(f, tt.format, tt.level)
+			require.NoError(t, err)
+			addFiles(t, w, dir, file)
+			require.NoError(t, w.Close())
+			require.NoError(t, f.Close())
+			assert.Equal(t, tt.expected, originalLevel(archivePath, tt.format))
+
+			u, err := NewUpdater(archivePath, nil)
+			require.NoError(t, err)
+			require.NoError(t, u.Close())
+			assert.Equal(t, tt.expected, originalLevel(archivePath, tt.format), "Level should be kept by updates")
+		})
+	}
+
+	t.Run("Archives of other tools", func(t *testing.T) {
+		for _, tool := range []struct {
+			name   string
+			format Format
+			args   []string
+			level  int
+		}{
+			{"gzip", TarGz, []string{"-9"}, MaxLevel},
+			{"gzip", TarGz, []string{"-1"}, MinLevel},
+			{"xz", TarXz, []string{"-9"}, DefaultLevel},
+		} {
+			if _, err := exec.LookPath(tool.name); err != nil {
+				t.Skip(tool.name + " is not installed")
+			}
+			archivePath := filepath.Join(t.TempDir(), "compressed")
+			output, err := exec.Command(tool.name, append(tool.args, "-c", file)...).Output()
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(archivePath, output, 0o644))
+			assert.Equal(t, tool.level, originalLevel(archivePath, tool.format), tool.name+" "+tool.args[0])
+		}
+	})
+}
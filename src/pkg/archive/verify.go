@@ -0,0 +1,67 @@
+package archive
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+)
+
+// VerifyResult is the outcome of checking every file of an archive
+type VerifyResult struct {
+	// Count of files that were checked, including the failed ones
+	Checked int
+	// Files whose content couldn't be read, or didn't match the stored checksum
+	Failed []EntryError
+}
+
+// EntryError is an error about a single archive entry
+type EntryError struct {
+	Name string
+	Err  error
+}
+
+func (e EntryError) Error() string {
+	return e.Name + " : " + e.Err.Error()
+}
+
+func (e EntryError) Unwrap() error {
+	return e.Err
+}
+
+// Verify reads the content of every file in the archive without writing it
+// anywhere, which checks it against the checksum stored in the archive for
+// formats having one. Encrypted archives are read with the password kept for
+// the session. onFile, if not nil, is called after every checked file, and
+// verification stops with its error if it returns one.
+// Errors that prevent reading the rest of the archive, like a missing password
+// or a corrupt tar stream, are returned along with the result so far.
+func Verify(archivePath string, onFile func(name string) error) (VerifyResult, error) {
+	var result VerifyResult
+	err := walk(archivePath, func(name string, info fs.FileInfo, open func() (io.ReadCloser, error)) error {
+		if !info.Mode().IsRegular() || isTarHardLink(info) {
+			return nil
+		}
+		result.Checked++
+		if err := readEntry(open); err != nil {
+			if errors.Is(err, ErrPasswordRequired) || errors.Is(err, ErrWrongPassword) {
+				return err
+			}
+			result.Failed = append(result.Failed, EntryError{Name: name, Err: err})
+		}
+		if onFile != nil {
+			return onFile(name)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func readEntry(open func() (io.ReadCloser, error)) error {
+	reader, err := open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	_, err = io.Copy(io.Discard, reader)
+	return err
+}
@@ -0,0 +1,76 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("Valid archives", func(t *testing.T) {
+		zipPath := filepath.Join(dir, "valid.zip")
+		utils.SetupZip(t, zipPath, map[string]string{"a.txt": "a", "dir/b.txt": "b"})
+		tarPath := filepath.Join(dir, "valid.tar")
+		createTar(t, tarPath, []*tar.Header{
+			{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o755},
+			regHeader("dir/a.txt"), regHeader("b.txt"),
+		})
+		for _, archivePath := range []string{zipPath, tarPath} {
+			var names []string
+			result, err := Verify(archivePath, func(name string) error {
+				names = append(names, name)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.Checked)
+			assert.Empty(t, result.Failed)
+			assert.Len(t, names, 2)
+		}
+	})
+
+	t.Run("Corrupt zip entry", func(t *testing.T) {
+		archivePath := filepath.Join(dir, "corrupt.zip")
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for _, name := range []string{"good.txt", "bad.txt"} {
+			fw, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+			require.NoError(t, err)
+			_, err = fw.Write([]byte("content of " + name))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		data := bytes.Replace(buf.Bytes(), []byte("content of bad.txt"), []byte("CONTENT of bad.txt"), 1)
+		require.NoError(t, os.WriteFile(archivePath, data, 0o644))
+
+		result, err := Verify(archivePath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Checked)
+		require.Len(t, result.Failed, 1)
+		assert.Equal(t, "bad.txt", result.Failed[0].Name)
+		require.ErrorIs(t, result.Failed[0], zip.ErrChecksum)
+	})
+
+	t.Run("Encrypted zip needs password", func(t *testing.T) {
+		archivePath := filepath.Join(dir, "encrypted.zip")
+		createZipCryptoZip(t, archivePath, "secret.txt", []byte("secret"), "pw")
+		_, err := Verify(archivePath, nil)
+		require.ErrorIs(t, err, ErrPasswordRequired)
+
+		SetSessionPassword(archivePath, "pw")
+		defer SetSessionPassword(archivePath, "")
+		result, err := Verify(archivePath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Checked)
+		assert.Empty(t, result.Failed)
+	})
+}
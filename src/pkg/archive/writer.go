@@ -0,0 +1,172 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// xz dictionary size for level 1, doubled with every level.
+// This gives 8MiB for the default level, same as xz's own default preset
+const xzBaseDictCapShift = 17
+
+var ErrFormatNotWritable = errors.New("archive format is not writable")
+
+// Writer adds files from the disk into an archive
+type Writer interface {
+	// AddFile adds the file at path with the given info, under name inside the archive
+	// For directories, only the entry is added, not the contents
+	AddFile(path string, name string, info os.FileInfo) error
+	Close() error
+}
+
+// NewWriter returns a Writer that writes an archive of the given format to dst.
+// level is clamped to [MinLevel, MaxLevel] and ignored for formats that
+// don't support it. Closing the Writer doesn't close dst.
+func NewWriter(dst io.Writer, format Format, level int) (Writer, error) {
+	return NewWriterWithPassword(dst, format, level, "")
+}
+
+// NewWriterWithPassword is like NewWriter, but encrypts the content of files
+// with password, if it isn't empty. Only zip supports encryption.
+func NewWriterWithPassword(dst io.Writer, format Format, level int, password string) (Writer, error) {
+	if !format.Writable() {
+		return nil, fmt.Errorf("%w : %s", ErrFormatNotWritable, format)
+	}
+	if password != "" && !format.SupportsEncryption() {
+		return nil, fmt.Errorf("%w : %s", ErrEncryptionNotSupported, format)
+	}
+	level = min(max(level, MinLevel), MaxLevel)
+	if format == Zip {
+		return newZipWriter(dst, level, password), nil
+	}
+	compressor, err := newCompressor(dst, format, level)
+	if err != nil {
+		return nil, err
+	}
+	return &tarWriter{
+		writer:     tar.NewWriter(compressor),
+		compressor: compressor,
+	}, nil
+}
+
+func newCompressor(dst io.Writer, format Format, level int) (io.WriteCloser, error) {
+	switch format {
+	case Tar:
+		return nopWriteCloser{dst}, nil
+	case TarGz:
+		return gzip.NewWriterLevel(dst, level)
+	case TarXz:
+		return xz.WriterConfig{DictCap: 1 << (xzBaseDictCapShift + level)}.NewWriter(dst)
+	case TarZst:
+		return zstd.NewWriter(dst, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	case Zip, TarBz2, SevenZip, Rar, Unknown:
+		fallthrough
+	default:
+		return nil, fmt.Errorf("%w : %s", ErrFormatNotWritable, format)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+type zipWriter struct {
+	writer *zip.Writer
+	level  int
+	// Empty for archives without encryption
+	password string
+}
+
+func newZipWriter(dst io.Writer, level int, password string) *zipWriter {
+	w := zip.NewWriter(dst)
+	w.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+	return &zipWriter{writer: w, level: level, password: password}
+}
+
+func (z *zipWriter) AddFile(path string, name string, info os.FileInfo) error {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Method = zip.Deflate
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if z.password != "" && !info.IsDir() {
+		return z.addEncryptedFile(path, header)
+	}
+	headerWriter, err := z.writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return nil
+	}
+	return copyFileTo(headerWriter, path)
+}
+
+func (z *zipWriter) Close() error {
+	return z.writer.Close()
+}
+
+// tarWriter keeps permission bits, ownership and symlinks intact
+type tarWriter struct {
+	writer     *tar.Writer
+	compressor io.WriteCloser
+}
+
+func (t *tarWriter) AddFile(path string, name string, info os.FileInfo) error {
+	var link string
+	if info.Mode()&os.ModeSymlink != 0 {
+		var err error
+		if link, err = os.Readlink(path); err != nil {
+			return err
+		}
+	}
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err = t.writer.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	return copyFileTo(t.writer, path)
+}
+
+func (t *tarWriter) Close() error {
+	return errors.Join(t.writer.Close(), t.compressor.Close())
+}
+
+func copyFileTo(dst io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(dst, file)
+	return err
+}
@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	subDir := filepath.Join(srcDir, "sub")
+	file1 := filepath.Join(srcDir, "file1.txt")
+	file2 := filepath.Join(subDir, "file2.txt")
+	utils.SetupDirectories(t, subDir)
+	utils.SetupFilesWithData(t, []byte("content1"), file1)
+	utils.SetupFilesWithData(t, []byte("content2"), file2)
+
+	for _, format := range WritableFormats() {
+		t.Run(format.String(), func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "test"+format.Extension())
+			f, err := os.Create(archivePath)
+			require.NoError(t, err)
+			w, err := NewWriter(f, format, DefaultLevel)
+			require.NoError(t, err)
+			for _, path := range []string{subDir, file1, file2} {
+				info, err := os.Lstat(path)
+				require.NoError(t, err)
+				rel, err := filepath.Rel(srcDir, path)
+				require.NoError(t, err)
+				require.NoError(t, w.AddFile(path, rel, info))
+			}
+			require.NoError(t, w.Close())
+			require.NoError(t, f.Close())
+
+			outDir := t.TempDir()
+			report, err := ExtractArchive(archivePath, outDir, ExtractOptions{})
+			require.NoError(t, err)
+			assert.False(t, report.HasIssues())
+			data, err := os.ReadFile(filepath.Join(outDir, "file1.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "content1", string(data))
+			data, err = os.ReadFile(filepath.Join(outDir, "sub", "file2.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "content2", string(data))
+		})
+	}
+}
+
+func TestNewWriterUnwritableFormat(t *testing.T) {
+	_, err := NewWriter(&bytes.Buffer{}, TarBz2, DefaultLevel)
+	require.ErrorIs(t, err, ErrFormatNotWritable)
+}
@@ -0,0 +1,126 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Time limit of the git commands. Status of large repositories can take a while
+const commandTimeout = 10 * time.Second
+
+// FindRoot returns the root of the repository containing dir, by looking
+// for a .git entry in it and its parents. ok is false outside of repositories.
+func FindRoot(dir string) (string, bool) {
+	dir = filepath.Clean(dir)
+	for {
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// GetStatus runs `git status` in the repository at root
+func GetStatus(root string) (*Status, error) {
+	output, err := runGitStatus(root, "--ignored")
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatus(root, output), nil
+}
+
+// GetBranchStatus is like GetStatus, but leaves out untracked and ignored
+// files, which are the slowest to list. Its branch information is complete.
+func GetBranchStatus(root string) (*Status, error) {
+	output, err := runGitStatus(root, "--untracked-files=no")
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatus(root, output), nil
+}
+
+// Runs `git status` with args in dir. It runs in background, so it must not
+// take .git/index.lock to refresh the index, which would make the git commands
+// of the user fail meanwhile
+func runGitStatus(dir string, args ...string) (string, error) {
+	args = append([]string{"status", "--porcelain=v2", "--branch", "-z"}, args...)
+	return runGitWithEnv(dir, []string{"GIT_OPTIONAL_LOCKS=0"}, args...)
+}
+
+// Runs git with args in dir, and returns its standard output
+func runGit(dir string, args ...string) (string, error) {
+	return runGitWithEnv(dir, nil, args...)
+}
+
+// Like runGit, with env added to the environment of git
+func runGitWithEnv(dir string, env []string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	utils.DetachFromTerminal(cmd)
+	output, err := cmd.Output()
+	if err != nil {
+		var reason string
+		if exitErr, ok := err.(*exec.ExitError); ok { //nolint:errorlint // Output() does not wrap the error
+			reason = strings.TrimSpace(string(exitErr.Stderr))
+		}
+		// Some failures like `nothing to commit` are only reported on stdout
+		if reason == "" {
+			reason = strings.TrimSpace(string(output))
+		}
+		return "", fmt.Errorf("git %s failed : %w %s", args[0], err, reason)
+	}
+	return string(output), nil
+}
+
+// Stage adds the changes of paths to the index, including deletions
+func Stage(root string, paths []string) error {
+	_, err := runGit(root, append([]string{"add", "--all", "--"}, paths...)...)
+	return err
+}
+
+// Unstage removes the changes of paths from the index, keeping them in the worktree
+func Unstage(root string, paths []string) error {
+	// Unlike restore --staged, reset works in repositories without commits
+	_, err := runGit(root, append([]string{"reset", "--quiet", "--"}, paths...)...)
+	return err
+}
+
+// Discard drops the unstaged changes of paths. Staged changes and untracked files are kept
+func Discard(root string, paths []string) error {
+	_, err := runGit(root, append([]string{"restore", "--"}, paths...)...)
+	return err
+}
+
+// Diff returns the staged and unstaged changes of paths
+func Diff(root string, paths []string) (string, error) {
+	args := append([]string{"diff", "--no-color", "--no-ext-diff", "HEAD", "--"}, paths...)
+	output, err := runGit(root, args...)
+	if err != nil {
+		// Repositories without commits have no HEAD. Everything is in the index
+		args = append([]string{"diff", "--no-color", "--no-ext-diff", "--cached", "--"}, paths...)
+		return runGit(root, args...)
+	}
+	return output, nil
+}
+
+// Commit commits the staged changes with message
+func Commit(root string, message string) error {
+	_, err := runGit(root, "commit", "--quiet", "--message", message)
+	return err
+}
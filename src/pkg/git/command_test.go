@@ -0,0 +1,101 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitActions(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	_, err := runGit(root, "init", "-b", "main")
+	require.NoError(t, err)
+	_, err = runGit(root, "config", "user.name", "test")
+	require.NoError(t, err)
+	_, err = runGit(root, "config", "user.email", "test@test")
+	require.NoError(t, err)
+	writeFile := func(name string, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+	}
+	getState := func(name string) FileState {
+		t.Helper()
+		s, statusErr := GetStatus(root)
+		require.NoError(t, statusErr)
+		return s.State(name, false)
+	}
+
+	// Repository without commits
+	writeFile("a.txt", "first\n")
+	require.NoError(t, Stage(root, []string{"a.txt"}))
+	assert.Equal(t, StateStaged, getState("a.txt"))
+	diff, err := Diff(root, []string{"a.txt"})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+first")
+	require.NoError(t, Unstage(root, []string{"a.txt"}))
+	assert.Equal(t, StateUntracked, getState("a.txt"))
+
+	require.NoError(t, Stage(root, []string{"a.txt"}))
+	require.NoError(t, Commit(root, "Add a.txt"))
+	assert.Equal(t, StateClean, getState("a.txt"))
+	err = Commit(root, "Nothing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing")
+
+	writeFile("a.txt", "second\n")
+	diff, err = Diff(root, []string{"a.txt"})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "-first")
+	assert.Contains(t, diff, "+second")
+	require.NoError(t, Discard(root, []string{"a.txt"}))
+	assert.Equal(t, StateClean, getState("a.txt"))
+	content, err := os.ReadFile(filepath.Join(root, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(content))
+
+	// Deletions are staged too
+	require.NoError(t, os.Remove(filepath.Join(root, "a.txt")))
+	require.NoError(t, Stage(root, []string{"a.txt"}))
+	assert.Equal(t, StateStaged, getState("a.txt"))
+}
+
+func TestStatusDoesNotWriteIndex(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	_, err := runGit(root, "init", "-b", "main")
+	require.NoError(t, err)
+	file := filepath.Join(root, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte("content\n"), 0o644))
+	require.NoError(t, Stage(root, []string{"a.txt"}))
+	// Same content with another modification time, so the index is stale
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(file, later, later))
+
+	index := filepath.Join(root, ".git", "index")
+	before, err := os.ReadFile(index)
+	require.NoError(t, err)
+	_, err = GetStatus(root)
+	require.NoError(t, err)
+	_, err = GetBranchStatus(root)
+	require.NoError(t, err)
+	after, err := os.ReadFile(index)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "Status should not refresh the index")
+
+	// Without GIT_OPTIONAL_LOCKS=0, git status would have refreshed it
+	_, err = runGit(root, "status")
+	require.NoError(t, err)
+	after, err = os.ReadFile(index)
+	require.NoError(t, err)
+	assert.NotEqual(t, before, after)
+}
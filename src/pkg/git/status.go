@@ -0,0 +1,156 @@
+package git
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// FileState is the git state of a file. States are ordered by importance,
+// the state of a directory is the most important one of its content.
+type FileState int
+
+const (
+	StateClean FileState = iota
+	StateIgnored
+	StateUntracked
+	StateStaged
+	StateModified
+	StateConflicted
+)
+
+// Marker is the short text shown for the state, empty for clean files
+func (s FileState) Marker() string {
+	switch s {
+	case StateIgnored:
+		return "!"
+	case StateUntracked:
+		return "?"
+	case StateStaged:
+		return "S"
+	case StateModified:
+		return "M"
+	case StateConflicted:
+		return "U"
+	default:
+		return ""
+	}
+}
+
+// Status of a repository, as reported by `git status`
+type Status struct {
+	Root string
+	// Name of the checked out branch, "(detached)" when HEAD is detached
+	Branch   string
+	Upstream string
+	Ahead    int
+	Behind   int
+	// State of the changed paths, relative to Root with '/' separators.
+	// Untracked and ignored directories are reported without their content.
+	files map[string]FileState
+	// Most important state of the content of directories with changes
+	dirs map[string]FileState
+}
+
+// State returns the state of a path relative to the root, using '/' separators.
+func (s *Status) State(relPath string, isDir bool) FileState {
+	state := s.files[relPath]
+	if isDir {
+		state = max(state, s.dirs[relPath])
+	}
+	// Content of untracked or ignored directories is not listed
+	for dir := path.Dir(relPath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if dirState, ok := s.files[dir]; ok && (dirState == StateUntracked || dirState == StateIgnored) {
+			return max(state, dirState)
+		}
+	}
+	return state
+}
+
+// Ignored reports whether a path relative to the root, using '/' separators,
+// is ignored by git, itself or through one of its directories. Unlike State,
+// it is not hidden by an untracked directory.
+func (s *Status) Ignored(relPath string) bool {
+	for ; relPath != "." && relPath != "/"; relPath = path.Dir(relPath) {
+		if s.files[relPath] == StateIgnored {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseStatus parses the output of `git status --porcelain=v2 --branch -z`
+func ParseStatus(root string, output string) *Status {
+	s := &Status{
+		Root:  root,
+		files: make(map[string]FileState),
+		dirs:  make(map[string]FileState),
+	}
+	records := strings.Split(output, "\x00")
+	for i := 0; i < len(records); i++ {
+		record := records[i]
+		if record == "" {
+			continue
+		}
+		switch record[0] {
+		case '#':
+			s.parseHeader(record)
+		case '1':
+			// 1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>
+			if fields := strings.SplitN(record, " ", 9); len(fields) == 9 {
+				s.addFile(fields[8], changeState(fields[1]))
+			}
+		case '2':
+			// 2 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <X><score> <path>, then the original path
+			if fields := strings.SplitN(record, " ", 10); len(fields) == 10 {
+				s.addFile(fields[9], changeState(fields[1]))
+			}
+			i++
+		case 'u':
+			// u <XY> <sub> <m1> <m2> <m3> <mW> <h1> <h2> <h3> <path>
+			if fields := strings.SplitN(record, " ", 11); len(fields) == 11 {
+				s.addFile(fields[10], StateConflicted)
+			}
+		case '?':
+			s.addFile(record[2:], StateUntracked)
+		case '!':
+			s.addFile(record[2:], StateIgnored)
+		}
+	}
+	return s
+}
+
+func (s *Status) parseHeader(record string) {
+	key, value, _ := strings.Cut(strings.TrimPrefix(record, "# "), " ")
+	switch key {
+	case "branch.head":
+		s.Branch = value
+	case "branch.upstream":
+		s.Upstream = value
+	case "branch.ab":
+		// +<ahead> -<behind>
+		ahead, behind, _ := strings.Cut(value, " ")
+		s.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+		s.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+	}
+}
+
+// State of a changed entry from its XY field of index and worktree states
+func changeState(xy string) FileState {
+	if len(xy) == 2 && xy[1] != '.' {
+		return StateModified
+	}
+	return StateStaged
+}
+
+func (s *Status) addFile(filePath string, state FileState) {
+	filePath = strings.TrimSuffix(filePath, "/")
+	s.files[filePath] = state
+	// Ignored files don't make their directories ignored
+	if state == StateIgnored {
+		return
+	}
+	for dir := path.Dir(filePath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		s.dirs[dir] = max(s.dirs[dir], state)
+	}
+}
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatus(t *testing.T) {
+	output := strings.Join([]string{
+		"# branch.oid 1234567890abcdef",
+		"# branch.head main",
+		"# branch.upstream origin/main",
+		"# branch.ab +2 -1",
+		"1 M. N... 100644 100644 100644 abc abc src/staged.go",
+		"1 .M N... 100644 100644 100644 abc abc src/pkg/modified file.go",
+		"1 MM N... 100644 100644 100644 abc abc both.go",
+		"2 R. N... 100644 100644 100644 abc abc R100 docs/new.md",
+		"docs/old.md",
+		"u UU N... 100644 100644 100644 100644 abc abc abc conflict.go",
+		"? src/untracked.go",
+		"? newdir/",
+		"! build/",
+		"! src/ignored.log",
+	}, "\x00") + "\x00"
+
+	s := ParseStatus("/repo", output)
+	assert.Equal(t, "/repo", s.Root)
+	assert.Equal(t, "main", s.Branch)
+	assert.Equal(t, "origin/main", s.Upstream)
+	assert.Equal(t, 2, s.Ahead)
+	assert.Equal(t, 1, s.Behind)
+
+	testdata := []struct {
+		path     string
+		isDir    bool
+		expected FileState
+	}{
+		{"src/staged.go", false, StateStaged},
+		{"src/pkg/modified file.go", false, StateModified},
+		{"both.go", false, StateModified},
+		{"docs/new.md", false, StateStaged},
+		{"docs/old.md", false, StateClean},
+		{"conflict.go", false, StateConflicted},
+		{"src/untracked.go", false, StateUntracked},
+		{"src/ignored.log", false, StateIgnored},
+		{"clean.go", false, StateClean},
+		{"newdir", true, StateUntracked},
+		{"newdir/sub/file.go", false, StateUntracked},
+		{"build", true, StateIgnored},
+		{"build/out/bin", false, StateIgnored},
+		{"src", true, StateModified},
+		{"src/pkg", true, StateModified},
+		{"docs", true, StateStaged},
+		{"cleandir", true, StateClean},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, s.State(tt.path, tt.isDir))
+			assert.Equal(t, tt.expected == StateIgnored, s.Ignored(tt.path))
+		})
+	}
+}
+
+func TestParseStatusDetached(t *testing.T) {
+	s := ParseStatus("/repo", "# branch.oid abc\x00# branch.head (detached)\x00")
+	assert.Equal(t, "(detached)", s.Branch)
+	assert.Empty(t, s.Upstream)
+	assert.Zero(t, s.Ahead)
+	assert.Zero(t, s.Behind)
+}
+
+func TestGetStatus(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	runGitCmd := func(args ...string) {
+		t.Helper()
+		_, err := runGit(root, append([]string{"-c", "user.name=test", "-c", "user.email=test@test"}, args...)...)
+		require.NoError(t, err)
+	}
+	runGitCmd("init", "-b", "main")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "tracked.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\n"), 0o644))
+	runGitCmd("add", ".")
+	runGitCmd("commit", "-m", "init")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "tracked.txt"), []byte("b"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "new.txt"), []byte("a"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "debug.log"), []byte("a"), 0o644))
+
+	found, ok := FindRoot(filepath.Join(root, "dir"))
+	require.True(t, ok)
+	assert.Equal(t, root, found)
+
+	s, err := GetStatus(root)
+	require.NoError(t, err)
+	assert.Equal(t, "main", s.Branch)
+	assert.Equal(t, StateModified, s.State("dir/tracked.txt", false))
+	assert.Equal(t, StateModified, s.State("dir", true))
+	assert.Equal(t, StateUntracked, s.State("new.txt", false))
+	assert.Equal(t, StateIgnored, s.State("debug.log", false))
+	assert.Equal(t, StateClean, s.State(".gitignore", false))
+}
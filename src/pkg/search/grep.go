@@ -0,0 +1,101 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Files with a NUL byte in this many first bytes are skipped as binary, like git does
+	binaryCheckSize = 8000
+	// Files with longer lines are not searched further
+	maxGrepLineSize = 1024 * 1024
+	// Text of the matching lines is cut after this many bytes
+	maxMatchTextSize = 256
+)
+
+// Grep searches the content of the files in the tree under root for the lines
+// accepted by match, like ripgrep. Lines are sent to results in batches, each
+// with the file path and the line number. Binary files are skipped, hidden and
+// ignored files are skipped unless opts says otherwise. Same as Walk otherwise.
+func Grep(ctx context.Context, root string, match func(line string) bool, opts WalkOptions,
+	results chan<- []Result) error {
+	visit := func(path string, relPath string, entry os.DirEntry) []Result {
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		matches, err := grepFile(path, match)
+		if err != nil {
+			slog.Debug("Skipping unreadable file in content search", "path", path, "error", err)
+		}
+		if len(matches) == 0 {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		for i := range matches {
+			matches[i].Path = relPath
+			matches[i].Info = info
+		}
+		return matches
+	}
+	return walk(ctx, root, visit, opts, results)
+}
+
+// Returns the lines of the file at path accepted by match, without their path
+// and info. Matches found before an error are returned with it
+func grepFile(path string, match func(line string) bool) ([]Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, binaryCheckSize)
+	head, err := reader.Peek(binaryCheckSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if bytes.IndexByte(head, 0) != -1 {
+		return nil, nil
+	}
+
+	var matches []Result
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxGrepLineSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if match(line) {
+			matches = append(matches, Result{Line: lineNumber, Text: matchText(line)})
+		}
+	}
+	return matches, scanner.Err()
+}
+
+// Text of a matching line as shown in results, on a single line without
+// control characters
+func matchText(line string) string {
+	if len(line) > maxMatchTextSize {
+		// Drops the rune cut in the middle
+		line = strings.ToValidUTF8(line[:maxMatchTextSize], "")
+	}
+	line = strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return ' '
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, line)
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Runs Grep to the end and returns the results as 'path:line:text', with '/' separators
+func grepResults(ctx context.Context, t *testing.T, root string, pattern string,
+	opts WalkOptions) ([]string, error) {
+	t.Helper()
+	results := make(chan []Result)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Grep(ctx, root, func(line string) bool {
+			return strings.Contains(line, pattern)
+		}, opts, results)
+	}()
+	var lines []string
+	for batch := range results {
+		for _, result := range batch {
+			require.NotNil(t, result.Info)
+			lines = append(lines, filepath.ToSlash(result.Path)+":"+strconv.Itoa(result.Line)+":"+result.Text)
+		}
+	}
+	return lines, <-errCh
+}
+
+func TestGrep(t *testing.T) {
+	root := t.TempDir()
+	writeFile := func(name string, content string) {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	writeFile("main.go", "package main\n\nfunc main() {\n\t// TODO: start\n}\n")
+	writeFile("src/util.go", "// TODO first\nx := 1\n// TODO second")
+	writeFile("image.bin", "TODO\x00\x01\x02")
+	writeFile(".hidden/notes.txt", "TODO hidden\n")
+	writeFile("long.txt", strings.Repeat("TODO ", 100))
+
+	lines, err := grepResults(t.Context(), t, root, "TODO", WalkOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, lines, "main.go:4:// TODO: start", "Tabs are trimmed")
+	assert.Contains(t, lines, "src/util.go:1:// TODO first")
+	assert.Contains(t, lines, "src/util.go:3:// TODO second", "Last line without newline is searched")
+	assert.Len(t, lines, 4, "Binary and hidden files are skipped")
+	for _, line := range lines {
+		if strings.HasPrefix(line, "long.txt") {
+			assert.LessOrEqual(t, len(line), len("long.txt:1:")+maxMatchTextSize)
+		}
+	}
+
+	lines, err = grepResults(t.Context(), t, root, "hidden", WalkOptions{Hidden: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".hidden/notes.txt:1:TODO hidden"}, lines)
+
+	lines, err = grepResults(t.Context(), t, root, "TODO", WalkOptions{Limit: 2})
+	require.ErrorIs(t, err, ErrLimitReached)
+	assert.Len(t, lines, 2)
+}
+
+func TestMatchText(t *testing.T) {
+	assert.Equal(t, "a b", matchText("\ta\tb\r"))
+	assert.Equal(t, "ab", matchText("a\x1b\x07b"))
+	// The rune cut in the middle is dropped
+	long := "a" + strings.Repeat("é", maxMatchTextSize)
+	assert.Equal(t, "a"+strings.Repeat("é", (maxMatchTextSize-1)/2), matchText(long))
+}
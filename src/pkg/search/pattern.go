@@ -0,0 +1,163 @@
+package search
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/yorukot/superfile/src/pkg/utils"
+)
+
+// Mode is the way a pattern is matched against names
+type Mode int
+
+const (
+	ModeFuzzy Mode = iota
+	ModeGlob
+	ModeRegex
+	ModeExact
+	modeCount
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeFuzzy:
+		return "fuzzy"
+	case ModeGlob:
+		return "glob"
+	case ModeRegex:
+		return "regex"
+	case ModeExact:
+		return "exact"
+	default:
+		return "unknown"
+	}
+}
+
+// Next returns the mode after m, cycling back to fuzzy after the last one
+func (m Mode) Next() Mode {
+	return (m + 1) % modeCount
+}
+
+// Matcher matches names against a pattern in glob, regex or exact mode. Case
+// is ignored unless the pattern has an uppercase letter (smart-case).
+type Matcher struct {
+	mode       Mode
+	pattern    string
+	ignoreCase bool
+	// Regex mode pattern, or exact pattern ignoring case used by Index
+	re *regexp.Regexp
+}
+
+// NewMatcher returns a matcher of pattern. Fuzzy matching ranks names instead
+// of matching them one by one, it is only done by Filter
+func NewMatcher(mode Mode, pattern string) (*Matcher, error) {
+	m := &Matcher{
+		mode:       mode,
+		pattern:    pattern,
+		ignoreCase: !hasUpper(pattern),
+	}
+	switch mode {
+	case ModeGlob:
+		if m.ignoreCase {
+			m.pattern = strings.ToLower(pattern)
+		}
+		// Match only reports malformed patterns when it reaches the bad part
+		if _, err := filepath.Match(m.pattern, m.pattern); err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q : %w", pattern, err)
+		}
+	case ModeRegex:
+		expr := pattern
+		if m.ignoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q : %w", pattern, err)
+		}
+		m.re = re
+	case ModeExact:
+		if m.ignoreCase {
+			m.pattern = strings.ToLower(pattern)
+			// Lowercase text can have another length, so offsets of matches
+			// are found with a regex
+			m.re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+		}
+	default:
+		return nil, fmt.Errorf("no matcher for %s mode", mode)
+	}
+	return m, nil
+}
+
+// Match reports whether name matches. Globs must match the whole name, regexes
+// and exact patterns any part of it
+func (m *Matcher) Match(name string) bool {
+	switch m.mode {
+	case ModeGlob:
+		if m.ignoreCase {
+			name = strings.ToLower(name)
+		}
+		ok, _ := filepath.Match(m.pattern, name)
+		return ok
+	case ModeRegex:
+		return m.re.MatchString(name)
+	case ModeExact:
+		if m.ignoreCase {
+			name = strings.ToLower(name)
+		}
+		return strings.Contains(name, m.pattern)
+	default:
+		return false
+	}
+}
+
+// Index returns the start and end offsets of the first match in s, or nil
+// when it doesn't match. Globs always match the whole of s
+func (m *Matcher) Index(s string) []int {
+	switch {
+	case m.mode == ModeGlob:
+		if m.Match(s) {
+			return []int{0, len(s)}
+		}
+		return nil
+	case m.re != nil:
+		return m.re.FindStringIndex(s)
+	case m.mode == ModeExact:
+		if start := strings.Index(s, m.pattern); start != -1 {
+			return []int{start, start + len(m.pattern)}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Filter returns the names matching pattern. Fuzzy results are ordered by
+// score, the others keep the order of names
+func Filter(mode Mode, pattern string, names []string) ([]string, error) {
+	if mode == ModeFuzzy {
+		results := utils.FzfSearch(pattern, names)
+		matched := make([]string, 0, len(results))
+		for _, result := range results {
+			matched = append(matched, result.Key)
+		}
+		return matched, nil
+	}
+	matcher, err := NewMatcher(mode, pattern)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]string, 0, len(names))
+	for _, name := range names {
+		if matcher.Match(name) {
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+func hasUpper(s string) bool {
+	return strings.ContainsFunc(s, unicode.IsUpper)
+}
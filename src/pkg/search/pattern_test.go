@@ -0,0 +1,80 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	names := []string{"test_main.go", "main_test.go", "README.md", "debug.log", "Debug.LOG", "notes.txt"}
+	testdata := []struct {
+		name     string
+		mode     Mode
+		pattern  string
+		expected []string
+	}{
+		{"Glob matches whole names", ModeGlob, "*.log", []string{"debug.log", "Debug.LOG"}},
+		{"Glob smart-case", ModeGlob, "*.LOG", []string{"Debug.LOG"}},
+		{"Glob without wildcard", ModeGlob, "main", []string{}},
+		{"Regex anchors", ModeRegex, "^test_", []string{"test_main.go"}},
+		{"Regex smart-case", ModeRegex, "^D", []string{"Debug.LOG"}},
+		{"Regex ignores case", ModeRegex, "readme", []string{"README.md"}},
+		{"Exact substring", ModeExact, "_test", []string{"main_test.go"}},
+		{"Exact is literal", ModeExact, "*.log", []string{}},
+		{"Exact smart-case", ModeExact, "LOG", []string{"Debug.LOG"}},
+		{"Fuzzy", ModeFuzzy, "ntxt", []string{"notes.txt"}},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, err := Filter(tt.mode, tt.pattern, names)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
+func TestMatcherIndex(t *testing.T) {
+	testdata := []struct {
+		name     string
+		mode     Mode
+		pattern  string
+		text     string
+		expected []int
+	}{
+		{"Exact", ModeExact, "log", "a.log.log", []int{2, 5}},
+		{"Exact ignoring case", ModeExact, "log", "ÉÉ.LOG", []int{5, 8}},
+		{"Exact smart-case", ModeExact, "LOG", "log", nil},
+		{"Regex", ModeRegex, "[0-9]+", "line 42 of 50", []int{5, 7}},
+		{"Glob is the whole text", ModeGlob, "*.go", "main.go", []int{0, 7}},
+		{"Glob without match", ModeGlob, "*.go", "main.rs", nil},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := NewMatcher(tt.mode, tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, matcher.Index(tt.text))
+		})
+	}
+}
+
+func TestInvalidPatterns(t *testing.T) {
+	_, err := Filter(ModeRegex, "(", []string{"a"})
+	require.Error(t, err)
+	_, err = Filter(ModeGlob, "[a", []string{"a"})
+	require.Error(t, err)
+	_, err = NewMatcher(ModeFuzzy, "a")
+	require.Error(t, err)
+}
+
+func TestModeNext(t *testing.T) {
+	mode := ModeFuzzy
+	var seen []string
+	for range modeCount {
+		seen = append(seen, mode.String())
+		mode = mode.Next()
+	}
+	assert.Equal(t, []string{"fuzzy", "glob", "regex", "exact"}, seen)
+	assert.Equal(t, ModeFuzzy, mode)
+}
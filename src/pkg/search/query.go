@@ -0,0 +1,266 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a search bar value, split into attribute predicates like
+// `size>100M` or `type:dir`, and the remaining name pattern
+type Query struct {
+	// Matched against names with the search mode. Empty matches every name
+	Pattern    string
+	predicates []predicate
+}
+
+// Attributes of an item that predicates are matched against
+type Attributes struct {
+	Name  string
+	IsDir bool
+	Info  os.FileInfo
+	// Returns the name of the owner. Only called for owner predicates
+	Owner func() string
+}
+
+type predicate func(attrs Attributes, now time.Time) bool
+
+// Sizes are in binary units, like the file size column
+const sizeUnit = 1024
+
+// Days are the largest unit of durations, units like months have no fixed length
+const day = 24 * time.Hour
+
+var errMissingValue = errors.New("missing value")
+
+// ParseQuery parses the predicates of value, separated by spaces. Other words
+// form the name pattern. Invalid predicate values are reported as errors
+func ParseQuery(value string) (Query, error) {
+	var query Query
+	var patternWords []string
+	for _, word := range strings.Fields(value) {
+		key, op, operand, ok := splitPredicate(word)
+		if !ok {
+			patternWords = append(patternWords, word)
+			continue
+		}
+		p, err := newPredicate(key, op, operand)
+		if err != nil {
+			return Query{}, fmt.Errorf("invalid %s filter %q : %w", key, word, err)
+		}
+		query.predicates = append(query.predicates, p)
+	}
+	query.Pattern = strings.Join(patternWords, " ")
+	return query, nil
+}
+
+// HasPredicates reports whether the query filters on attributes
+func (q Query) HasPredicates() bool {
+	return len(q.predicates) > 0
+}
+
+// MatchAttributes reports whether attrs match every predicate of the query.
+// The name pattern is not checked
+func (q Query) MatchAttributes(attrs Attributes, now time.Time) bool {
+	for _, p := range q.predicates {
+		if !p(attrs, now) {
+			return false
+		}
+	}
+	return true
+}
+
+// Splits words like `size>=10M` into key, operator and operand. Words with
+// unknown keys are not predicates
+func splitPredicate(word string) (string, string, string, bool) {
+	for _, key := range []string{"size", "modified", "type", "ext", "perm", "owner"} {
+		rest, found := strings.CutPrefix(word, key)
+		if !found {
+			continue
+		}
+		// Two characters operators first
+		for _, op := range []string{">=", "<=", ">", "<", "=", ":"} {
+			if operand, ok := strings.CutPrefix(rest, op); ok {
+				return key, op, operand, true
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+func newPredicate(key string, op string, operand string) (predicate, error) {
+	if operand == "" {
+		return nil, errMissingValue
+	}
+	switch key {
+	case "size":
+		return sizePredicate(op, operand)
+	case "modified":
+		return modifiedPredicate(op, operand)
+	}
+	if op != ":" && op != "=" {
+		return nil, fmt.Errorf("%s only supports ':'", key)
+	}
+	switch key {
+	case "type":
+		return typePredicate(operand)
+	case "ext":
+		return extPredicate(operand), nil
+	case "perm":
+		return permPredicate(operand)
+	default:
+		return func(attrs Attributes, _ time.Time) bool {
+			return attrs.Owner != nil && attrs.Owner() == operand
+		}, nil
+	}
+}
+
+// size>100M : larger than 100 MiB. Directories have no size and never match
+func sizePredicate(op string, operand string) (predicate, error) {
+	size, err := parseSize(operand)
+	if err != nil {
+		return nil, err
+	}
+	compare, err := comparison(op)
+	if err != nil {
+		return nil, err
+	}
+	return func(attrs Attributes, _ time.Time) bool {
+		return !attrs.IsDir && compare(attrs.Info.Size(), size)
+	}, nil
+}
+
+// modified<7d : modified less than 7 days ago. modified>7d : more than 7 days ago
+func modifiedPredicate(op string, operand string) (predicate, error) {
+	age, err := parseAge(operand)
+	if err != nil {
+		return nil, err
+	}
+	compare, err := comparison(op)
+	if err != nil {
+		return nil, err
+	}
+	return func(attrs Attributes, now time.Time) bool {
+		return compare(int64(now.Sub(attrs.Info.ModTime())), int64(age))
+	}, nil
+}
+
+func typePredicate(operand string) (predicate, error) {
+	switch operand {
+	case "dir", "d":
+		return func(attrs Attributes, _ time.Time) bool { return attrs.IsDir }, nil
+	case "file", "f":
+		return func(attrs Attributes, _ time.Time) bool {
+			return !attrs.IsDir && attrs.Info.Mode().IsRegular()
+		}, nil
+	case "symlink", "link", "l":
+		return func(attrs Attributes, _ time.Time) bool {
+			return attrs.Info.Mode()&os.ModeSymlink != 0
+		}, nil
+	default:
+		return nil, errors.New("type must be dir, file or symlink")
+	}
+}
+
+// ext:go,mod : extension among the list, ignoring case. Directories never match
+func extPredicate(operand string) predicate {
+	exts := strings.Split(strings.ToLower(operand), ",")
+	return func(attrs Attributes, _ time.Time) bool {
+		if attrs.IsDir {
+			return false
+		}
+		ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(attrs.Name), "."))
+		for _, e := range exts {
+			if strings.TrimPrefix(e, ".") == ext && ext != "" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// perm:x : executable by the owner, same for r and w, or their combinations.
+// perm:644 : exact permission bits in octal
+func permPredicate(operand string) (predicate, error) {
+	if bits, err := strconv.ParseUint(operand, 8, 32); err == nil {
+		return func(attrs Attributes, _ time.Time) bool {
+			return uint64(attrs.Info.Mode().Perm()) == bits
+		}, nil
+	}
+	var mask os.FileMode
+	for _, c := range operand {
+		switch c {
+		case 'r':
+			mask |= 0o400
+		case 'w':
+			mask |= 0o200
+		case 'x':
+			mask |= 0o100
+		default:
+			return nil, errors.New("permission must be octal, or made of r, w and x")
+		}
+	}
+	return func(attrs Attributes, _ time.Time) bool {
+		return attrs.Info.Mode().Perm()&mask == mask
+	}, nil
+}
+
+func comparison(op string) (func(a, b int64) bool, error) {
+	switch op {
+	case ">":
+		return func(a, b int64) bool { return a > b }, nil
+	case ">=":
+		return func(a, b int64) bool { return a >= b }, nil
+	case "<":
+		return func(a, b int64) bool { return a < b }, nil
+	case "<=":
+		return func(a, b int64) bool { return a <= b }, nil
+	case "=", ":":
+		return func(a, b int64) bool { return a == b }, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %s", op)
+	}
+}
+
+// Sizes like 512, 10K, 1.5M or 2GB
+func parseSize(s string) (int64, error) {
+	number := strings.TrimRight(strings.ToUpper(s), "BI")
+	multiplier := int64(1)
+	if number != "" {
+		if i := strings.IndexByte("KMGT", number[len(number)-1]); i >= 0 {
+			for range i + 1 {
+				multiplier *= sizeUnit
+			}
+			number = number[:len(number)-1]
+		}
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+// Ages like 30s, 15m, 12h, 7d or 2w
+func parseAge(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': day,
+		'w': 7 * day, //nolint:mnd // days of a week
+	}
+	unit, ok := units[s[len(s)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid duration %q, it must end with s, m, h, d or w", s)
+	}
+	value, err := strconv.ParseFloat(s[:len(s)-1], 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return time.Duration(value * float64(unit)), nil
+}
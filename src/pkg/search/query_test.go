@@ -0,0 +1,102 @@
+package search
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFileInfo struct {
+	os.FileInfo
+
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (f testFileInfo) Size() int64        { return f.size }
+func (f testFileInfo) Mode() os.FileMode  { return f.mode }
+func (f testFileInfo) ModTime() time.Time { return f.modTime }
+
+func TestParseQuery(t *testing.T) {
+	query, err := ParseQuery("  report  size>1M type:file 2024 ")
+	require.NoError(t, err)
+	assert.Equal(t, "report 2024", query.Pattern)
+	assert.True(t, query.HasPredicates())
+
+	query, err = ParseQuery("sizes.txt owner type")
+	require.NoError(t, err)
+	assert.Equal(t, "sizes.txt owner type", query.Pattern, "Words without operator are part of the name")
+	assert.False(t, query.HasPredicates())
+
+	invalidQueries := []string{
+		"size>big",
+		"modified<7",
+		"modified<7y",
+		"type:pipe",
+		"perm:z",
+		"ext:",
+		"owner>root",
+	}
+	for _, invalid := range invalidQueries {
+		_, err = ParseQuery(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMatchAttributes(t *testing.T) {
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	file := Attributes{
+		Name:  "main.GO",
+		Info:  testFileInfo{size: 2 * 1024 * 1024, mode: 0o755, modTime: now.Add(-3 * day)},
+		Owner: func() string { return "root" },
+	}
+	dir := Attributes{
+		Name:  "src",
+		IsDir: true,
+		Info:  testFileInfo{size: 4096, mode: os.ModeDir | 0o700, modTime: now.Add(-30 * day)},
+		Owner: func() string { return "user" },
+	}
+	link := Attributes{
+		Name: "latest.log",
+		Info: testFileInfo{mode: os.ModeSymlink | 0o777, modTime: now.Add(-time.Minute)},
+	}
+
+	testdata := []struct {
+		query    string
+		expected []string
+	}{
+		{"size>1M", []string{"main.GO"}},
+		{"size<=2MiB", []string{"main.GO", "latest.log"}},
+		{"size=0", []string{"latest.log"}},
+		{"modified<7d", []string{"main.GO", "latest.log"}},
+		{"modified>2w", []string{"src"}},
+		{"modified<1h", []string{"latest.log"}},
+		{"type:dir", []string{"src"}},
+		{"type:f", []string{"main.GO"}},
+		{"type:symlink", []string{"latest.log"}},
+		{"ext:go,mod", []string{"main.GO"}},
+		{"ext:.log", []string{"latest.log"}},
+		{"perm:x", []string{"main.GO", "src", "latest.log"}},
+		{"perm:rwx type:file", []string{"main.GO"}},
+		{"perm:700", []string{"src"}},
+		{"owner:root", []string{"main.GO"}},
+		{"type:dir modified<7d", []string{}},
+	}
+	for _, tt := range testdata {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := ParseQuery(tt.query)
+			require.NoError(t, err)
+			matched := []string{}
+			for _, attrs := range []Attributes{file, dir, link} {
+				if query.MatchAttributes(attrs, now) {
+					matched = append(matched, attrs.Name)
+				}
+			}
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yorukot/superfile/src/pkg/git"
+)
+
+// Results are sent in batches of this size, or sooner after walkBatchInterval,
+// so that the receiver is not woken up for every single match
+const (
+	walkBatchSize     = 256
+	walkBatchInterval = 100 * time.Millisecond
+)
+
+// ErrLimitReached is returned by Walk when it stopped at WalkOptions.Limit results
+var ErrLimitReached = errors.New("result limit reached")
+
+// WalkOptions of a recursive search
+type WalkOptions struct {
+	// Search hidden entries, and inside hidden directories
+	Hidden bool
+	// Search the entries ignored by git, they are skipped otherwise
+	Ignored bool
+	// Maximum count of results, 0 for no limit
+	Limit int
+}
+
+// Result of a recursive search
+type Result struct {
+	// Path relative to the root of the search
+	Path string
+	Info os.FileInfo
+	// Line of a content match, starting at 1. 0 for name matches
+	Line int
+	// Text of the matching line
+	Text string
+}
+
+type walker struct {
+	ctx context.Context
+	// Returns the results of an entry, at path and relPath from the root
+	visit   func(path string, relPath string, entry os.DirEntry) []Result
+	opts    WalkOptions
+	results chan<- []Result
+	batch   []Result
+	sentAt  time.Time
+	count   int
+}
+
+// Walk searches the tree under root for the entries accepted by match, and
+// sends them to results in batches, in the order of the walk. It stops when
+// ctx is done, and closes results when returning. Unreadable directories are
+// skipped. Symbolic links to directories are not followed.
+func Walk(ctx context.Context, root string, match func(entry os.DirEntry) bool, opts WalkOptions,
+	results chan<- []Result) error {
+	visit := func(_ string, relPath string, entry os.DirEntry) []Result {
+		if !match(entry) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// Removed since the directory was read
+			return nil
+		}
+		return []Result{{Path: relPath, Info: info}}
+	}
+	return walk(ctx, root, visit, opts, results)
+}
+
+func walk(ctx context.Context, root string, visit func(path string, relPath string, entry os.DirEntry) []Result,
+	opts WalkOptions, results chan<- []Result) error {
+	defer close(results)
+	w := &walker{
+		ctx:     ctx,
+		visit:   visit,
+		opts:    opts,
+		results: results,
+		sentAt:  time.Now(),
+	}
+	var status *git.Status
+	if !opts.Ignored {
+		status = repoStatus(root)
+	}
+	if err := w.walkDir(root, "", status); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+// Status of the repository containing dir, nil outside of repositories or
+// when the status cannot be loaded
+func repoStatus(dir string) *git.Status {
+	root, ok := git.FindRoot(dir)
+	if !ok {
+		return nil
+	}
+	status, err := git.GetStatus(root)
+	if err != nil {
+		slog.Debug("Cannot get git status for search, ignored files are included", "root", root, "error", err)
+		return nil
+	}
+	return status
+}
+
+// Walks dir, whose path relative to the root of the search is relPath.
+// status is the one of the innermost repository containing dir
+func (w *walker) walkDir(dir string, relPath string, status *git.Status) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		slog.Debug("Skipping unreadable directory in search", "dir", dir, "error", err)
+		return nil
+	}
+	for _, entry := range entries {
+		if err = w.ctx.Err(); err != nil {
+			return err
+		}
+		// Sparse matches are not held back until the batch is full
+		if time.Since(w.sentAt) >= walkBatchInterval {
+			if err = w.flush(); err != nil {
+				return err
+			}
+		}
+		name := entry.Name()
+		if !w.opts.Hidden && strings.HasPrefix(name, ".") {
+			continue
+		}
+		entryPath := filepath.Join(dir, name)
+		if status != nil && (name == ".git" || isIgnored(status, entryPath)) {
+			continue
+		}
+		entryRelPath := filepath.Join(relPath, name)
+		if err = w.add(w.visit(entryPath, entryRelPath, entry)); err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			continue
+		}
+		subStatus := status
+		// Nested repositories have their own ignore rules
+		if status != nil {
+			if _, err = os.Lstat(filepath.Join(entryPath, ".git")); err == nil {
+				subStatus = repoStatus(entryPath)
+			}
+		}
+		if err = w.walkDir(entryPath, entryRelPath, subStatus); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isIgnored(status *git.Status, path string) bool {
+	relPath, err := filepath.Rel(status.Root, path)
+	if err != nil {
+		return false
+	}
+	return status.Ignored(filepath.ToSlash(relPath))
+}
+
+func (w *walker) add(results []Result) error {
+	if w.opts.Limit > 0 && w.count+len(results) >= w.opts.Limit {
+		w.batch = append(w.batch, results[:w.opts.Limit-w.count]...)
+		w.count = w.opts.Limit
+		if err := w.flush(); err != nil {
+			return err
+		}
+		return ErrLimitReached
+	}
+	w.batch = append(w.batch, results...)
+	w.count += len(results)
+	if len(w.batch) >= walkBatchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Sends the pending results, unless ctx is done first
+func (w *walker) flush() error {
+	if len(w.batch) == 0 {
+		w.sentAt = time.Now()
+		return nil
+	}
+	select {
+	case w.results <- w.batch:
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+	w.batch = nil
+	w.sentAt = time.Now()
+	return nil
+}
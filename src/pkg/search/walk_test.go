@@ -0,0 +1,105 @@
+package search
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWalkTree(t *testing.T, files ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("a"), 0o644))
+	}
+	return root
+}
+
+// Runs Walk to the end and returns the paths of the results, with '/' separators
+func walkPaths(ctx context.Context, t *testing.T, root string, match func(os.DirEntry) bool,
+	opts WalkOptions) ([]string, error) {
+	t.Helper()
+	results := make(chan []Result)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Walk(ctx, root, match, opts, results)
+	}()
+	var paths []string
+	for batch := range results {
+		for _, result := range batch {
+			paths = append(paths, filepath.ToSlash(result.Path))
+		}
+	}
+	return paths, <-errCh
+}
+
+func matchSuffix(suffix string) func(os.DirEntry) bool {
+	return func(entry os.DirEntry) bool {
+		return strings.HasSuffix(entry.Name(), suffix)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	root := setupWalkTree(t, "a.go", "docs/b.md", "src/pkg/c.go", "src/d.txt", ".hidden/e.go", "src/.f.go")
+
+	t.Run("Matches at any depth", func(t *testing.T) {
+		paths, err := walkPaths(t.Context(), t, root, matchSuffix(".go"), WalkOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.go", "src/pkg/c.go"}, paths)
+	})
+
+	t.Run("Directories are matched too", func(t *testing.T) {
+		paths, err := walkPaths(t.Context(), t, root, matchSuffix("pkg"), WalkOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"src/pkg"}, paths)
+	})
+
+	t.Run("Hidden entries", func(t *testing.T) {
+		paths, err := walkPaths(t.Context(), t, root, matchSuffix(".go"), WalkOptions{Hidden: true})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{".hidden/e.go", "a.go", "src/.f.go", "src/pkg/c.go"}, paths)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		paths, err := walkPaths(t.Context(), t, root, matchSuffix(""), WalkOptions{Limit: 2})
+		require.ErrorIs(t, err, ErrLimitReached)
+		assert.Len(t, paths, 2)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		paths, err := walkPaths(ctx, t, root, matchSuffix(""), WalkOptions{})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, paths)
+	})
+}
+
+func TestWalkGitIgnore(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := setupWalkTree(t, "main.go", "debug.log", "build/out.go", "src/trace.log", "src/lib.go")
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("*.log\nbuild/\n"), 0o644))
+	require.NoError(t, exec.Command("git", "-C", root, "init").Run())
+
+	paths, err := walkPaths(t.Context(), t, root, matchSuffix(""), WalkOptions{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"main.go", "src", "src/lib.go"}, paths)
+
+	paths, err = walkPaths(t.Context(), t, filepath.Join(root, "src"), matchSuffix(""), WalkOptions{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"lib.go"}, paths, "Ignore rules apply below the repository root")
+
+	paths, err = walkPaths(t.Context(), t, root, matchSuffix(".log"), WalkOptions{Ignored: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"debug.log", "src/trace.log"}, paths)
+}
@@ -1,5 +1,7 @@
 package utils
 
+import "time"
+
 const (
 	TrueString  = "true"
 	FalseString = "false"
@@ -27,4 +29,7 @@ const (
 	SidebarSectionHome   = "home"
 	SidebarSectionPinned = "pinned"
 	SidebarSectionDisks  = "disks"
+
+	// Time given to the output of a finished command to be closed
+	commandWaitDelay = time.Second
 )
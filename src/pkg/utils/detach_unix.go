@@ -16,3 +16,8 @@ func DetachFromTerminal(cmd *exec.Cmd) {
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 }
+
+// Kill cmd, and every process of its session
+func killProcessTree(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
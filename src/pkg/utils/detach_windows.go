@@ -2,9 +2,24 @@
 
 package utils
 
-import "os/exec"
+import (
+	"errors"
+	"os/exec"
+	"strconv"
+)
 
 func DetachFromTerminal(cmd *exec.Cmd) {
 	// No-op: current Windows path uses rundll32 and returns immediately.
 	// If needed later, set CreationFlags/HideWindow via syscall.SysProcAttr.
 }
+
+// Kill cmd, and every process it started. Killing only cmd would leave the
+// programs run by powershell running
+func killProcessTree(cmd *exec.Cmd) error {
+	err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+	if err == nil {
+		return nil
+	}
+	// At least powershell is killed when taskkill fails
+	return errors.Join(err, cmd.Process.Kill())
+}
@@ -99,8 +99,8 @@ func LoadTomlFile(filePath string, defaultData string, target interface{},
 		} else {
 			fieldName = field.Name
 		}
-		// Skip open_with field as it's an optional table
-		if fieldName == "open_with" {
+		// Skip open_with and commands fields as they are optional tables
+		if fieldName == "open_with" || fieldName == "commands" {
 			continue
 		}
 		if _, exists := rawData[fieldName]; !exists {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"os/exec"
 	"runtime"
 	"time"
@@ -12,24 +14,73 @@ import (
 
 // Choose correct shell as per OS
 func ExecuteCommandInShell(timeLimit time.Duration, cmdDir string, shellCommand string) (int, string, error) {
-	// Linux and Darwin
-	baseCmd := "/bin/sh"
-	args := []string{"-c", shellCommand}
+	return ExecuteCommandInShellWithEnv(timeLimit, cmdDir, nil, shellCommand)
+}
 
-	if runtime.GOOS == OsWindows {
-		baseCmd = "powershell.exe"
-		args[0] = "-Command"
+// env is added to the environment of superfile, in "key=value" form
+func ExecuteCommandInShellWithEnv(timeLimit time.Duration, cmdDir string, env []string,
+	shellCommand string) (int, string, error) {
+	baseCmd, args := shellCommandArgs(shellCommand)
+	return executeCommandWithEnv(timeLimit, cmdDir, env, baseCmd, args...)
+}
+
+// RunCommandInShell runs shellCommand without time limit, writing its combined
+// output to output as it comes. The command, and the processes it started, are
+// killed once ctx is done. retCode is -1 if the command could not run till its end
+func RunCommandInShell(ctx context.Context, cmdDir string, env []string, shellCommand string,
+	output io.Writer) (int, error) {
+	baseCmd, args := shellCommandArgs(shellCommand)
+	cmd := exec.CommandContext(ctx, baseCmd, args...)
+	cmd.Dir = cmdDir
+	DetachFromTerminal(cmd)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Cancel = func() error {
+		return killProcessTree(cmd)
 	}
+	// Processes started in background by the command could keep the output open
+	cmd.WaitDelay = commandWaitDelay
 
-	return ExecuteCommand(timeLimit, cmdDir, baseCmd, args...)
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// Choose correct shell as per OS
+func shellCommandArgs(shellCommand string) (string, []string) {
+	if runtime.GOOS == OsWindows {
+		return "powershell.exe", []string{"-Command", shellCommand}
+	}
+	// Linux and Darwin
+	return "/bin/sh", []string{"-c", shellCommand}
 }
 
 func ExecuteCommand(timeLimit time.Duration, cmdDir string, baseCmd string, args ...string) (int, string, error) {
+	return executeCommandWithEnv(timeLimit, cmdDir, nil, baseCmd, args...)
+}
+
+func executeCommandWithEnv(timeLimit time.Duration, cmdDir string, env []string, baseCmd string,
+	args ...string) (int, string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeLimit)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, baseCmd, args...)
 	cmd.Dir = cmdDir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	DetachFromTerminal(cmd)
 	outputBytes, err := cmd.CombinedOutput()
 	retCode := -1
@@ -1,6 +1,17 @@
 package utils
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Range of all the non rune key types of bubbletea. Control keys are positive,
+// and special keys like arrows are negative
+const (
+	minTeaKeyType = tea.KeyF20
+	maxTeaKeyType = tea.KeyCtrlQuestionMark
+)
 
 func TeaRuneKeyMsg(msg string) tea.KeyMsg {
 	return tea.KeyMsg{
@@ -8,3 +19,27 @@ func TeaRuneKeyMsg(msg string) tea.KeyMsg {
 		Runes: []rune(msg),
 	}
 }
+
+// TeaKeyMsgFromString is the inverse of tea.KeyMsg.String(). Key names like
+// "enter" or "ctrl+c", "alt+" prefixes and "[...]" pastes are recognized,
+// anything else is treated as runes.
+func TeaKeyMsgFromString(key string) tea.KeyMsg {
+	var msg tea.KeyMsg
+	if rest, ok := strings.CutPrefix(key, "alt+"); ok && rest != "" {
+		msg.Alt = true
+		key = rest
+	}
+	for keyType := minTeaKeyType; keyType <= maxTeaKeyType; keyType++ {
+		if keyType != tea.KeyRunes && (tea.Key{Type: keyType}).String() == key {
+			msg.Type = keyType
+			return msg
+		}
+	}
+	msg.Type = tea.KeyRunes
+	if len(key) > 2 && strings.HasPrefix(key, "[") && strings.HasSuffix(key, "]") {
+		msg.Paste = true
+		key = key[1 : len(key)-1]
+	}
+	msg.Runes = []rune(key)
+	return msg
+}
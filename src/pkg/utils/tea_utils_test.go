@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeaKeyMsgFromString(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		expected tea.KeyMsg
+	}{
+		{"Rune", "j", TeaRuneKeyMsg("j")},
+		{"Multiple runes", "abc", TeaRuneKeyMsg("abc")},
+		{"Named key", "enter", tea.KeyMsg{Type: tea.KeyEnter}},
+		{"Control key", "ctrl+c", tea.KeyMsg{Type: tea.KeyCtrlC}},
+		{"Arrow key", "up", tea.KeyMsg{Type: tea.KeyUp}},
+		{"Space", " ", tea.KeyMsg{Type: tea.KeySpace}},
+		{"Alt rune", "alt+x", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}},
+		{"Alt named key", "alt+enter", tea.KeyMsg{Type: tea.KeyEnter, Alt: true}},
+		{"Paste", "[hello]", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("hello"), Paste: true}},
+		{"Brackets only", "[]", TeaRuneKeyMsg("[]")},
+		{"Alt prefix only", "alt+", TeaRuneKeyMsg("alt+")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := TeaKeyMsgFromString(tt.key)
+			assert.Equal(t, tt.expected, msg)
+			assert.Equal(t, tt.key, msg.String())
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"archive/zip"
+	"maps"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -55,3 +58,21 @@ func SetupFilesWithData(t *testing.T, data []byte, files ...string) {
 func SetupFiles(t *testing.T, files ...string) {
 	SetupFilesWithData(t, SampleDataBytes, files...)
 }
+
+// SetupZip writes a zip at archivePath with files, from their names to their
+// content. Entries are stored uncompressed, so their content can be found in
+// the archive, like to corrupt it
+func SetupZip(t *testing.T, archivePath string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	w := zip.NewWriter(f)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		fw, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store})
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+}
@@ -30,6 +30,6 @@ class CompressExtractTest(GenericTestImpl):
             start_dir=TESTROOT,
             test_dirs=[DIR1],
             test_files=[(FILE1, tconst.FILE_TEXT1), (FILE2, tconst.FILE_TEXT1)],
-            key_inputs=[keys.KEY_CTRL_A, keys.KEY_DOWN, keys.KEY_CTRL_E],
+            key_inputs=[keys.KEY_CTRL_A, keys.KEY_ENTER, keys.KEY_DOWN, keys.KEY_CTRL_E],
             validate_exists=[DIR1, DIR1_ZIPPED, DIR1_EXTRACTED, FILE1_EXTRACTED, FILE2_EXTRACTED]
         )